@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bkiran6398/library/internal/books/domain"
+	bookrepo "github.com/bkiran6398/library/internal/books/repository"
+	booksvc "github.com/bkiran6398/library/internal/books/service"
+	"github.com/bkiran6398/library/internal/config"
+	"github.com/bkiran6398/library/internal/db"
+	"github.com/rs/zerolog"
+)
+
+// runImport imports books from a CSV or NDJSON file (or stdin for "-") and writes the
+// per-row report to stdout as JSON. It fails when any row failed.
+func runImport(ctx context.Context, loggerInstance zerolog.Logger, dbConfig config.DBConfig, args []string) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	format := flags.String("format", "", "csv, ndjson, marc or marcxml; defaults to the file extension")
+	dryRun := flags.Bool("dry-run", false, "validate rows without saving them")
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	if flags.NArg() != 1 {
+		return fmt.Errorf("%w: import requires exactly one file, or - for stdin", errUsage)
+	}
+	path := flags.Arg(0)
+
+	importFormat, err := importFormatFor(*format, path)
+	if err != nil {
+		return err
+	}
+
+	source := io.Reader(os.Stdin)
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("open import file: %w", err)
+		}
+		defer file.Close()
+		source = file
+	}
+
+	return withDatabase(ctx, dbConfig, func(databasePool *db.Pool) error {
+		bookService := booksvc.NewService(bookrepo.NewPgRepository(databasePool))
+		report, err := bookService.Import(ctx, source, domain.ImportOptions{Format: importFormat, DryRun: *dryRun})
+		if err != nil {
+			return err
+		}
+
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return fmt.Errorf("write import report: %w", err)
+		}
+
+		loggerInstance.Info().
+			Bool("dry_run", report.DryRun).
+			Int("created", report.Created).
+			Int("updated", report.Updated).
+			Int("failed", report.Failed).
+			Msg("import completed")
+		if report.Failed > 0 {
+			return fmt.Errorf("%d rows failed to import", report.Failed)
+		}
+		return nil
+	})
+}
+
+// importFormatFor returns the explicit format, or infers it from the file extension.
+func importFormatFor(format, path string) (domain.ImportFormat, error) {
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".csv":
+			format = string(domain.ImportFormatCSV)
+		case ".ndjson", ".jsonl":
+			format = string(domain.ImportFormatNDJSON)
+		case ".mrc", ".marc":
+			format = string(domain.ImportFormatMARC)
+		case ".xml":
+			format = string(domain.ImportFormatMARCXML)
+		}
+	}
+
+	switch domain.ImportFormat(format) {
+	case domain.ImportFormatCSV, domain.ImportFormatNDJSON, domain.ImportFormatMARC, domain.ImportFormatMARCXML:
+		return domain.ImportFormat(format), nil
+	default:
+		return "", fmt.Errorf("%w: cannot determine import format, pass -format csv, ndjson, marc or marcxml", errUsage)
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/bkiran6398/library/internal/config"
+	"github.com/bkiran6398/library/internal/db"
+	"github.com/bkiran6398/library/internal/logger"
+	"github.com/rs/zerolog"
+)
+
+const usage = `Usage: library-admin <command> [arguments]
+
+Commands:
+  migrate up|down|status|redo|to <version>  manage the database schema
+  seed                                      insert sample books, skipping existing ISBNs
+  import [-format csv|ndjson|marc|marcxml] [-dry-run] <file|->
+                                            create or update books by ISBN and print a per-row report
+  purge-trash [-older-than 720h]            permanently delete API keys revoked or expired before the
+                                            cutoff, idle rate limit buckets and expired idempotency keys;
+                                            deleted keys cannot be restored
+  reindex                                   rebuild the indexes of application tables
+  check-integrity                           report schema and data consistency problems
+`
+
+// errUsage marks errors caused by invalid command line arguments.
+var errUsage = errors.New("invalid usage")
+
+func main() {
+	configuration, err := config.Load()
+	if err != nil {
+		panic(fmt.Errorf("failed to load configuration: %w", err))
+	}
+
+	loggerInstance, err := logger.New(configuration.Log.Level)
+	if err != nil {
+		panic(fmt.Errorf("failed to create logger: %w", err))
+	}
+
+	db.SetMigrationDir(configuration.DB.MigrationsDir)
+
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	err = run(ctx, loggerInstance, configuration, os.Args[1], os.Args[2:])
+	stop()
+
+	if errors.Is(err, errUsage) {
+		fmt.Fprintf(os.Stderr, "%v\n\n%s", err, usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		loggerInstance.Fatal().Err(err).Str("command", os.Args[1]).Msg("command failed")
+	}
+}
+
+// run dispatches a subcommand.
+func run(ctx context.Context, loggerInstance zerolog.Logger, configuration *config.Config, command string, args []string) error {
+	switch command {
+	case "migrate":
+		return runMigrate(ctx, loggerInstance, configuration.DB, args)
+	case "seed":
+		return withDatabase(ctx, configuration.DB, func(databasePool *db.Pool) error {
+			return runSeed(ctx, loggerInstance, databasePool)
+		})
+	case "import":
+		return runImport(ctx, loggerInstance, configuration.DB, args)
+	case "purge-trash":
+		return runPurgeTrash(ctx, loggerInstance, configuration.DB, configuration.RateLimit, args)
+	case "reindex":
+		return withDatabase(ctx, configuration.DB, func(databasePool *db.Pool) error {
+			return runReindex(ctx, loggerInstance, databasePool)
+		})
+	case "check-integrity":
+		return withDatabase(ctx, configuration.DB, func(databasePool *db.Pool) error {
+			return runCheckIntegrity(ctx, loggerInstance, databasePool)
+		})
+	case "help", "-h", "--help":
+		fmt.Print(usage)
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown command %q", errUsage, command)
+	}
+}
+
+// withDatabase connects to the database without migrating it and closes the pool afterwards.
+func withDatabase(ctx context.Context, dbConfig config.DBConfig, action func(databasePool *db.Pool) error) error {
+	databasePool, err := db.Connect(
+		ctx,
+		dbConfig.Host,
+		dbConfig.Port,
+		dbConfig.User,
+		dbConfig.Password,
+		dbConfig.Name,
+		dbConfig.SSLMode,
+		dbConfig.MaxConns,
+		dbConfig.MinConns,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer databasePool.Close()
+
+	return action(databasePool)
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	apikeyrepo "github.com/bkiran6398/library/internal/apikeys/repository"
+	"github.com/bkiran6398/library/internal/books/domain"
+	bookrepo "github.com/bkiran6398/library/internal/books/repository"
+	booksvc "github.com/bkiran6398/library/internal/books/service"
+	"github.com/bkiran6398/library/internal/config"
+	"github.com/bkiran6398/library/internal/db"
+	intErr "github.com/bkiran6398/library/internal/errors"
+	"github.com/bkiran6398/library/internal/idempotency"
+	"github.com/bkiran6398/library/internal/ratelimit"
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog"
+)
+
+const (
+	defaultTrashRetention   = 30 * 24 * time.Hour
+	rateLimitBucketIdleTime = time.Hour
+)
+
+// seedBooks is a small catalog for local development and demos.
+var seedBooks = []domain.CreateBookRequest{
+	{Title: "The Go Programming Language", Author: "Alan A. A. Donovan", ISBN: "9780134190440", PublishedYear: intPointer(2015), CopiesTotal: 3},
+	{Title: "Designing Data-Intensive Applications", Author: "Martin Kleppmann", ISBN: "9781449373320", PublishedYear: intPointer(2017), CopiesTotal: 2},
+	{Title: "Structure and Interpretation of Computer Programs", Author: "Harold Abelson", ISBN: "9780262510875", PublishedYear: intPointer(1996), CopiesTotal: 1},
+	{Title: "The Pragmatic Programmer", Author: "Andrew Hunt", ISBN: "9780135957059", PublishedYear: intPointer(2019), CopiesTotal: 4},
+	{Title: "Pride and Prejudice", Author: "Jane Austen", ISBN: "9780141439518", PublishedYear: intPointer(2002), CopiesTotal: 2},
+}
+
+// runSeed inserts seedBooks through the book service; books whose ISBN already exists are skipped.
+func runSeed(ctx context.Context, loggerInstance zerolog.Logger, databasePool *db.Pool) error {
+	bookService := booksvc.NewService(bookrepo.NewPgRepository(databasePool))
+
+	var created, skipped int
+	for _, createRequest := range seedBooks {
+		_, err := bookService.Create(ctx, createRequest)
+		switch {
+		case err == nil:
+			created++
+		case errors.Is(err, intErr.ErrConflict):
+			skipped++
+		default:
+			return fmt.Errorf("seed book %q: %w", createRequest.ISBN, err)
+		}
+	}
+
+	loggerInstance.Info().Int("created", created).Int("skipped", skipped).Msg("seed completed")
+	return nil
+}
+
+// runPurgeTrash deletes data that is no longer used: API keys revoked or expired before the
+// retention window, idle rate limit buckets and expired idempotency keys. Books are deleted outright, so they leave no trash.
+func runPurgeTrash(ctx context.Context, loggerInstance zerolog.Logger, dbConfig config.DBConfig, rateLimitConfig config.RateLimitConfig, args []string) error {
+	flags := flag.NewFlagSet("purge-trash", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	olderThan := flags.Duration("older-than", defaultTrashRetention, "retention for revoked and expired API keys")
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	if *olderThan < 0 || flags.NArg() != 0 {
+		return fmt.Errorf("%w: purge-trash accepts only a non-negative -older-than", errUsage)
+	}
+
+	return withDatabase(ctx, dbConfig, func(databasePool *db.Pool) error {
+		apiKeysDeleted, err := apikeyrepo.NewPgRepository(databasePool).PurgeInactive(ctx, time.Now().Add(-*olderThan))
+		if err != nil {
+			return err
+		}
+		bucketsDeleted, err := ratelimit.NewPgStore(databasePool).DeleteIdle(ctx, max(rateLimitBucketIdleTime, rateLimitConfig.LongestRefillTime()))
+		if err != nil {
+			return err
+		}
+		idempotencyKeysDeleted, err := idempotency.NewPgStore(databasePool).DeleteExpired(ctx)
+		if err != nil {
+			return err
+		}
+
+		loggerInstance.Info().
+			Int64("api_keys_deleted", apiKeysDeleted).
+			Int64("rate_limit_buckets_deleted", bucketsDeleted).
+			Int64("idempotency_keys_deleted", idempotencyKeysDeleted).
+			Msg("purge-trash completed")
+		return nil
+	})
+}
+
+// runReindex rebuilds the indexes of every application table without blocking writes.
+func runReindex(ctx context.Context, loggerInstance zerolog.Logger, databasePool *db.Pool) error {
+	tables, err := applicationTables(ctx, databasePool)
+	if err != nil {
+		return err
+	}
+	for _, table := range tables {
+		startedAt := time.Now()
+		if _, err := databasePool.Exec(ctx, "REINDEX TABLE CONCURRENTLY "+pgx.Identifier{table}.Sanitize()); err != nil {
+			return fmt.Errorf("reindex %s: %w", table, err)
+		}
+		loggerInstance.Info().Str("table", table).Dur("duration_ms", time.Since(startedAt)).Msg("table reindexed")
+	}
+	return nil
+}
+
+// applicationTables lists the tables in the current schema, except goose's version table,
+// so tables added by new migrations are picked up without changes here.
+func applicationTables(ctx context.Context, databasePool *db.Pool) ([]string, error) {
+	const tablesQuery = `
+SELECT tablename FROM pg_tables
+WHERE schemaname = current_schema() AND tablename <> 'goose_db_version'
+ORDER BY tablename;`
+	rows, err := databasePool.Query(ctx, tablesQuery)
+	if err != nil {
+		return nil, fmt.Errorf("list application tables: %w", err)
+	}
+	tables, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("list application tables: %w", err)
+	}
+	return tables, nil
+}
+
+// integrityCheck is a query counting rows that violate an invariant.
+type integrityCheck struct {
+	name  string
+	query string
+}
+
+var integrityChecks = []integrityCheck{
+	{
+		name:  "invalid_indexes",
+		query: `SELECT COUNT(*) FROM pg_index WHERE NOT indisvalid OR NOT indisready;`,
+	},
+	{
+		name: "unvalidated_constraints",
+		query: `
+SELECT COUNT(*) FROM pg_constraint c
+JOIN pg_namespace n ON n.oid = c.connamespace
+WHERE n.nspname = current_schema() AND NOT c.convalidated;`,
+	},
+	{
+		name:  "book_copy_counts",
+		query: `SELECT COUNT(*) FROM books WHERE copies_total < 0 OR copies_available < 0 OR copies_available > copies_total;`,
+	},
+	{
+		name: "duplicate_isbns",
+		query: `
+SELECT COUNT(*) FROM (
+	SELECT 1 FROM books
+	GROUP BY regexp_replace(upper(isbn), '[^0-9X]', '', 'g')
+	HAVING COUNT(*) > 1
+) duplicates;`,
+	},
+}
+
+// runCheckIntegrity runs every integrity check plus the schema version check and fails if any
+// of them find a problem.
+func runCheckIntegrity(ctx context.Context, loggerInstance zerolog.Logger, databasePool *db.Pool) error {
+	failed := 0
+
+	if err := db.MigrationVersionCheck(databasePool)(ctx); err != nil {
+		loggerInstance.Error().Err(err).Str("check", "schema_version").Msg("integrity check failed")
+		failed++
+	}
+
+	for _, check := range integrityChecks {
+		var violations int64
+		if err := databasePool.QueryRow(ctx, check.query).Scan(&violations); err != nil {
+			return fmt.Errorf("run integrity check %s: %w", check.name, err)
+		}
+		if violations > 0 {
+			loggerInstance.Error().Str("check", check.name).Int64("violations", violations).Msg("integrity check failed")
+			failed++
+			continue
+		}
+		loggerInstance.Info().Str("check", check.name).Msg("integrity check passed")
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d integrity checks failed", failed)
+	}
+	return nil
+}
+
+func intPointer(value int) *int {
+	return &value
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/bkiran6398/library/internal/config"
+	"github.com/bkiran6398/library/internal/db"
+	"github.com/rs/zerolog"
+)
+
+// runMigrate executes one of the migrate subcommands: up, down, status, redo or to <version>.
+func runMigrate(ctx context.Context, loggerInstance zerolog.Logger, dbConfig config.DBConfig, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: migrate requires a subcommand", errUsage)
+	}
+
+	migrate, err := migrateAction(args[0], args[1:])
+	if err != nil {
+		return err
+	}
+
+	migrator, err := db.NewMigrator(dbConfig.Host, dbConfig.Port, dbConfig.User, dbConfig.Password, dbConfig.Name, dbConfig.SSLMode)
+	if err != nil {
+		return err
+	}
+	defer migrator.Close()
+
+	if err := migrate(migrator, ctx); err != nil {
+		return err
+	}
+
+	version, err := migrator.Version(ctx)
+	if err != nil {
+		return err
+	}
+	loggerInstance.Info().Str("subcommand", args[0]).Int64("schema_version", version).Msg("migrate completed")
+	return nil
+}
+
+// migrateAction resolves a migrate subcommand and its arguments to a Migrator call.
+func migrateAction(subcommand string, args []string) (func(migrator *db.Migrator, ctx context.Context) error, error) {
+	if subcommand == "to" {
+		if len(args) != 1 {
+			return nil, fmt.Errorf("%w: migrate to requires a version", errUsage)
+		}
+		version, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil || version < 0 {
+			return nil, fmt.Errorf("%w: invalid migration version %q", errUsage, args[0])
+		}
+		return func(migrator *db.Migrator, ctx context.Context) error {
+			return migrator.To(ctx, version)
+		}, nil
+	}
+
+	if len(args) != 0 {
+		return nil, fmt.Errorf("%w: migrate %s takes no arguments", errUsage, subcommand)
+	}
+	switch subcommand {
+	case "up":
+		return (*db.Migrator).Up, nil
+	case "down":
+		return (*db.Migrator).Down, nil
+	case "redo":
+		return (*db.Migrator).Redo, nil
+	case "status":
+		return (*db.Migrator).Status, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown migrate subcommand %q", errUsage, subcommand)
+	}
+}
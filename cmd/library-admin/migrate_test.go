@@ -0,0 +1,38 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateAction(t *testing.T) {
+	tests := []struct {
+		name       string
+		subcommand string
+		args       []string
+		wantErr    bool
+	}{
+		{name: "up", subcommand: "up"},
+		{name: "down", subcommand: "down"},
+		{name: "redo", subcommand: "redo"},
+		{name: "status", subcommand: "status"},
+		{name: "to version", subcommand: "to", args: []string{"20251112100347"}},
+		{name: "to without version", subcommand: "to", wantErr: true},
+		{name: "to invalid version", subcommand: "to", args: []string{"latest"}, wantErr: true},
+		{name: "unexpected argument", subcommand: "up", args: []string{"1"}, wantErr: true},
+		{name: "unknown subcommand", subcommand: "reset", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			action, err := migrateAction(test.subcommand, test.args)
+			if test.wantErr {
+				require.ErrorIs(t, err, errUsage)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, action)
+		})
+	}
+}
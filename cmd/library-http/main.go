@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
@@ -10,17 +11,44 @@ import (
 	"syscall"
 	"time"
 
+	apikeyhttp "github.com/bkiran6398/library/internal/apikeys/http"
+	apikeyrepo "github.com/bkiran6398/library/internal/apikeys/repository"
+	apikeysvc "github.com/bkiran6398/library/internal/apikeys/service"
+	"github.com/bkiran6398/library/internal/auth"
+	authorhttp "github.com/bkiran6398/library/internal/authors/http"
+	authorrepo "github.com/bkiran6398/library/internal/authors/repository"
+	authorsvc "github.com/bkiran6398/library/internal/authors/service"
 	bookhttp "github.com/bkiran6398/library/internal/books/http"
+	"github.com/bkiran6398/library/internal/books/metadata"
 	bookrepo "github.com/bkiran6398/library/internal/books/repository"
 	booksvc "github.com/bkiran6398/library/internal/books/service"
+	branchhttp "github.com/bkiran6398/library/internal/branches/http"
+	branchrepo "github.com/bkiran6398/library/internal/branches/repository"
+	branchsvc "github.com/bkiran6398/library/internal/branches/service"
 	"github.com/bkiran6398/library/internal/config"
 	"github.com/bkiran6398/library/internal/db"
+	"github.com/bkiran6398/library/internal/health"
+	"github.com/bkiran6398/library/internal/http/middleware"
+	"github.com/bkiran6398/library/internal/http/response"
 	"github.com/bkiran6398/library/internal/http/router"
+	"github.com/bkiran6398/library/internal/idempotency"
 	"github.com/bkiran6398/library/internal/logger"
+	"github.com/bkiran6398/library/internal/metrics"
+	"github.com/bkiran6398/library/internal/ratelimit"
+	subjecthttp "github.com/bkiran6398/library/internal/subjects/http"
+	subjectrepo "github.com/bkiran6398/library/internal/subjects/repository"
+	subjectsvc "github.com/bkiran6398/library/internal/subjects/service"
+	"github.com/bkiran6398/library/internal/tracing"
 	"github.com/rs/zerolog"
 )
 
-const shutdownTimeout = 10 * time.Second
+const (
+	shutdownTimeout            = 10 * time.Second
+	rateLimitJanitorInterval   = 10 * time.Minute
+	rateLimitJanitorTimeout    = 30 * time.Second
+	idempotencyJanitorInterval = 10 * time.Minute
+	idempotencyJanitorTimeout  = 30 * time.Second
+)
 
 func main() {
 	configuration, err := config.Load()
@@ -34,29 +62,134 @@ func main() {
 	}
 	loggerInstance.Info().Msg("starting library API")
 
-	databasePool, err := initializeDatabase(context.Background(), loggerInstance, configuration.DB)
+	errorConfig, err := initializeErrorConfig(configuration.Server)
+	if err != nil {
+		loggerInstance.Fatal().Err(err).Msg("invalid server configuration")
+	}
+
+	tracingProvider, err := initializeTracing(context.Background(), configuration.Tracing)
+	if err != nil {
+		loggerInstance.Fatal().Err(err).Msg("failed to initialize tracing")
+	}
+	defer shutdownTracing(loggerInstance, tracingProvider)
+
+	databasePool, err := initializeDatabase(context.Background(), loggerInstance, configuration.DB, tracingProvider)
 	if err != nil {
 		loggerInstance.Fatal().Err(err).Msg("failed to initialize database")
 	}
 	defer databasePool.Close()
 
-	bookHandler := initializeBookHandler(databasePool)
+	bookHandler := initializeBookHandler(databasePool, tracingProvider, configuration.Metadata)
+	metricsRegistry := initializeMetrics(databasePool)
+	readiness := initializeReadiness(configuration.Health, databasePool)
+	apiKeyService := initializeAPIKeyService(databasePool)
+
+	authenticators, err := initializeAuthenticators(context.Background(), configuration.Auth, apiKeyService)
+	if err != nil {
+		loggerInstance.Fatal().Err(err).Msg("failed to initialize authentication")
+	}
+
+	rateLimitStore, err := initializeRateLimitStore(loggerInstance, configuration.RateLimit, databasePool)
+	if err != nil {
+		loggerInstance.Fatal().Err(err).Msg("failed to initialize rate limiting")
+	}
+
+	idempotencyStore, err := initializeIdempotencyStore(loggerInstance, configuration.Idempotency, databasePool)
+	if err != nil {
+		loggerInstance.Fatal().Err(err).Msg("failed to initialize idempotency keys")
+	}
 
-	routeHandler := initializeHTTPRouter(loggerInstance, configuration.Server.CORSAllowedOrigins, bookHandler)
+	routeHandler := initializeHTTPRouter(
+		loggerInstance,
+		configuration,
+		errorConfig,
+		authenticators,
+		initializePolicy(configuration.Auth),
+		rateLimitStore,
+		idempotencyStore,
+		metricsRegistry,
+		tracingProvider,
+		readiness,
+		router.Handlers{
+			Books:    bookHandler,
+			Authors:  initializeAuthorHandler(databasePool),
+			Subjects: initializeSubjectHandler(databasePool),
+			Branches: initializeBranchHandler(databasePool),
+			APIKeys:  apikeyhttp.NewHandler(apiKeyService),
+		},
+	)
 
 	server := startHTTPServer(
 		loggerInstance,
 		routeHandler,
 		configuration.Server.Port,
 	)
+	servers := []*http.Server{server}
+
+	if configuration.Metrics.Enabled && configuration.Metrics.AdminPort != 0 {
+		adminServer := startHTTPServer(
+			loggerInstance,
+			initializeAdminRouter(configuration.Metrics.Path, metricsRegistry),
+			configuration.Metrics.AdminPort,
+		)
+		servers = append(servers, adminServer)
+	}
 
 	<-waitForShutdownSignal()
-	shutdownServer(loggerInstance, server)
+	drainTraffic(loggerInstance, readiness, configuration.Server.ShutdownDrainDelay)
+	for _, server := range servers {
+		shutdownServer(loggerInstance, server)
+	}
 }
 
-// initializeDatabase connects to the database and runs migrations.
-func initializeDatabase(ctx context.Context, loggerInstance zerolog.Logger, dbConfig config.DBConfig) (*db.Pool, error) {
-	databasePool, err := db.ConnectAndMigrate(
+// initializeErrorConfig validates server.error_format so a typo fails startup instead of
+// silently rendering JSON errors.
+func initializeErrorConfig(serverConfig config.ServerConfig) (router.ErrorConfig, error) {
+	format := response.ErrorFormat(serverConfig.ErrorFormat)
+	switch format {
+	case response.ErrorFormatJSON, response.ErrorFormatProblem:
+	default:
+		return router.ErrorConfig{}, fmt.Errorf("unknown server.error_format %q", serverConfig.ErrorFormat)
+	}
+	return router.ErrorConfig{Format: format, ProblemTypeBaseURI: serverConfig.ProblemTypeBaseURI}, nil
+}
+
+// initializeTracing creates the tracer provider; it is a no-op when tracing is disabled.
+func initializeTracing(ctx context.Context, tracingConfig config.TracingConfig) (*tracing.Provider, error) {
+	return tracing.NewProvider(ctx, tracing.Config{
+		Enabled:     tracingConfig.Enabled,
+		Endpoint:    tracingConfig.Endpoint,
+		Insecure:    tracingConfig.Insecure,
+		ServiceName: tracingConfig.ServiceName,
+		SampleRatio: tracingConfig.SampleRatio,
+	})
+}
+
+// shutdownTracing flushes buffered spans before the process exits.
+func shutdownTracing(logger zerolog.Logger, tracingProvider *tracing.Provider) {
+	shutdownContext, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := tracingProvider.Shutdown(shutdownContext); err != nil {
+		logger.Error().Err(err).Msg("tracing shutdown error")
+	}
+}
+
+// initializeDatabase connects to the database and, depending on db.migration_mode, either
+// runs migrations or waits for a separate job to bring the schema up to date.
+func initializeDatabase(ctx context.Context, loggerInstance zerolog.Logger, dbConfig config.DBConfig, tracingProvider *tracing.Provider) (*db.Pool, error) {
+	db.SetMigrationDir(dbConfig.MigrationsDir)
+
+	var connect func(context.Context, string, int, string, string, string, string, int32, int32, ...db.Option) (*db.Pool, error)
+	switch dbConfig.MigrationMode {
+	case config.MigrationModeMigrate:
+		connect = db.ConnectAndMigrate
+	case config.MigrationModeWait:
+		connect = db.Connect
+	default:
+		return nil, fmt.Errorf("unknown db.migration_mode %q", dbConfig.MigrationMode)
+	}
+
+	databasePool, err := connect(
 		ctx,
 		dbConfig.Host,
 		dbConfig.Port,
@@ -66,30 +199,286 @@ func initializeDatabase(ctx context.Context, loggerInstance zerolog.Logger, dbCo
 		dbConfig.SSLMode,
 		dbConfig.MaxConns,
 		dbConfig.MinConns,
+		db.WithQueryTracer(db.NewQueryTracer(tracingProvider.Tracer())),
+		db.WithQueryTracer(db.NewQueryLogger(loggerInstance, db.QueryLoggerConfig{
+			SlowThreshold: dbConfig.SlowQueryThreshold,
+			SampleRate:    dbConfig.QueryLogSampleRate,
+			RequestID:     middleware.GetRequestID,
+		})),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
-	loggerInstance.Info().Msg("database connected and migrations completed")
+
+	if dbConfig.MigrationMode == config.MigrationModeWait {
+		loggerInstance.Info().Msg("waiting for database schema to be migrated")
+		waitContext, cancel := context.WithTimeout(ctx, dbConfig.SchemaWaitTimeout)
+		defer cancel()
+		if err := db.WaitForSchema(waitContext, databasePool); err != nil {
+			databasePool.Close()
+			return nil, err
+		}
+	}
+
+	loggerInstance.Info().Str("migration_mode", dbConfig.MigrationMode).Msg("database connected and schema up to date")
 	return databasePool, nil
 }
 
 // initializeBookHandler creates and wires up the book handler with its dependencies.
-func initializeBookHandler(databasePool *db.Pool) bookhttp.Handler {
+func initializeBookHandler(databasePool *db.Pool, tracingProvider *tracing.Provider, metadataConfig config.MetadataConfig) bookhttp.Handler {
 	bookRepo := bookrepo.NewPgRepository(databasePool)
-	bookSvc := booksvc.NewService(bookRepo)
+	var options []booksvc.Option
+	if metadataConfig.Enabled {
+		provider := metadata.NewCache(
+			metadata.NewOpenLibrary(metadataConfig.BaseURL, metadataConfig.Timeout),
+			metadataConfig.CacheTTL,
+			metadataConfig.CacheSize,
+		)
+		options = append(options, booksvc.WithMetadataProvider(provider, metadataConfig.AutoEnrich))
+	}
+	bookSvc := booksvc.NewTracingService(booksvc.NewService(bookRepo, options...), tracingProvider.Tracer())
 	return bookhttp.NewHandler(bookSvc)
 }
 
+// initializeMetrics creates the Prometheus registry with pool and catalog collectors.
+func initializeMetrics(databasePool *db.Pool) *metrics.Registry {
+	return metrics.NewRegistry(databasePool, bookrepo.NewPgRepository(databasePool))
+}
+
+// initializeReadiness registers the dependency checks reported by /readyz.
+func initializeReadiness(healthConfig config.HealthConfig, databasePool *db.Pool) *health.Checker {
+	readiness := health.NewChecker(healthConfig.CheckTimeout)
+	readiness.Register("database", db.PingCheck(databasePool))
+	readiness.Register("database_pool", db.PoolSaturationCheck(databasePool, healthConfig.PoolSaturationThreshold))
+	readiness.Register("schema_version", db.MigrationVersionCheck(databasePool))
+	return readiness
+}
+
+// initializeAdminRouter serves metrics on the separate admin listener.
+func initializeAdminRouter(metricsPath string, metricsRegistry *metrics.Registry) http.Handler {
+	adminMux := http.NewServeMux()
+	adminMux.Handle("GET "+metricsPath, metricsRegistry.Handler())
+	return adminMux
+}
+
+// initializeAuthorHandler creates and wires up the author handler with its dependencies.
+func initializeAuthorHandler(databasePool *db.Pool) authorhttp.Handler {
+	return authorhttp.NewHandler(authorsvc.NewService(authorrepo.NewPgRepository(databasePool)))
+}
+
+// initializeSubjectHandler creates and wires up the subject handler with its dependencies.
+func initializeSubjectHandler(databasePool *db.Pool) subjecthttp.Handler {
+	return subjecthttp.NewHandler(subjectsvc.NewService(subjectrepo.NewPgRepository(databasePool)))
+}
+
+// initializeAPIKeyService creates the API key service with its dependencies.
+func initializeAPIKeyService(databasePool *db.Pool) apikeysvc.Service {
+	return apikeysvc.NewService(apikeyrepo.NewPgRepository(databasePool))
+}
+
+// initializeAuthenticators builds the Authorization scheme handlers enabled in the configuration.
+func initializeAuthenticators(ctx context.Context, authConfig config.AuthConfig, apiKeyService apikeysvc.Service) (map[string]middleware.Authenticator, error) {
+	if !authConfig.Enabled {
+		return nil, nil
+	}
+
+	authenticators := map[string]middleware.Authenticator{}
+	if authConfig.APIKeys.Enabled {
+		authenticators["ApiKey"] = apiKeyService
+	}
+
+	jwtSettings := authConfig.JWT
+	if jwtSettings.HMACSecret != "" || jwtSettings.JWKSFile != "" || jwtSettings.JWKSURL != "" {
+		jwtVerifier, err := initializeJWTVerifier(ctx, jwtSettings)
+		if err != nil {
+			return nil, err
+		}
+		authenticators["Bearer"] = jwtVerifier
+	}
+
+	if len(authenticators) == 0 {
+		return nil, errors.New("auth is enabled but neither JWT keys nor API keys are configured")
+	}
+	return authenticators, nil
+}
+
+// initializePolicy builds the role-based access policy, falling back to auth.DefaultRoles.
+// Authorization is only enforced when authentication is enabled, since anonymous
+// requests carry no roles.
+func initializePolicy(authConfig config.AuthConfig) *auth.Policy {
+	if !authConfig.Enabled {
+		return nil
+	}
+	if len(authConfig.Roles) == 0 {
+		return auth.NewPolicy(auth.DefaultRoles)
+	}
+	return auth.NewPolicy(authConfig.Roles)
+}
+
+// initializeJWTVerifier creates a bearer token verifier from the JWT configuration.
+func initializeJWTVerifier(ctx context.Context, jwtSettings config.JWTConfig) (*auth.JWTVerifier, error) {
+	jwtConfig := auth.JWTConfig{
+		Issuer:     jwtSettings.Issuer,
+		Audience:   jwtSettings.Audience,
+		HMACSecret: []byte(jwtSettings.HMACSecret),
+		RolesClaim: jwtSettings.RolesClaim,
+	}
+
+	var err error
+	switch {
+	case jwtSettings.JWKSFile != "":
+		jwtConfig.KeySet, err = auth.NewFileKeySet(jwtSettings.JWKSFile)
+	case jwtSettings.JWKSURL != "":
+		jwtConfig.KeySet, err = auth.NewURLKeySet(ctx, jwtSettings.JWKSURL)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load jwks: %w", err)
+	}
+
+	jwtVerifier, err := auth.NewJWTVerifier(jwtConfig)
+	if err != nil {
+		return nil, fmt.Errorf("create jwt verifier: %w", err)
+	}
+
+	return jwtVerifier, nil
+}
+
+// initializeRateLimitStore returns the configured rate limit store, or nil when rate limiting is disabled.
+// The Postgres store gets a background janitor that removes idle buckets.
+func initializeRateLimitStore(loggerInstance zerolog.Logger, rateLimitConfig config.RateLimitConfig, databasePool *db.Pool) (ratelimit.Store, error) {
+	if !rateLimitConfig.Enabled {
+		return nil, nil
+	}
+
+	switch rateLimitConfig.Store {
+	case "memory":
+		return ratelimit.NewMemoryStore(), nil
+	case "postgres":
+		store := ratelimit.NewPgStore(databasePool)
+		go runRateLimitJanitor(loggerInstance, store, max(rateLimitJanitorInterval, rateLimitConfig.LongestRefillTime()))
+		return store, nil
+	default:
+		return nil, fmt.Errorf("unknown rate limit store %q", rateLimitConfig.Store)
+	}
+}
+
+// runRateLimitJanitor periodically deletes Postgres rate limit buckets idle for longer than idleFor.
+func runRateLimitJanitor(loggerInstance zerolog.Logger, store *ratelimit.PgStore, idleFor time.Duration) {
+	ticker := time.NewTicker(rateLimitJanitorInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		deleteContext, cancel := context.WithTimeout(context.Background(), rateLimitJanitorTimeout)
+		if _, err := store.DeleteIdle(deleteContext, idleFor); err != nil {
+			loggerInstance.Warn().Err(err).Msg("failed to delete idle rate limit buckets")
+		}
+		cancel()
+	}
+}
+
+// initializeIdempotencyStore returns the configured idempotency key store, or nil when
+// Idempotency-Key handling is disabled. The Postgres store gets a background janitor that
+// removes expired keys.
+func initializeIdempotencyStore(loggerInstance zerolog.Logger, idempotencyConfig config.IdempotencyConfig, databasePool *db.Pool) (idempotency.Store, error) {
+	if !idempotencyConfig.Enabled {
+		return nil, nil
+	}
+	if idempotencyConfig.TTL <= 0 {
+		return nil, fmt.Errorf("idempotency ttl must be positive, got %s", idempotencyConfig.TTL)
+	}
+	if idempotencyConfig.Lease <= 0 || idempotencyConfig.Lease > idempotencyConfig.TTL {
+		return nil, fmt.Errorf("idempotency lease must be positive and at most the ttl, got %s", idempotencyConfig.Lease)
+	}
+	if idempotencyConfig.MaxRequestBytes <= 0 || idempotencyConfig.MaxResponseBytes <= 0 {
+		return nil, fmt.Errorf("idempotency max_request_bytes and max_response_bytes must be positive, got %d and %d", idempotencyConfig.MaxRequestBytes, idempotencyConfig.MaxResponseBytes)
+	}
+
+	switch idempotencyConfig.Store {
+	case "memory":
+		return idempotency.NewMemoryStore(), nil
+	case "postgres":
+		store := idempotency.NewPgStore(databasePool)
+		go runIdempotencyJanitor(loggerInstance, store)
+		return store, nil
+	default:
+		return nil, fmt.Errorf("unknown idempotency store %q", idempotencyConfig.Store)
+	}
+}
+
+// runIdempotencyJanitor periodically deletes expired Postgres idempotency keys.
+func runIdempotencyJanitor(loggerInstance zerolog.Logger, store *idempotency.PgStore) {
+	ticker := time.NewTicker(idempotencyJanitorInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		deleteContext, cancel := context.WithTimeout(context.Background(), idempotencyJanitorTimeout)
+		if _, err := store.DeleteExpired(deleteContext); err != nil {
+			loggerInstance.Warn().Err(err).Msg("failed to delete expired idempotency keys")
+		}
+		cancel()
+	}
+}
+
 // initializeHTTPRouter creates and configures the HTTP router with all routes and middleware.
-func initializeHTTPRouter(loggerInstance zerolog.Logger, allowedOrigins []string, bookHandler bookhttp.Handler) http.Handler {
+func initializeHTTPRouter(
+	loggerInstance zerolog.Logger,
+	configuration *config.Config,
+	errorConfig router.ErrorConfig,
+	authenticators map[string]middleware.Authenticator,
+	policy *auth.Policy,
+	rateLimitStore ratelimit.Store,
+	idempotencyStore idempotency.Store,
+	metricsRegistry *metrics.Registry,
+	tracingProvider *tracing.Provider,
+	readiness *health.Checker,
+	handlers router.Handlers,
+) http.Handler {
+	serverConfig := configuration.Server
+	rateLimitConfig := configuration.RateLimit
 	return router.NewRouter(
 		loggerInstance,
-		router.CORSConfig{AllowedOrigins: allowedOrigins},
-		bookHandler,
+		router.CORSConfig{AllowedOrigins: serverConfig.CORSAllowedOrigins},
+		errorConfig,
+		router.AuthConfig{Authenticators: authenticators, Policy: policy},
+		router.RateLimitConfig{
+			Store: rateLimitStore,
+			Limits: middleware.RateLimitConfig{
+				Read:              ratelimit.Limit{Rate: rateLimitConfig.Read.RequestsPerSecond, Burst: rateLimitConfig.Read.Burst},
+				Write:             ratelimit.Limit{Rate: rateLimitConfig.Write.RequestsPerSecond, Burst: rateLimitConfig.Write.Burst},
+				PerIP:             ratelimit.Limit{Rate: rateLimitConfig.PerIP.RequestsPerSecond, Burst: rateLimitConfig.PerIP.Burst},
+				TrustForwardedFor: rateLimitConfig.TrustForwardedFor,
+			},
+		},
+		router.IdempotencyConfig{
+			Store: idempotencyStore,
+			Config: middleware.IdempotencyConfig{
+				TTL:               configuration.Idempotency.TTL,
+				Lease:             configuration.Idempotency.Lease,
+				MaxRequestBytes:   configuration.Idempotency.MaxRequestBytes,
+				MaxResponseBytes:  configuration.Idempotency.MaxResponseBytes,
+				TrustForwardedFor: rateLimitConfig.TrustForwardedFor,
+			},
+		},
+		buildRouterMetricsConfig(configuration.Metrics, metricsRegistry),
+		router.TracingConfig{Tracer: tracingProvider.Tracer(), Propagator: tracingProvider.Propagator},
+		readiness,
+		handlers,
 	)
 }
 
+// buildRouterMetricsConfig instruments the router when metrics are enabled. The scrape
+// endpoint is served on the admin listener when an admin port is set, and on the API
+// router otherwise.
+func buildRouterMetricsConfig(metricsConfig config.MetricsConfig, metricsRegistry *metrics.Registry) router.MetricsConfig {
+	if !metricsConfig.Enabled {
+		return router.MetricsConfig{}
+	}
+	if metricsConfig.AdminPort != 0 {
+		return router.MetricsConfig{HTTP: metricsRegistry.HTTP}
+	}
+	return router.MetricsConfig{HTTP: metricsRegistry.HTTP, Handler: metricsRegistry.Handler(), Path: metricsConfig.Path}
+}
+
 // startHTTPServer starts the HTTP server in a goroutine and returns it.
 func startHTTPServer(logger zerolog.Logger, handler http.Handler, port int) *http.Server {
 	server := &http.Server{
@@ -114,6 +503,17 @@ func waitForShutdownSignal() <-chan os.Signal {
 	return shutdownSignal
 }
 
+// drainTraffic fails readiness and waits so load balancers stop routing new requests
+// before the servers stop accepting connections.
+func drainTraffic(logger zerolog.Logger, readiness *health.Checker, drainDelay time.Duration) {
+	readiness.MarkShuttingDown()
+	if drainDelay <= 0 {
+		return
+	}
+	logger.Info().Dur("drain_delay", drainDelay).Msg("readiness failing, draining traffic")
+	time.Sleep(drainDelay)
+}
+
 // shutdownServer gracefully shuts down the HTTP server.
 func shutdownServer(logger zerolog.Logger, server *http.Server) {
 	logger.Info().Msg("shutting down server...")
@@ -126,3 +526,8 @@ func shutdownServer(logger zerolog.Logger, server *http.Server) {
 	}
 	logger.Info().Msg("server stopped")
 }
+
+// initializeBranchHandler creates and wires up the branch handler with its dependencies.
+func initializeBranchHandler(databasePool *db.Pool) branchhttp.Handler {
+	return branchhttp.NewHandler(branchsvc.NewService(branchrepo.NewPgRepository(databasePool)))
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIKey represents a long-lived credential for service-to-service clients.
+// Only a hash of the secret is ever stored.
+type APIKey struct {
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	SecretHash []byte     `json:"-"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// IsActive reports whether the key can still be used at the given time.
+func (apiKey APIKey) IsActive(now time.Time) bool {
+	if apiKey.RevokedAt != nil {
+		return false
+	}
+	return apiKey.ExpiresAt == nil || now.Before(*apiKey.ExpiresAt)
+}
+
+// IssuedAPIKey is returned once when a key is created or rotated.
+// Key holds the plaintext credential and cannot be retrieved again.
+type IssuedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+// CreateAPIKeyRequest represents the request payload for creating a new API key.
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name" validate:"required,min=1"`
+	Scopes    []string   `json:"scopes" validate:"dive,required"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
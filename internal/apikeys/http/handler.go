@@ -0,0 +1,81 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/bkiran6398/library/internal/apikeys/domain"
+	"github.com/bkiran6398/library/internal/apikeys/service"
+	"github.com/bkiran6398/library/internal/http/response"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// Handler handles HTTP requests for API key administration.
+type Handler struct {
+	service service.Service
+}
+
+// NewHandler creates a new Handler instance.
+func NewHandler(service service.Service) Handler {
+	return Handler{service: service}
+}
+
+func (handler Handler) Create(w http.ResponseWriter, r *http.Request) {
+	var createRequest domain.CreateAPIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&createRequest); err != nil {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Invalid JSON body", nil)
+		return
+	}
+
+	issuedKey, err := handler.service.Create(r.Context(), createRequest)
+	if err != nil {
+		response.MapServiceErrorToHTTP(w, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, issuedKey)
+}
+
+func (handler Handler) List(w http.ResponseWriter, r *http.Request) {
+	apiKeys, err := handler.service.List(r.Context())
+	if err != nil {
+		response.MapServiceErrorToHTTP(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, apiKeys)
+}
+
+func (handler Handler) Rotate(w http.ResponseWriter, r *http.Request) {
+	apiKeyID, err := parseAPIKeyIDFromPath(r)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Invalid API key ID", nil)
+		return
+	}
+
+	issuedKey, err := handler.service.Rotate(r.Context(), apiKeyID)
+	if err != nil {
+		response.MapServiceErrorToHTTP(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, issuedKey)
+}
+
+func (handler Handler) Revoke(w http.ResponseWriter, r *http.Request) {
+	apiKeyID, err := parseAPIKeyIDFromPath(r)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Invalid API key ID", nil)
+		return
+	}
+
+	apiKey, err := handler.service.Revoke(r.Context(), apiKeyID)
+	if err != nil {
+		response.MapServiceErrorToHTTP(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, apiKey)
+}
+
+// parseAPIKeyIDFromPath extracts and parses the API key ID from the request path.
+func parseAPIKeyIDFromPath(r *http.Request) (uuid.UUID, error) {
+	return uuid.Parse(mux.Vars(r)["id"])
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bkiran6398/library/internal/apikeys/domain"
+	intErr "github.com/bkiran6398/library/internal/errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const apiKeyColumns = `id, name, prefix, secret_hash, scopes, expires_at, last_used_at, revoked_at, created_at, updated_at`
+
+// lastUsedResolution bounds how often last_used_at is written for a busy key.
+const lastUsedResolution = "1 minute"
+
+// pgRepository is the PostgreSQL implementation of Repository.
+type pgRepository struct {
+	dbPool *pgxpool.Pool
+}
+
+// NewPgRepository creates a new PostgreSQL-based Repository implementation.
+// This constructor is the only place where consumers should depend on the concrete type.
+func NewPgRepository(dbPool *pgxpool.Pool) *pgRepository {
+	return &pgRepository{dbPool: dbPool}
+}
+
+func (repository *pgRepository) Create(ctx context.Context, apiKey domain.APIKey) (domain.APIKey, error) {
+	const insertQuery = `
+INSERT INTO api_keys (id, name, prefix, secret_hash, scopes, expires_at, created_at, updated_at)
+VALUES ($1,$2,$3,$4,$5,$6,NOW(),NOW())
+RETURNING created_at, updated_at;
+`
+	row := repository.dbPool.QueryRow(ctx, insertQuery, apiKey.ID, apiKey.Name, apiKey.Prefix, apiKey.SecretHash, apiKey.Scopes, apiKey.ExpiresAt)
+	if err := row.Scan(&apiKey.CreatedAt, &apiKey.UpdatedAt); err != nil {
+		if isUniqueViolationError(err) {
+			return domain.APIKey{}, intErr.ErrConflict
+		}
+		return domain.APIKey{}, fmt.Errorf("insert api key: %w", err)
+	}
+	return apiKey, nil
+}
+
+// isUniqueViolationError checks if the error is a PostgreSQL unique violation error.
+func isUniqueViolationError(err error) bool {
+	var pgError *pgconn.PgError
+	return errors.As(err, &pgError) && pgError.Code == "23505"
+}
+
+func (repository *pgRepository) Get(ctx context.Context, apiKeyID uuid.UUID) (domain.APIKey, error) {
+	selectQuery := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE id=$1;`
+	return repository.queryOne(ctx, "get api key", selectQuery, apiKeyID)
+}
+
+func (repository *pgRepository) GetByPrefix(ctx context.Context, prefix string) (domain.APIKey, error) {
+	selectQuery := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE prefix=$1;`
+	return repository.queryOne(ctx, "get api key by prefix", selectQuery, prefix)
+}
+
+func (repository *pgRepository) List(ctx context.Context) ([]domain.APIKey, error) {
+	selectQuery := `SELECT ` + apiKeyColumns + ` FROM api_keys ORDER BY created_at DESC;`
+	rows, err := repository.dbPool.Query(ctx, selectQuery)
+	if err != nil {
+		return nil, fmt.Errorf("list api keys: %w", err)
+	}
+	defer rows.Close()
+
+	var apiKeys []domain.APIKey
+	for rows.Next() {
+		apiKey, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan api key row: %w", err)
+		}
+		apiKeys = append(apiKeys, apiKey)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return apiKeys, nil
+}
+
+func (repository *pgRepository) UpdateSecret(ctx context.Context, apiKeyID uuid.UUID, prefix string, secretHash []byte) (domain.APIKey, error) {
+	updateQuery := `
+UPDATE api_keys SET prefix=$2, secret_hash=$3, updated_at=NOW()
+WHERE id=$1 AND revoked_at IS NULL
+RETURNING ` + apiKeyColumns + `;`
+	apiKey, err := repository.queryOne(ctx, "rotate api key", updateQuery, apiKeyID, prefix, secretHash)
+	if isUniqueViolationError(err) {
+		return domain.APIKey{}, intErr.ErrConflict
+	}
+	return apiKey, err
+}
+
+func (repository *pgRepository) Revoke(ctx context.Context, apiKeyID uuid.UUID) (domain.APIKey, error) {
+	updateQuery := `
+UPDATE api_keys SET revoked_at=COALESCE(revoked_at, NOW()), updated_at=NOW()
+WHERE id=$1
+RETURNING ` + apiKeyColumns + `;`
+	return repository.queryOne(ctx, "revoke api key", updateQuery, apiKeyID)
+}
+
+func (repository *pgRepository) TouchLastUsed(ctx context.Context, apiKeyID uuid.UUID) error {
+	const updateQuery = `
+UPDATE api_keys SET last_used_at=NOW()
+WHERE id=$1 AND (last_used_at IS NULL OR last_used_at < NOW() - INTERVAL '` + lastUsedResolution + `');
+`
+	if _, err := repository.dbPool.Exec(ctx, updateQuery, apiKeyID); err != nil {
+		return fmt.Errorf("touch api key: %w", err)
+	}
+	return nil
+}
+
+func (repository *pgRepository) PurgeInactive(ctx context.Context, cutoff time.Time) (int64, error) {
+	const deleteQuery = `
+DELETE FROM api_keys
+WHERE revoked_at < $1 OR expires_at < $1;
+`
+	result, err := repository.dbPool.Exec(ctx, deleteQuery, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("purge inactive api keys: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
+
+// queryOne runs a query expected to return a single API key row.
+func (repository *pgRepository) queryOne(ctx context.Context, operation, query string, args ...any) (domain.APIKey, error) {
+	apiKey, err := scanAPIKey(repository.dbPool.QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.APIKey{}, intErr.ErrNotFound
+		}
+		return domain.APIKey{}, fmt.Errorf("%s: %w", operation, err)
+	}
+	return apiKey, nil
+}
+
+// scanAPIKey scans a single row into an APIKey entity.
+func scanAPIKey(row pgx.Row) (domain.APIKey, error) {
+	var apiKey domain.APIKey
+	err := row.Scan(&apiKey.ID, &apiKey.Name, &apiKey.Prefix, &apiKey.SecretHash, &apiKey.Scopes, &apiKey.ExpiresAt, &apiKey.LastUsedAt, &apiKey.RevokedAt, &apiKey.CreatedAt, &apiKey.UpdatedAt)
+	return apiKey, err
+}
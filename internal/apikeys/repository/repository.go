@@ -0,0 +1,24 @@
+//go:generate mockgen -source=repository.go -destination=mocks/repository.go -package=mocks
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/bkiran6398/library/internal/apikeys/domain"
+	"github.com/google/uuid"
+)
+
+// Repository defines the interface for API key data access operations.
+// Consumers should depend on this interface, not on concrete implementations.
+type Repository interface {
+	Create(ctx context.Context, apiKey domain.APIKey) (domain.APIKey, error)
+	Get(ctx context.Context, apiKeyID uuid.UUID) (domain.APIKey, error)
+	GetByPrefix(ctx context.Context, prefix string) (domain.APIKey, error)
+	List(ctx context.Context) ([]domain.APIKey, error)
+	UpdateSecret(ctx context.Context, apiKeyID uuid.UUID, prefix string, secretHash []byte) (domain.APIKey, error)
+	Revoke(ctx context.Context, apiKeyID uuid.UUID) (domain.APIKey, error)
+	TouchLastUsed(ctx context.Context, apiKeyID uuid.UUID) error
+	// PurgeInactive deletes keys revoked or expired before cutoff and returns how many were removed.
+	PurgeInactive(ctx context.Context, cutoff time.Time) (int64, error)
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+const (
+	keyPrefix       = "lib"
+	keySeparator    = "_"
+	prefixByteCount = 6
+	secretByteCount = 32
+)
+
+// generatedKey holds a freshly generated credential.
+type generatedKey struct {
+	prefix     string
+	secretHash []byte
+	plaintext  string
+}
+
+// generateKey creates a credential of the form lib_<prefix>_<secret>.
+// The prefix is stored in clear text for lookup; only the secret's hash is stored.
+func generateKey() (generatedKey, error) {
+	prefixBytes := make([]byte, prefixByteCount)
+	if _, err := rand.Read(prefixBytes); err != nil {
+		return generatedKey{}, fmt.Errorf("generate key prefix: %w", err)
+	}
+	secretBytes := make([]byte, secretByteCount)
+	if _, err := rand.Read(secretBytes); err != nil {
+		return generatedKey{}, fmt.Errorf("generate key secret: %w", err)
+	}
+
+	prefix := hex.EncodeToString(prefixBytes)
+	secret := base64.RawURLEncoding.EncodeToString(secretBytes)
+	return generatedKey{
+		prefix:     prefix,
+		secretHash: hashSecret(secret),
+		plaintext:  strings.Join([]string{keyPrefix, prefix, secret}, keySeparator),
+	}, nil
+}
+
+// parseKey splits a plaintext credential into its lookup prefix and secret.
+func parseKey(plaintext string) (prefix, secret string, ok bool) {
+	parts := strings.SplitN(plaintext, keySeparator, 3)
+	if len(parts) != 3 || parts[0] != keyPrefix || parts[1] == "" || parts[2] == "" {
+		return "", "", false
+	}
+	return parts[1], parts[2], true
+}
+
+// hashSecret hashes a high-entropy secret. A fast hash is sufficient because secrets
+// are random 256-bit values, not user-chosen passwords.
+func hashSecret(secret string) []byte {
+	sum := sha256.Sum256([]byte(secret))
+	return sum[:]
+}
+
+// secretMatches compares a presented secret against a stored hash in constant time.
+func secretMatches(secret string, secretHash []byte) bool {
+	return subtle.ConstantTimeCompare(hashSecret(secret), secretHash) == 1
+}
@@ -0,0 +1,21 @@
+//go:generate mockgen -source=service.go -destination=mocks/service.go -package=mocks
+package service
+
+import (
+	"context"
+
+	"github.com/bkiran6398/library/internal/apikeys/domain"
+	"github.com/bkiran6398/library/internal/auth"
+	"github.com/google/uuid"
+)
+
+// Service defines the interface for API key management and authentication.
+// Consumers should depend on this interface, not on concrete implementations.
+type Service interface {
+	Create(ctx context.Context, createRequest domain.CreateAPIKeyRequest) (domain.IssuedAPIKey, error)
+	List(ctx context.Context) ([]domain.APIKey, error)
+	Rotate(ctx context.Context, apiKeyID uuid.UUID) (domain.IssuedAPIKey, error)
+	Revoke(ctx context.Context, apiKeyID uuid.UUID) (domain.APIKey, error)
+	// Authenticate resolves the credentials of an "Authorization: ApiKey ..." header.
+	Authenticate(ctx context.Context, credentials string) (auth.Principal, error)
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bkiran6398/library/internal/apikeys/domain"
+	"github.com/bkiran6398/library/internal/apikeys/repository"
+	"github.com/bkiran6398/library/internal/auth"
+	intErr "github.com/bkiran6398/library/internal/errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+const (
+	createTimeout       = 5 * time.Second
+	listTimeout         = 10 * time.Second
+	rotateTimeout       = 5 * time.Second
+	revokeTimeout       = 5 * time.Second
+	authenticateTimeout = 2 * time.Second
+)
+
+var errInvalidAPIKey = intErr.New(intErr.KindUnauthorized, "invalid api key")
+
+// service is the implementation of Service.
+type service struct {
+	repository repository.Repository
+	validator  *validator.Validate
+	now        func() time.Time
+}
+
+// NewService creates a new Service implementation.
+// This constructor is the only place where consumers should depend on the concrete type.
+func NewService(repository repository.Repository) Service {
+	return &service{
+		repository: repository,
+		validator:  validator.New(),
+		now:        time.Now,
+	}
+}
+
+func (serviceInstance *service) Create(ctx context.Context, createRequest domain.CreateAPIKeyRequest) (domain.IssuedAPIKey, error) {
+	if err := serviceInstance.validator.Struct(createRequest); err != nil {
+		return domain.IssuedAPIKey{}, fmt.Errorf("%w: %v", intErr.ErrBadRequest, err)
+	}
+	if createRequest.ExpiresAt != nil && !createRequest.ExpiresAt.After(serviceInstance.now()) {
+		return domain.IssuedAPIKey{}, fmt.Errorf("%w: expires_at must be in the future", intErr.ErrBadRequest)
+	}
+
+	key, err := generateKey()
+	if err != nil {
+		return domain.IssuedAPIKey{}, err
+	}
+
+	scopes := createRequest.Scopes
+	if scopes == nil {
+		scopes = []string{}
+	}
+	apiKey := domain.APIKey{
+		ID:         uuid.New(),
+		Name:       createRequest.Name,
+		Prefix:     key.prefix,
+		SecretHash: key.secretHash,
+		Scopes:     scopes,
+		ExpiresAt:  createRequest.ExpiresAt,
+	}
+
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+	created, err := serviceInstance.repository.Create(ctxWithTimeout, apiKey)
+	if err != nil {
+		return domain.IssuedAPIKey{}, err
+	}
+	return domain.IssuedAPIKey{APIKey: created, Key: key.plaintext}, nil
+}
+
+func (serviceInstance *service) List(ctx context.Context) ([]domain.APIKey, error) {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, listTimeout)
+	defer cancel()
+	return serviceInstance.repository.List(ctxWithTimeout)
+}
+
+func (serviceInstance *service) Rotate(ctx context.Context, apiKeyID uuid.UUID) (domain.IssuedAPIKey, error) {
+	key, err := generateKey()
+	if err != nil {
+		return domain.IssuedAPIKey{}, err
+	}
+
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, rotateTimeout)
+	defer cancel()
+	rotated, err := serviceInstance.repository.UpdateSecret(ctxWithTimeout, apiKeyID, key.prefix, key.secretHash)
+	if err != nil {
+		return domain.IssuedAPIKey{}, err
+	}
+	return domain.IssuedAPIKey{APIKey: rotated, Key: key.plaintext}, nil
+}
+
+func (serviceInstance *service) Revoke(ctx context.Context, apiKeyID uuid.UUID) (domain.APIKey, error) {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, revokeTimeout)
+	defer cancel()
+	return serviceInstance.repository.Revoke(ctxWithTimeout, apiKeyID)
+}
+
+func (serviceInstance *service) Authenticate(ctx context.Context, credentials string) (auth.Principal, error) {
+	prefix, secret, ok := parseKey(credentials)
+	if !ok {
+		return auth.Principal{}, errInvalidAPIKey
+	}
+
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, authenticateTimeout)
+	defer cancel()
+
+	apiKey, err := serviceInstance.repository.GetByPrefix(ctxWithTimeout, prefix)
+	if err != nil {
+		if errors.Is(err, intErr.ErrNotFound) {
+			return auth.Principal{}, errInvalidAPIKey
+		}
+		return auth.Principal{}, err
+	}
+	if !secretMatches(secret, apiKey.SecretHash) || !apiKey.IsActive(serviceInstance.now()) {
+		return auth.Principal{}, errInvalidAPIKey
+	}
+
+	// Usage tracking is best effort: a failed timestamp write must not reject a valid key.
+	_ = serviceInstance.repository.TouchLastUsed(ctxWithTimeout, apiKey.ID)
+
+	return auth.Principal{
+		Subject: apiKey.ID.String(),
+		Type:    auth.PrincipalTypeAPIKey,
+		Scopes:  apiKey.Scopes,
+	}, nil
+}
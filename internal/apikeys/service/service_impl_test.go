@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bkiran6398/library/internal/apikeys/domain"
+	"github.com/bkiran6398/library/internal/apikeys/repository/mocks"
+	"github.com/bkiran6398/library/internal/auth"
+	intErr "github.com/bkiran6398/library/internal/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreate_IssuesKeyThatAuthenticates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	service := NewService(mockRepo)
+
+	var stored domain.APIKey
+	mockRepo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, apiKey domain.APIKey) (domain.APIKey, error) {
+			require.Equal(t, "batch-job", apiKey.Name)
+			require.Equal(t, []string{"books:read"}, apiKey.Scopes)
+			require.NotEmpty(t, apiKey.SecretHash)
+			stored = apiKey
+			return apiKey, nil
+		}).
+		Times(1)
+
+	issued, err := service.Create(context.Background(), domain.CreateAPIKeyRequest{Name: "batch-job", Scopes: []string{"books:read"}})
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(issued.Key, "lib_"+stored.Prefix+"_"))
+
+	mockRepo.EXPECT().GetByPrefix(gomock.Any(), stored.Prefix).Return(stored, nil).Times(1)
+	mockRepo.EXPECT().TouchLastUsed(gomock.Any(), stored.ID).Return(nil).Times(1)
+
+	principal, err := service.Authenticate(context.Background(), issued.Key)
+	require.NoError(t, err)
+	require.Equal(t, stored.ID.String(), principal.Subject)
+	require.Equal(t, auth.PrincipalTypeAPIKey, principal.Type)
+	require.Equal(t, []string{"books:read"}, principal.Scopes)
+}
+
+func TestCreate_ValidationError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	service := NewService(mockRepo)
+
+	_, err := service.Create(context.Background(), domain.CreateAPIKeyRequest{Name: ""})
+	require.ErrorIs(t, err, intErr.ErrBadRequest)
+
+	past := time.Now().Add(-time.Hour)
+	_, err = service.Create(context.Background(), domain.CreateAPIKeyRequest{Name: "old", ExpiresAt: &past})
+	require.ErrorIs(t, err, intErr.ErrBadRequest)
+}
+
+func TestAuthenticate_RejectsInactiveKeys(t *testing.T) {
+	key, err := generateKey()
+	require.NoError(t, err)
+
+	past := time.Now().Add(-time.Minute)
+	otherKey, err := generateKey()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		apiKey domain.APIKey
+	}{
+		{name: "revoked", apiKey: domain.APIKey{ID: uuid.New(), Prefix: key.prefix, SecretHash: key.secretHash, RevokedAt: &past}},
+		{name: "expired", apiKey: domain.APIKey{ID: uuid.New(), Prefix: key.prefix, SecretHash: key.secretHash, ExpiresAt: &past}},
+		{name: "wrong secret", apiKey: domain.APIKey{ID: uuid.New(), Prefix: key.prefix, SecretHash: otherKey.secretHash}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockRepository(ctrl)
+			service := NewService(mockRepo)
+
+			mockRepo.EXPECT().GetByPrefix(gomock.Any(), key.prefix).Return(tt.apiKey, nil).Times(1)
+
+			_, err := service.Authenticate(context.Background(), key.plaintext)
+			require.ErrorIs(t, err, intErr.ErrUnauthorized)
+		})
+	}
+}
+
+func TestAuthenticate_UnknownOrMalformedKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	service := NewService(mockRepo)
+
+	_, err := service.Authenticate(context.Background(), "not-a-key")
+	require.ErrorIs(t, err, intErr.ErrUnauthorized)
+
+	mockRepo.EXPECT().GetByPrefix(gomock.Any(), "abc").Return(domain.APIKey{}, intErr.ErrNotFound).Times(1)
+	_, err = service.Authenticate(context.Background(), "lib_abc_secret")
+	require.ErrorIs(t, err, intErr.ErrUnauthorized)
+}
+
+func TestAuthenticate_LastUsedFailureDoesNotReject(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	service := NewService(mockRepo)
+
+	key, err := generateKey()
+	require.NoError(t, err)
+	apiKey := domain.APIKey{ID: uuid.New(), Prefix: key.prefix, SecretHash: key.secretHash}
+
+	mockRepo.EXPECT().GetByPrefix(gomock.Any(), key.prefix).Return(apiKey, nil).Times(1)
+	mockRepo.EXPECT().TouchLastUsed(gomock.Any(), apiKey.ID).Return(errors.New("db down")).Times(1)
+
+	_, err = service.Authenticate(context.Background(), key.plaintext)
+	require.NoError(t, err)
+}
+
+func TestRotate_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	service := NewService(mockRepo)
+
+	apiKeyID := uuid.New()
+	mockRepo.EXPECT().UpdateSecret(gomock.Any(), apiKeyID, gomock.Any(), gomock.Any()).Return(domain.APIKey{}, intErr.ErrNotFound).Times(1)
+
+	_, err := service.Rotate(context.Background(), apiKeyID)
+	require.ErrorIs(t, err, intErr.ErrNotFound)
+}
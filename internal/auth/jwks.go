@@ -0,0 +1,227 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	jwksFetchTimeout   = 5 * time.Second
+	jwksMinRefreshWait = 30 * time.Second
+)
+
+// jsonWebKey is the subset of RFC 7517 members needed for RSA and EC public keys.
+type jsonWebKey struct {
+	KeyType string `json:"kty"`
+	KeyID   string `json:"kid"`
+	Use     string `json:"use"`
+	Curve   string `json:"crv"`
+	N       string `json:"n"`
+	E       string `json:"e"`
+	X       string `json:"x"`
+	Y       string `json:"y"`
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+// KeySet resolves public keys by key ID from a JWKS document loaded from a file or URL.
+// URL-backed sets are refreshed when an unknown key ID is seen, at most once per jwksMinRefreshWait.
+type KeySet struct {
+	source     string
+	httpClient *http.Client
+
+	mutex       sync.RWMutex
+	keys        map[string]crypto.PublicKey
+	lastRefresh time.Time
+}
+
+// NewFileKeySet loads a JWKS document from a local file.
+func NewFileKeySet(path string) (*KeySet, error) {
+	keySet := &KeySet{source: path}
+	if err := keySet.refresh(context.Background()); err != nil {
+		return nil, err
+	}
+	return keySet, nil
+}
+
+// NewURLKeySet loads a JWKS document from an HTTP(S) URL.
+func NewURLKeySet(ctx context.Context, url string) (*KeySet, error) {
+	keySet := &KeySet{source: url, httpClient: &http.Client{Timeout: jwksFetchTimeout}}
+	if err := keySet.refresh(ctx); err != nil {
+		return nil, err
+	}
+	return keySet, nil
+}
+
+// Key returns the public key with the given key ID.
+func (keySet *KeySet) Key(ctx context.Context, keyID string) (crypto.PublicKey, error) {
+	if key, ok := keySet.lookup(keyID); ok {
+		return key, nil
+	}
+
+	if keySet.httpClient != nil && keySet.canRefresh() {
+		if err := keySet.refresh(ctx); err != nil {
+			return nil, err
+		}
+		if key, ok := keySet.lookup(keyID); ok {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown key id %q", keyID)
+}
+
+// lookup finds a key by ID. A single unnamed key matches any ID.
+func (keySet *KeySet) lookup(keyID string) (crypto.PublicKey, bool) {
+	keySet.mutex.RLock()
+	defer keySet.mutex.RUnlock()
+	if key, ok := keySet.keys[keyID]; ok {
+		return key, true
+	}
+	if len(keySet.keys) == 1 {
+		if key, ok := keySet.keys[""]; ok {
+			return key, true
+		}
+	}
+	return nil, false
+}
+
+// canRefresh reports whether enough time has passed since the last refresh.
+func (keySet *KeySet) canRefresh() bool {
+	keySet.mutex.RLock()
+	defer keySet.mutex.RUnlock()
+	return time.Since(keySet.lastRefresh) >= jwksMinRefreshWait
+}
+
+// refresh reloads the key set from its source.
+func (keySet *KeySet) refresh(ctx context.Context) error {
+	document, err := keySet.read(ctx)
+	if err != nil {
+		return fmt.Errorf("read jwks %s: %w", keySet.source, err)
+	}
+
+	keys, err := parseJSONWebKeySet(document)
+	if err != nil {
+		return fmt.Errorf("parse jwks %s: %w", keySet.source, err)
+	}
+
+	keySet.mutex.Lock()
+	defer keySet.mutex.Unlock()
+	keySet.keys = keys
+	keySet.lastRefresh = time.Now()
+	return nil
+}
+
+// read fetches the raw JWKS document.
+func (keySet *KeySet) read(ctx context.Context) ([]byte, error) {
+	if keySet.httpClient == nil {
+		return os.ReadFile(keySet.source)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, keySet.source, nil)
+	if err != nil {
+		return nil, err
+	}
+	httpResponse, err := keySet.httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResponse.Body.Close()
+
+	if httpResponse.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", httpResponse.StatusCode)
+	}
+	return io.ReadAll(httpResponse.Body)
+}
+
+// parseJSONWebKeySet converts the signing keys of a JWKS document into public keys.
+func parseJSONWebKeySet(document []byte) (map[string]crypto.PublicKey, error) {
+	var keySet jsonWebKeySet
+	if err := json.Unmarshal(document, &keySet); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(keySet.Keys))
+	for _, webKey := range keySet.Keys {
+		if webKey.Use != "" && webKey.Use != "sig" {
+			continue
+		}
+		publicKey, err := parseJSONWebKey(webKey)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", webKey.KeyID, err)
+		}
+		keys[webKey.KeyID] = publicKey
+	}
+	return keys, nil
+}
+
+// parseJSONWebKey converts a single RSA or EC JWK into a public key.
+func parseJSONWebKey(webKey jsonWebKey) (crypto.PublicKey, error) {
+	switch webKey.KeyType {
+	case "RSA":
+		modulus, err := decodeBigInt(webKey.N)
+		if err != nil {
+			return nil, fmt.Errorf("decode n: %w", err)
+		}
+		exponent, err := decodeBigInt(webKey.E)
+		if err != nil {
+			return nil, fmt.Errorf("decode e: %w", err)
+		}
+		return &rsa.PublicKey{N: modulus, E: int(exponent.Int64())}, nil
+	case "EC":
+		curve, err := ellipticCurve(webKey.Curve)
+		if err != nil {
+			return nil, err
+		}
+		x, err := decodeBigInt(webKey.X)
+		if err != nil {
+			return nil, fmt.Errorf("decode x: %w", err)
+		}
+		y, err := decodeBigInt(webKey.Y)
+		if err != nil {
+			return nil, fmt.Errorf("decode y: %w", err)
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, fmt.Errorf("point is not on curve %s", webKey.Curve)
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", webKey.KeyType)
+	}
+}
+
+// ellipticCurve maps a JWK curve name to its implementation.
+func ellipticCurve(name string) (elliptic.Curve, error) {
+	switch name {
+	case "P-256":
+		return elliptic.P256(), nil
+	case "P-384":
+		return elliptic.P384(), nil
+	case "P-521":
+		return elliptic.P521(), nil
+	default:
+		return nil, fmt.Errorf("unsupported curve %q", name)
+	}
+}
+
+// decodeBigInt decodes a base64url-encoded unsigned big-endian integer.
+func decodeBigInt(encoded string) (*big.Int, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(decoded), nil
+}
@@ -0,0 +1,141 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	defaultRolesClaim = "roles"
+	clockSkewLeeway   = 30 * time.Second
+)
+
+var (
+	errMissingHMACSecret = errors.New("HS256 token received but no HMAC secret is configured")
+	errMissingKeySet     = errors.New("asymmetric token received but no JWKS is configured")
+)
+
+// JWTConfig configures bearer token verification.
+type JWTConfig struct {
+	Issuer   string
+	Audience string
+	// HMACSecret enables HS256 tokens.
+	HMACSecret []byte
+	// KeySet enables RS256 and ES256 tokens.
+	KeySet *KeySet
+	// RolesClaim names the claim holding the principal's roles. Defaults to "roles".
+	RolesClaim string
+}
+
+// JWTVerifier validates bearer JWTs and converts their claims into a Principal.
+type JWTVerifier struct {
+	config JWTConfig
+	parser *jwt.Parser
+}
+
+// NewJWTVerifier creates a verifier. At least one of HMACSecret or KeySet must be set.
+func NewJWTVerifier(config JWTConfig) (*JWTVerifier, error) {
+	if len(config.HMACSecret) == 0 && config.KeySet == nil {
+		return nil, errors.New("jwt verifier requires an HMAC secret or a JWKS")
+	}
+	if config.RolesClaim == "" {
+		config.RolesClaim = defaultRolesClaim
+	}
+
+	parserOptions := []jwt.ParserOption{
+		jwt.WithValidMethods(allowedMethods(config)),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(clockSkewLeeway),
+	}
+	if config.Issuer != "" {
+		parserOptions = append(parserOptions, jwt.WithIssuer(config.Issuer))
+	}
+	if config.Audience != "" {
+		parserOptions = append(parserOptions, jwt.WithAudience(config.Audience))
+	}
+
+	return &JWTVerifier{config: config, parser: jwt.NewParser(parserOptions...)}, nil
+}
+
+// allowedMethods lists the signing algorithms enabled by the configured keys.
+func allowedMethods(config JWTConfig) []string {
+	var methods []string
+	if len(config.HMACSecret) > 0 {
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+	if config.KeySet != nil {
+		methods = append(methods, jwt.SigningMethodRS256.Alg(), jwt.SigningMethodES256.Alg())
+	}
+	return methods
+}
+
+// Authenticate verifies a raw bearer token and returns its principal.
+func (verifier *JWTVerifier) Authenticate(ctx context.Context, rawToken string) (Principal, error) {
+	claims := jwt.MapClaims{}
+	_, err := verifier.parser.ParseWithClaims(rawToken, claims, func(token *jwt.Token) (interface{}, error) {
+		return verifier.resolveKey(ctx, token)
+	})
+	if err != nil {
+		return Principal{}, fmt.Errorf("verify token: %w", err)
+	}
+
+	subject, err := claims.GetSubject()
+	if err != nil || subject == "" {
+		return Principal{}, errors.New("verify token: missing sub claim")
+	}
+
+	return Principal{
+		Subject: subject,
+		Type:    PrincipalTypeUser,
+		Roles:   stringsClaim(claims[verifier.config.RolesClaim]),
+		Scopes:  scopesClaim(claims),
+	}, nil
+}
+
+// resolveKey returns the verification key for the token's algorithm and key ID.
+func (verifier *JWTVerifier) resolveKey(ctx context.Context, token *jwt.Token) (interface{}, error) {
+	switch token.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		if len(verifier.config.HMACSecret) == 0 {
+			return nil, errMissingHMACSecret
+		}
+		return verifier.config.HMACSecret, nil
+	default:
+		if verifier.config.KeySet == nil {
+			return nil, errMissingKeySet
+		}
+		keyID, _ := token.Header["kid"].(string)
+		return verifier.config.KeySet.Key(ctx, keyID)
+	}
+}
+
+// scopesClaim reads OAuth2 scopes from either a space-delimited "scope" claim or an "scp" array.
+func scopesClaim(claims jwt.MapClaims) []string {
+	if scope, ok := claims["scope"].(string); ok {
+		return strings.Fields(scope)
+	}
+	return stringsClaim(claims["scp"])
+}
+
+// stringsClaim normalizes a claim that may be a single string or an array of strings.
+func stringsClaim(value interface{}) []string {
+	switch typed := value.(type) {
+	case string:
+		return []string{typed}
+	case []interface{}:
+		values := make([]string, 0, len(typed))
+		for _, item := range typed {
+			if text, ok := item.(string); ok {
+				values = append(values, text)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
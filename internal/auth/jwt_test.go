@@ -0,0 +1,142 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+)
+
+var testHMACSecret = []byte("test-secret")
+
+func signHS256(t *testing.T, claims jwt.MapClaims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(testHMACSecret)
+	require.NoError(t, err)
+	return token
+}
+
+func validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"sub":   "user-1",
+		"iss":   "https://issuer.example.com",
+		"aud":   "library",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"roles": []string{"librarian"},
+		"scope": "books:read books:write",
+	}
+}
+
+func newTestVerifier(t *testing.T, keySet *KeySet) *JWTVerifier {
+	t.Helper()
+	verifier, err := NewJWTVerifier(JWTConfig{
+		Issuer:     "https://issuer.example.com",
+		Audience:   "library",
+		HMACSecret: testHMACSecret,
+		KeySet:     keySet,
+	})
+	require.NoError(t, err)
+	return verifier
+}
+
+func TestJWTVerifier_HS256(t *testing.T) {
+	verifier := newTestVerifier(t, nil)
+
+	principal, err := verifier.Authenticate(context.Background(), signHS256(t, validClaims()))
+	require.NoError(t, err)
+	require.Equal(t, "user-1", principal.Subject)
+	require.Equal(t, PrincipalTypeUser, principal.Type)
+	require.Equal(t, []string{"librarian"}, principal.Roles)
+	require.Equal(t, []string{"books:read", "books:write"}, principal.Scopes)
+}
+
+func TestJWTVerifier_RejectsInvalidTokens(t *testing.T) {
+	verifier := newTestVerifier(t, nil)
+
+	expired := validClaims()
+	expired["exp"] = time.Now().Add(-time.Hour).Unix()
+
+	wrongAudience := validClaims()
+	wrongAudience["aud"] = "another-api"
+
+	wrongIssuer := validClaims()
+	wrongIssuer["iss"] = "https://evil.example.com"
+
+	missingSubject := validClaims()
+	delete(missingSubject, "sub")
+
+	missingExpiry := validClaims()
+	delete(missingExpiry, "exp")
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "expired", token: signHS256(t, expired)},
+		{name: "wrong audience", token: signHS256(t, wrongAudience)},
+		{name: "wrong issuer", token: signHS256(t, wrongIssuer)},
+		{name: "missing subject", token: signHS256(t, missingSubject)},
+		{name: "missing expiry", token: signHS256(t, missingExpiry)},
+		{name: "garbage", token: "not-a-jwt"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := verifier.Authenticate(context.Background(), tt.token)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestJWTVerifier_ES256WithJWKSFile(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	jwks := map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "EC",
+			"kid": "key-1",
+			"use": "sig",
+			"crv": "P-256",
+			"x":   base64.RawURLEncoding.EncodeToString(privateKey.X.FillBytes(make([]byte, 32))),
+			"y":   base64.RawURLEncoding.EncodeToString(privateKey.Y.FillBytes(make([]byte, 32))),
+		}},
+	}
+	document, err := json.Marshal(jwks)
+	require.NoError(t, err)
+	jwksPath := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(jwksPath, document, 0o600))
+
+	keySet, err := NewFileKeySet(jwksPath)
+	require.NoError(t, err)
+	verifier := newTestVerifier(t, keySet)
+
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, validClaims())
+	token.Header["kid"] = "key-1"
+	signed, err := token.SignedString(privateKey)
+	require.NoError(t, err)
+
+	principal, err := verifier.Authenticate(context.Background(), signed)
+	require.NoError(t, err)
+	require.Equal(t, "user-1", principal.Subject)
+
+	token.Header["kid"] = "unknown"
+	signed, err = token.SignedString(privateKey)
+	require.NoError(t, err)
+	_, err = verifier.Authenticate(context.Background(), signed)
+	require.Error(t, err)
+}
+
+func TestNewJWTVerifier_RequiresKeys(t *testing.T) {
+	_, err := NewJWTVerifier(JWTConfig{})
+	require.Error(t, err)
+}
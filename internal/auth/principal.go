@@ -0,0 +1,47 @@
+package auth
+
+import (
+	"context"
+	"slices"
+)
+
+// PrincipalType identifies how a principal authenticated.
+type PrincipalType string
+
+const (
+	PrincipalTypeUser   PrincipalType = "user"
+	PrincipalTypeAPIKey PrincipalType = "api_key"
+)
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	Subject string
+	Type    PrincipalType
+	Roles   []string
+	Scopes  []string
+}
+
+// HasRole reports whether the principal was granted role.
+func (principal Principal) HasRole(role string) bool {
+	return slices.Contains(principal.Roles, role)
+}
+
+// HasScope reports whether the principal was granted scope.
+func (principal Principal) HasScope(scope string) bool {
+	return slices.Contains(principal.Scopes, scope)
+}
+
+type ctxKey string
+
+const principalKey ctxKey = "principal"
+
+// WithPrincipal returns a copy of ctx carrying the principal.
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalKey, principal)
+}
+
+// PrincipalFromContext returns the principal stored in ctx, if any.
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalKey).(Principal)
+	return principal, ok
+}
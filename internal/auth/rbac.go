@@ -0,0 +1,69 @@
+package auth
+
+import "strings"
+
+// Permission names an operation that can be granted to a role, e.g. "books:write".
+type Permission string
+
+const (
+	PermissionBooksRead         Permission = "books:read"
+	PermissionBooksWrite        Permission = "books:write"
+	PermissionBooksDelete       Permission = "books:delete"
+	PermissionCirculationBorrow Permission = "circulation:borrow"
+	PermissionCirculationReturn Permission = "circulation:return"
+	PermissionAPIKeysManage     Permission = "api_keys:manage"
+)
+
+const (
+	permissionWildcard         = "*"
+	permissionNamespaceDivider = ":"
+)
+
+// DefaultRoles is the role-to-permission mapping used when none is configured.
+var DefaultRoles = map[string][]string{
+	"admin":     {permissionWildcard},
+	"librarian": {"books:*", "circulation:*"},
+	"patron":    {string(PermissionBooksRead), string(PermissionCirculationBorrow), string(PermissionCirculationReturn)},
+}
+
+// Policy decides whether a principal may perform an operation.
+// User principals are granted the permissions of their roles; API key principals are
+// granted their scopes directly, since keys are issued for a fixed set of operations.
+type Policy struct {
+	roles map[string][]string
+}
+
+// NewPolicy creates a policy from a role-to-permissions mapping. Permissions may use
+// "*" for everything or "namespace:*" for every operation in a namespace.
+func NewPolicy(roles map[string][]string) *Policy {
+	normalizedRoles := make(map[string][]string, len(roles))
+	for role, permissions := range roles {
+		normalizedRoles[strings.ToLower(role)] = permissions
+	}
+	return &Policy{roles: normalizedRoles}
+}
+
+// Allows reports whether the principal holds the permission.
+func (policy *Policy) Allows(principal Principal, permission Permission) bool {
+	if principal.Type == PrincipalTypeAPIKey {
+		return grants(principal.Scopes, permission)
+	}
+	for _, role := range principal.Roles {
+		if grants(policy.roles[strings.ToLower(role)], permission) {
+			return true
+		}
+	}
+	return false
+}
+
+// grants reports whether any granted permission pattern matches permission.
+func grants(granted []string, permission Permission) bool {
+	namespace, _, _ := strings.Cut(string(permission), permissionNamespaceDivider)
+	for _, pattern := range granted {
+		switch pattern {
+		case permissionWildcard, string(permission), namespace + permissionNamespaceDivider + permissionWildcard:
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,41 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicy_Allows(t *testing.T) {
+	policy := NewPolicy(DefaultRoles)
+
+	librarian := Principal{Subject: "l", Type: PrincipalTypeUser, Roles: []string{"Librarian"}}
+	patron := Principal{Subject: "p", Type: PrincipalTypeUser, Roles: []string{"patron"}}
+	admin := Principal{Subject: "a", Type: PrincipalTypeUser, Roles: []string{"admin"}}
+	anonymous := Principal{Subject: "x", Type: PrincipalTypeUser}
+	batchJob := Principal{Subject: "k", Type: PrincipalTypeAPIKey, Roles: []string{"admin"}, Scopes: []string{"books:read"}}
+
+	tests := []struct {
+		name       string
+		principal  Principal
+		permission Permission
+		allowed    bool
+	}{
+		{name: "librarian writes books", principal: librarian, permission: PermissionBooksWrite, allowed: true},
+		{name: "librarian deletes books", principal: librarian, permission: PermissionBooksDelete, allowed: true},
+		{name: "librarian cannot manage api keys", principal: librarian, permission: PermissionAPIKeysManage, allowed: false},
+		{name: "patron reads books", principal: patron, permission: PermissionBooksRead, allowed: true},
+		{name: "patron borrows", principal: patron, permission: PermissionCirculationBorrow, allowed: true},
+		{name: "patron cannot write books", principal: patron, permission: PermissionBooksWrite, allowed: false},
+		{name: "admin does anything", principal: admin, permission: PermissionAPIKeysManage, allowed: true},
+		{name: "no roles", principal: anonymous, permission: PermissionBooksRead, allowed: false},
+		{name: "api key uses scopes", principal: batchJob, permission: PermissionBooksRead, allowed: true},
+		{name: "api key ignores roles", principal: batchJob, permission: PermissionBooksWrite, allowed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.allowed, policy.Allows(tt.principal, tt.permission))
+		})
+	}
+}
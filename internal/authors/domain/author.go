@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Role is the part a person played in creating a book.
+type Role string
+
+const (
+	RoleAuthor      Role = "author"
+	RoleEditor      Role = "editor"
+	RoleTranslator  Role = "translator"
+	RoleIllustrator Role = "illustrator"
+)
+
+// Author represents a person or organisation credited on books. Names are unique regardless
+// of case; add life dates or a qualifier to tell apart people sharing a name.
+type Author struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CreateAuthorRequest represents the request payload for creating a new author.
+type CreateAuthorRequest struct {
+	Name string `json:"name" validate:"required,min=1,max=300"`
+}
+
+// UpdateAuthorRequest represents the request payload for renaming an author.
+type UpdateAuthorRequest struct {
+	Name string `json:"name" validate:"required,min=1,max=300"`
+}
+
+// ListFilter represents filtering options for listing authors.
+type ListFilter struct {
+	Name   *string
+	Limit  int
+	Offset int
+}
+
+// Contributor is an author credited on a book, with their role and position in the credits.
+type Contributor struct {
+	AuthorID uuid.UUID `json:"author_id"`
+	Name     string    `json:"name"`
+	Role     Role      `json:"role"`
+	Position int       `json:"position"`
+}
+
+// ContributorRequest credits an author on a book. The order of the requests gives the positions.
+type ContributorRequest struct {
+	AuthorID uuid.UUID `json:"author_id" validate:"required"`
+	Role     Role      `json:"role" validate:"required,oneof=author editor translator illustrator"`
+}
+
+// SetContributorsRequest replaces all the contributors of a book.
+type SetContributorsRequest struct {
+	Contributors []ContributorRequest `json:"contributors" validate:"dive"`
+}
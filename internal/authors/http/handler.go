@@ -0,0 +1,148 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/bkiran6398/library/internal/authors/domain"
+	"github.com/bkiran6398/library/internal/authors/service"
+	"github.com/bkiran6398/library/internal/http/response"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// Handler handles HTTP requests for authors and book contributors.
+type Handler struct {
+	service service.Service
+}
+
+// NewHandler creates a new Handler instance.
+func NewHandler(service service.Service) Handler {
+	return Handler{service: service}
+}
+
+func (handler Handler) List(w http.ResponseWriter, r *http.Request) {
+	queryParams := r.URL.Query()
+	limit, _ := strconv.Atoi(queryParams.Get("limit"))
+	offset, _ := strconv.Atoi(queryParams.Get("offset"))
+	filter := domain.ListFilter{Limit: limit, Offset: offset}
+	if name := queryParams.Get("name"); name != "" {
+		filter.Name = &name
+	}
+
+	authors, err := handler.service.List(r.Context(), filter)
+	if err != nil {
+		response.MapServiceErrorToHTTP(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, authors)
+}
+
+func (handler Handler) Create(w http.ResponseWriter, r *http.Request) {
+	var createRequest domain.CreateAuthorRequest
+	if err := json.NewDecoder(r.Body).Decode(&createRequest); err != nil {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Invalid JSON body", nil)
+		return
+	}
+
+	author, err := handler.service.Create(r.Context(), createRequest)
+	if err != nil {
+		response.MapServiceErrorToHTTP(w, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, author)
+}
+
+func (handler Handler) Get(w http.ResponseWriter, r *http.Request) {
+	authorID, err := parseIDFromPath(r)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Invalid author ID", nil)
+		return
+	}
+
+	author, err := handler.service.Get(r.Context(), authorID)
+	if err != nil {
+		response.MapServiceErrorToHTTP(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, author)
+}
+
+func (handler Handler) Update(w http.ResponseWriter, r *http.Request) {
+	authorID, err := parseIDFromPath(r)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Invalid author ID", nil)
+		return
+	}
+
+	var updateRequest domain.UpdateAuthorRequest
+	if err := json.NewDecoder(r.Body).Decode(&updateRequest); err != nil {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Invalid JSON body", nil)
+		return
+	}
+
+	author, err := handler.service.Update(r.Context(), authorID, updateRequest)
+	if err != nil {
+		response.MapServiceErrorToHTTP(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, author)
+}
+
+func (handler Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	authorID, err := parseIDFromPath(r)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Invalid author ID", nil)
+		return
+	}
+
+	if err := handler.service.Delete(r.Context(), authorID); err != nil {
+		response.MapServiceErrorToHTTP(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Contributors serves GET /books/{id}/authors.
+func (handler Handler) Contributors(w http.ResponseWriter, r *http.Request) {
+	bookID, err := parseIDFromPath(r)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Invalid book ID", nil)
+		return
+	}
+
+	contributors, err := handler.service.Contributors(r.Context(), bookID)
+	if err != nil {
+		response.MapServiceErrorToHTTP(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, contributors)
+}
+
+// SetContributors serves PUT /books/{id}/authors.
+func (handler Handler) SetContributors(w http.ResponseWriter, r *http.Request) {
+	bookID, err := parseIDFromPath(r)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Invalid book ID", nil)
+		return
+	}
+
+	var setRequest domain.SetContributorsRequest
+	if err := json.NewDecoder(r.Body).Decode(&setRequest); err != nil {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Invalid JSON body", nil)
+		return
+	}
+
+	contributors, err := handler.service.SetContributors(r.Context(), bookID, setRequest)
+	if err != nil {
+		response.MapServiceErrorToHTTP(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, contributors)
+}
+
+// parseIDFromPath extracts and parses the author or book ID from the request path.
+func parseIDFromPath(r *http.Request) (uuid.UUID, error) {
+	return uuid.Parse(mux.Vars(r)["id"])
+}
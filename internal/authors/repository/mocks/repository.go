@@ -0,0 +1,147 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=repository.go -destination=mocks/repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/bkiran6398/library/internal/authors/domain"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Contributors mocks base method.
+func (m *MockRepository) Contributors(ctx context.Context, bookID uuid.UUID) ([]domain.Contributor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Contributors", ctx, bookID)
+	ret0, _ := ret[0].([]domain.Contributor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Contributors indicates an expected call of Contributors.
+func (mr *MockRepositoryMockRecorder) Contributors(ctx, bookID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Contributors", reflect.TypeOf((*MockRepository)(nil).Contributors), ctx, bookID)
+}
+
+// Create mocks base method.
+func (m *MockRepository) Create(ctx context.Context, author domain.Author) (domain.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, author)
+	ret0, _ := ret[0].(domain.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRepositoryMockRecorder) Create(ctx, author any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), ctx, author)
+}
+
+// Delete mocks base method.
+func (m *MockRepository) Delete(ctx context.Context, authorID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, authorID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRepositoryMockRecorder) Delete(ctx, authorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), ctx, authorID)
+}
+
+// Get mocks base method.
+func (m *MockRepository) Get(ctx context.Context, authorID uuid.UUID) (domain.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, authorID)
+	ret0, _ := ret[0].(domain.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRepositoryMockRecorder) Get(ctx, authorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepository)(nil).Get), ctx, authorID)
+}
+
+// List mocks base method.
+func (m *MockRepository) List(ctx context.Context, filter domain.ListFilter) ([]domain.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].([]domain.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockRepositoryMockRecorder) List(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRepository)(nil).List), ctx, filter)
+}
+
+// SetContributors mocks base method.
+func (m *MockRepository) SetContributors(ctx context.Context, bookID uuid.UUID, contributors []domain.ContributorRequest) ([]domain.Contributor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetContributors", ctx, bookID, contributors)
+	ret0, _ := ret[0].([]domain.Contributor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetContributors indicates an expected call of SetContributors.
+func (mr *MockRepositoryMockRecorder) SetContributors(ctx, bookID, contributors any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetContributors", reflect.TypeOf((*MockRepository)(nil).SetContributors), ctx, bookID, contributors)
+}
+
+// Update mocks base method.
+func (m *MockRepository) Update(ctx context.Context, author domain.Author) (domain.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, author)
+	ret0, _ := ret[0].(domain.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockRepositoryMockRecorder) Update(ctx, author any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepository)(nil).Update), ctx, author)
+}
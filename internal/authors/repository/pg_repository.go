@@ -0,0 +1,275 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bkiran6398/library/internal/authors/domain"
+	intErr "github.com/bkiran6398/library/internal/errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const authorColumns = `id, name, created_at, updated_at`
+
+// syncBookAuthorTextQuery rewrites books.author from the names credited with the author role,
+// in credit order, for the books in $1. Books without such credits keep their text.
+const syncBookAuthorTextQuery = `
+UPDATE books SET author=credited.names, updated_at=NOW()
+FROM (
+	SELECT book_authors.book_id, string_agg(authors.name, ' and ' ORDER BY book_authors.ordinal) AS names
+	FROM book_authors JOIN authors ON authors.id = book_authors.author_id
+	WHERE book_authors.role = 'author' AND book_authors.book_id = ANY($1)
+	GROUP BY book_authors.book_id
+) AS credited
+WHERE books.id = credited.book_id AND books.author <> credited.names;
+`
+
+// pgRepository is the PostgreSQL implementation of Repository.
+type pgRepository struct {
+	dbPool *pgxpool.Pool
+}
+
+// NewPgRepository creates a new PostgreSQL-based Repository implementation.
+// This constructor is the only place where consumers should depend on the concrete type.
+func NewPgRepository(dbPool *pgxpool.Pool) *pgRepository {
+	return &pgRepository{dbPool: dbPool}
+}
+
+func (repository *pgRepository) Create(ctx context.Context, author domain.Author) (domain.Author, error) {
+	const insertQuery = `
+INSERT INTO authors (id, name, created_at, updated_at)
+VALUES ($1,$2,NOW(),NOW())
+RETURNING created_at, updated_at;
+`
+	row := repository.dbPool.QueryRow(ctx, insertQuery, author.ID, author.Name)
+	if err := row.Scan(&author.CreatedAt, &author.UpdatedAt); err != nil {
+		if isPgErrorCode(err, "23505") {
+			return domain.Author{}, fmt.Errorf("%w: an author with this name already exists", intErr.ErrConflict)
+		}
+		return domain.Author{}, fmt.Errorf("insert author: %w", err)
+	}
+	return author, nil
+}
+
+// isPgErrorCode checks if the error is a PostgreSQL error with the given SQLSTATE code.
+func isPgErrorCode(err error, code string) bool {
+	var pgError *pgconn.PgError
+	return errors.As(err, &pgError) && pgError.Code == code
+}
+
+func (repository *pgRepository) Get(ctx context.Context, authorID uuid.UUID) (domain.Author, error) {
+	const selectQuery = `SELECT ` + authorColumns + ` FROM authors WHERE id=$1;`
+	return queryOne(ctx, repository.dbPool, "get author", selectQuery, authorID)
+}
+
+func (repository *pgRepository) Update(ctx context.Context, author domain.Author) (domain.Author, error) {
+	tx, err := repository.dbPool.Begin(ctx)
+	if err != nil {
+		return domain.Author{}, fmt.Errorf("begin update author: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	const updateQuery = `
+UPDATE authors SET name=$2, updated_at=NOW()
+WHERE id=$1
+RETURNING ` + authorColumns + `;`
+	updated, err := queryOne(ctx, tx, "update author", updateQuery, author.ID, author.Name)
+	if err != nil {
+		if isPgErrorCode(err, "23505") {
+			return domain.Author{}, fmt.Errorf("%w: an author with this name already exists", intErr.ErrConflict)
+		}
+		return domain.Author{}, err
+	}
+
+	const creditedBooksQuery = `SELECT DISTINCT book_id FROM book_authors WHERE author_id=$1 AND role='author';`
+	rows, err := tx.Query(ctx, creditedBooksQuery, author.ID)
+	if err != nil {
+		return domain.Author{}, fmt.Errorf("list credited books: %w", err)
+	}
+	bookIDs, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		return domain.Author{}, fmt.Errorf("list credited books: %w", err)
+	}
+	if _, err := tx.Exec(ctx, syncBookAuthorTextQuery, bookIDs); err != nil {
+		return domain.Author{}, fmt.Errorf("sync book authors: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.Author{}, fmt.Errorf("commit update author: %w", err)
+	}
+	return updated, nil
+}
+
+func (repository *pgRepository) Delete(ctx context.Context, authorID uuid.UUID) error {
+	const deleteQuery = `DELETE FROM authors WHERE id=$1;`
+	result, err := repository.dbPool.Exec(ctx, deleteQuery, authorID)
+	if err != nil {
+		if isPgErrorCode(err, "23503") {
+			return fmt.Errorf("%w: the author is still credited on books", intErr.ErrConflict)
+		}
+		return fmt.Errorf("delete author: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return intErr.ErrNotFound
+	}
+	return nil
+}
+
+func (repository *pgRepository) List(ctx context.Context, filter domain.ListFilter) ([]domain.Author, error) {
+	query := `SELECT ` + authorColumns + ` FROM authors`
+	var queryArguments []any
+	if filter.Name != nil && *filter.Name != "" {
+		query += " WHERE name ILIKE $1"
+		queryArguments = append(queryArguments, "%"+*filter.Name+"%")
+	}
+	query += " ORDER BY lower(name), id"
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", filter.Limit)
+	}
+	if filter.Offset > 0 {
+		query += fmt.Sprintf(" OFFSET %d", filter.Offset)
+	}
+
+	rows, err := repository.dbPool.Query(ctx, query, queryArguments...)
+	if err != nil {
+		return nil, fmt.Errorf("list authors: %w", err)
+	}
+	defer rows.Close()
+
+	var authors []domain.Author
+	for rows.Next() {
+		author, err := scanAuthor(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan author row: %w", err)
+		}
+		authors = append(authors, author)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return authors, nil
+}
+
+func (repository *pgRepository) Contributors(ctx context.Context, bookID uuid.UUID) ([]domain.Contributor, error) {
+	if err := requireBook(ctx, repository.dbPool, bookID, ""); err != nil {
+		return nil, err
+	}
+	return selectContributors(ctx, repository.dbPool, bookID)
+}
+
+func (repository *pgRepository) SetContributors(ctx context.Context, bookID uuid.UUID, contributors []domain.ContributorRequest) ([]domain.Contributor, error) {
+	tx, err := repository.dbPool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin set contributors: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Locking the book serialises concurrent replacements of its contributors.
+	if err := requireBook(ctx, tx, bookID, " FOR UPDATE"); err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM book_authors WHERE book_id=$1;`, bookID); err != nil {
+		return nil, fmt.Errorf("clear contributors: %w", err)
+	}
+
+	authorIDs := make([]uuid.UUID, len(contributors))
+	roles := make([]string, len(contributors))
+	for index, contributor := range contributors {
+		authorIDs[index], roles[index] = contributor.AuthorID, string(contributor.Role)
+	}
+	const insertQuery = `
+INSERT INTO book_authors (book_id, author_id, role, ordinal)
+SELECT $1, credit.author_id, credit.role, credit.ordinal
+FROM unnest($2::uuid[], $3::text[]) WITH ORDINALITY AS credit(author_id, role, ordinal);
+`
+	if _, err := tx.Exec(ctx, insertQuery, bookID, authorIDs, roles); err != nil {
+		switch {
+		case isPgErrorCode(err, "23503"):
+			return nil, fmt.Errorf("%w: unknown author", intErr.ErrBadRequest)
+		case isPgErrorCode(err, "23505"):
+			return nil, fmt.Errorf("%w: an author is credited twice with the same role", intErr.ErrBadRequest)
+		}
+		return nil, fmt.Errorf("insert contributors: %w", err)
+	}
+	if _, err := tx.Exec(ctx, syncBookAuthorTextQuery, []uuid.UUID{bookID}); err != nil {
+		return nil, fmt.Errorf("sync book authors: %w", err)
+	}
+
+	credited, err := selectContributors(ctx, tx, bookID)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit set contributors: %w", err)
+	}
+	return credited, nil
+}
+
+// querier is satisfied by both the pool and a transaction.
+type querier interface {
+	Query(ctx context.Context, query string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, query string, args ...any) pgx.Row
+}
+
+// requireBook returns ErrNotFound unless the book exists. lock is appended to the query.
+func requireBook(ctx context.Context, db querier, bookID uuid.UUID, lock string) error {
+	var found uuid.UUID
+	if err := db.QueryRow(ctx, `SELECT id FROM books WHERE id=$1`+lock+`;`, bookID).Scan(&found); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return intErr.ErrNotFound
+		}
+		return fmt.Errorf("get book: %w", err)
+	}
+	return nil
+}
+
+// selectContributors reads the contributors of a book in credit order.
+func selectContributors(ctx context.Context, db querier, bookID uuid.UUID) ([]domain.Contributor, error) {
+	const selectQuery = `
+SELECT book_authors.author_id, authors.name, book_authors.role, book_authors.ordinal
+FROM book_authors JOIN authors ON authors.id = book_authors.author_id
+WHERE book_authors.book_id=$1
+ORDER BY book_authors.ordinal;
+`
+	rows, err := db.Query(ctx, selectQuery, bookID)
+	if err != nil {
+		return nil, fmt.Errorf("list contributors: %w", err)
+	}
+	defer rows.Close()
+
+	contributors := []domain.Contributor{}
+	for rows.Next() {
+		var contributor domain.Contributor
+		if err := rows.Scan(&contributor.AuthorID, &contributor.Name, &contributor.Role, &contributor.Position); err != nil {
+			return nil, fmt.Errorf("scan contributor row: %w", err)
+		}
+		contributors = append(contributors, contributor)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return contributors, nil
+}
+
+// queryOne runs a query expected to return a single author row.
+func queryOne(ctx context.Context, db querier, operation, query string, args ...any) (domain.Author, error) {
+	author, err := scanAuthor(db.QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Author{}, intErr.ErrNotFound
+		}
+		return domain.Author{}, fmt.Errorf("%s: %w", operation, err)
+	}
+	return author, nil
+}
+
+// scanAuthor scans a single row into an Author entity.
+func scanAuthor(row pgx.Row) (domain.Author, error) {
+	var author domain.Author
+	err := row.Scan(&author.ID, &author.Name, &author.CreatedAt, &author.UpdatedAt)
+	return author, err
+}
@@ -0,0 +1,26 @@
+//go:generate mockgen -source=repository.go -destination=mocks/repository.go -package=mocks
+package repository
+
+import (
+	"context"
+
+	"github.com/bkiran6398/library/internal/authors/domain"
+	"github.com/google/uuid"
+)
+
+// Repository defines the interface for author data access operations.
+// Consumers should depend on this interface, not on concrete implementations.
+type Repository interface {
+	Create(ctx context.Context, author domain.Author) (domain.Author, error)
+	Get(ctx context.Context, authorID uuid.UUID) (domain.Author, error)
+	// Update renames an author and rewrites the author text of the books crediting them.
+	Update(ctx context.Context, author domain.Author) (domain.Author, error)
+	// Delete removes an author. It fails with ErrConflict while books still credit them.
+	Delete(ctx context.Context, authorID uuid.UUID) error
+	List(ctx context.Context, filter domain.ListFilter) ([]domain.Author, error)
+	// Contributors returns the contributors of a book in credit order.
+	Contributors(ctx context.Context, bookID uuid.UUID) ([]domain.Contributor, error)
+	// SetContributors replaces the contributors of a book in one transaction, in the order
+	// given, and rewrites the book's author text from those credited as authors.
+	SetContributors(ctx context.Context, bookID uuid.UUID, contributors []domain.ContributorRequest) ([]domain.Contributor, error)
+}
@@ -0,0 +1,147 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=service.go -destination=mocks/service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/bkiran6398/library/internal/authors/domain"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// Contributors mocks base method.
+func (m *MockService) Contributors(ctx context.Context, bookID uuid.UUID) ([]domain.Contributor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Contributors", ctx, bookID)
+	ret0, _ := ret[0].([]domain.Contributor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Contributors indicates an expected call of Contributors.
+func (mr *MockServiceMockRecorder) Contributors(ctx, bookID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Contributors", reflect.TypeOf((*MockService)(nil).Contributors), ctx, bookID)
+}
+
+// Create mocks base method.
+func (m *MockService) Create(ctx context.Context, createRequest domain.CreateAuthorRequest) (domain.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, createRequest)
+	ret0, _ := ret[0].(domain.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockServiceMockRecorder) Create(ctx, createRequest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockService)(nil).Create), ctx, createRequest)
+}
+
+// Delete mocks base method.
+func (m *MockService) Delete(ctx context.Context, authorID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, authorID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockServiceMockRecorder) Delete(ctx, authorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockService)(nil).Delete), ctx, authorID)
+}
+
+// Get mocks base method.
+func (m *MockService) Get(ctx context.Context, authorID uuid.UUID) (domain.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, authorID)
+	ret0, _ := ret[0].(domain.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockServiceMockRecorder) Get(ctx, authorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockService)(nil).Get), ctx, authorID)
+}
+
+// List mocks base method.
+func (m *MockService) List(ctx context.Context, filter domain.ListFilter) ([]domain.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].([]domain.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockServiceMockRecorder) List(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockService)(nil).List), ctx, filter)
+}
+
+// SetContributors mocks base method.
+func (m *MockService) SetContributors(ctx context.Context, bookID uuid.UUID, setRequest domain.SetContributorsRequest) ([]domain.Contributor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetContributors", ctx, bookID, setRequest)
+	ret0, _ := ret[0].([]domain.Contributor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetContributors indicates an expected call of SetContributors.
+func (mr *MockServiceMockRecorder) SetContributors(ctx, bookID, setRequest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetContributors", reflect.TypeOf((*MockService)(nil).SetContributors), ctx, bookID, setRequest)
+}
+
+// Update mocks base method.
+func (m *MockService) Update(ctx context.Context, authorID uuid.UUID, updateRequest domain.UpdateAuthorRequest) (domain.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, authorID, updateRequest)
+	ret0, _ := ret[0].(domain.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockServiceMockRecorder) Update(ctx, authorID, updateRequest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockService)(nil).Update), ctx, authorID, updateRequest)
+}
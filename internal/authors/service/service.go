@@ -0,0 +1,21 @@
+//go:generate mockgen -source=service.go -destination=mocks/service.go -package=mocks
+package service
+
+import (
+	"context"
+
+	"github.com/bkiran6398/library/internal/authors/domain"
+	"github.com/google/uuid"
+)
+
+// Service defines the interface for author business logic operations.
+// Consumers should depend on this interface, not on concrete implementations.
+type Service interface {
+	Create(ctx context.Context, createRequest domain.CreateAuthorRequest) (domain.Author, error)
+	Get(ctx context.Context, authorID uuid.UUID) (domain.Author, error)
+	Update(ctx context.Context, authorID uuid.UUID, updateRequest domain.UpdateAuthorRequest) (domain.Author, error)
+	Delete(ctx context.Context, authorID uuid.UUID) error
+	List(ctx context.Context, filter domain.ListFilter) ([]domain.Author, error)
+	Contributors(ctx context.Context, bookID uuid.UUID) ([]domain.Contributor, error)
+	SetContributors(ctx context.Context, bookID uuid.UUID, setRequest domain.SetContributorsRequest) ([]domain.Contributor, error)
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bkiran6398/library/internal/authors/domain"
+	"github.com/bkiran6398/library/internal/authors/repository"
+	intErr "github.com/bkiran6398/library/internal/errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+const (
+	createTimeout          = 5 * time.Second
+	getTimeout             = 3 * time.Second
+	updateTimeout          = 5 * time.Second
+	deleteTimeout          = 5 * time.Second
+	listTimeout            = 10 * time.Second
+	setContributorsTimeout = 5 * time.Second
+)
+
+// maxContributors bounds the number of contributors credited on one book.
+const maxContributors = 50
+
+// service is the implementation of Service.
+type service struct {
+	repository repository.Repository
+	validator  *validator.Validate
+}
+
+// NewService creates a new Service implementation.
+// This constructor is the only place where consumers should depend on the concrete type.
+func NewService(repository repository.Repository) Service {
+	return &service{
+		repository: repository,
+		validator:  validator.New(),
+	}
+}
+
+func (serviceInstance *service) Create(ctx context.Context, createRequest domain.CreateAuthorRequest) (domain.Author, error) {
+	createRequest.Name = normalizeName(createRequest.Name)
+	if err := serviceInstance.validator.Struct(createRequest); err != nil {
+		return domain.Author{}, fmt.Errorf("%w: %v", intErr.ErrBadRequest, err)
+	}
+
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+	return serviceInstance.repository.Create(ctxWithTimeout, domain.Author{ID: uuid.New(), Name: createRequest.Name})
+}
+
+func (serviceInstance *service) Get(ctx context.Context, authorID uuid.UUID) (domain.Author, error) {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, getTimeout)
+	defer cancel()
+	return serviceInstance.repository.Get(ctxWithTimeout, authorID)
+}
+
+func (serviceInstance *service) Update(ctx context.Context, authorID uuid.UUID, updateRequest domain.UpdateAuthorRequest) (domain.Author, error) {
+	updateRequest.Name = normalizeName(updateRequest.Name)
+	if err := serviceInstance.validator.Struct(updateRequest); err != nil {
+		return domain.Author{}, fmt.Errorf("%w: %v", intErr.ErrBadRequest, err)
+	}
+
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+	return serviceInstance.repository.Update(ctxWithTimeout, domain.Author{ID: authorID, Name: updateRequest.Name})
+}
+
+func (serviceInstance *service) Delete(ctx context.Context, authorID uuid.UUID) error {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+	return serviceInstance.repository.Delete(ctxWithTimeout, authorID)
+}
+
+func (serviceInstance *service) List(ctx context.Context, filter domain.ListFilter) ([]domain.Author, error) {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, listTimeout)
+	defer cancel()
+	return serviceInstance.repository.List(ctxWithTimeout, filter)
+}
+
+func (serviceInstance *service) Contributors(ctx context.Context, bookID uuid.UUID) ([]domain.Contributor, error) {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, getTimeout)
+	defer cancel()
+	return serviceInstance.repository.Contributors(ctxWithTimeout, bookID)
+}
+
+func (serviceInstance *service) SetContributors(ctx context.Context, bookID uuid.UUID, setRequest domain.SetContributorsRequest) ([]domain.Contributor, error) {
+	if err := serviceInstance.validator.Struct(setRequest); err != nil {
+		return nil, fmt.Errorf("%w: %v", intErr.ErrBadRequest, err)
+	}
+	if len(setRequest.Contributors) > maxContributors {
+		return nil, fmt.Errorf("%w: at most %d contributors are allowed", intErr.ErrBadRequest, maxContributors)
+	}
+	type credit struct {
+		authorID uuid.UUID
+		role     domain.Role
+	}
+	seen := make(map[credit]bool, len(setRequest.Contributors))
+	for _, contributor := range setRequest.Contributors {
+		key := credit{authorID: contributor.AuthorID, role: contributor.Role}
+		if seen[key] {
+			return nil, fmt.Errorf("%w: author %s is credited twice as %s", intErr.ErrBadRequest, contributor.AuthorID, contributor.Role)
+		}
+		seen[key] = true
+	}
+
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, setContributorsTimeout)
+	defer cancel()
+	return serviceInstance.repository.SetContributors(ctxWithTimeout, bookID, setRequest.Contributors)
+}
+
+// normalizeName trims the name and collapses runs of whitespace, so "Le  Guin" and
+// "Le Guin" are the same author.
+func normalizeName(name string) string {
+	return strings.Join(strings.Fields(name), " ")
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bkiran6398/library/internal/authors/domain"
+	"github.com/bkiran6398/library/internal/authors/repository/mocks"
+	intErr "github.com/bkiran6398/library/internal/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreate_NormalizesName(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	service := NewService(mockRepo)
+
+	mockRepo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, author domain.Author) (domain.Author, error) {
+			require.NotEqual(t, uuid.Nil, author.ID)
+			require.Equal(t, "Ursula K. Le Guin", author.Name)
+			return author, nil
+		}).
+		Times(1)
+
+	created, err := service.Create(context.Background(), domain.CreateAuthorRequest{Name: "  Ursula K.  Le\tGuin "})
+	require.NoError(t, err)
+	require.Equal(t, "Ursula K. Le Guin", created.Name)
+}
+
+func TestCreate_ValidationError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	service := NewService(mockRepo)
+
+	_, err := service.Create(context.Background(), domain.CreateAuthorRequest{Name: "   "})
+	require.ErrorIs(t, err, intErr.ErrBadRequest)
+
+	_, err = service.Update(context.Background(), uuid.New(), domain.UpdateAuthorRequest{Name: ""})
+	require.ErrorIs(t, err, intErr.ErrBadRequest)
+}
+
+func TestUpdate_Renames(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	service := NewService(mockRepo)
+
+	authorID := uuid.New()
+	renamed := domain.Author{ID: authorID, Name: "Octavia E. Butler"}
+	mockRepo.EXPECT().Update(gomock.Any(), renamed).Return(renamed, nil).Times(1)
+
+	updated, err := service.Update(context.Background(), authorID, domain.UpdateAuthorRequest{Name: "Octavia E.  Butler"})
+	require.NoError(t, err)
+	require.Equal(t, renamed, updated)
+}
+
+func TestSetContributors_PassesCreditsInOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	service := NewService(mockRepo)
+
+	bookID, authorID, translatorID := uuid.New(), uuid.New(), uuid.New()
+	credits := []domain.ContributorRequest{
+		{AuthorID: authorID, Role: domain.RoleAuthor},
+		{AuthorID: translatorID, Role: domain.RoleTranslator},
+		{AuthorID: authorID, Role: domain.RoleIllustrator},
+	}
+	expected := []domain.Contributor{
+		{AuthorID: authorID, Name: "Tove Jansson", Role: domain.RoleAuthor, Position: 1},
+		{AuthorID: translatorID, Name: "Thomas Teal", Role: domain.RoleTranslator, Position: 2},
+		{AuthorID: authorID, Name: "Tove Jansson", Role: domain.RoleIllustrator, Position: 3},
+	}
+	mockRepo.EXPECT().SetContributors(gomock.Any(), bookID, credits).Return(expected, nil).Times(1)
+
+	contributors, err := service.SetContributors(context.Background(), bookID, domain.SetContributorsRequest{Contributors: credits})
+	require.NoError(t, err)
+	require.Equal(t, expected, contributors)
+}
+
+func TestSetContributors_RejectsInvalidCredits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	service := NewService(mockRepo)
+
+	authorID := uuid.New()
+	tests := []struct {
+		name    string
+		credits []domain.ContributorRequest
+	}{
+		{name: "unknown role", credits: []domain.ContributorRequest{{AuthorID: authorID, Role: "narrator"}}},
+		{name: "missing author", credits: []domain.ContributorRequest{{Role: domain.RoleAuthor}}},
+		{name: "duplicate credit", credits: []domain.ContributorRequest{
+			{AuthorID: authorID, Role: domain.RoleEditor},
+			{AuthorID: authorID, Role: domain.RoleEditor},
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := service.SetContributors(context.Background(), uuid.New(), domain.SetContributorsRequest{Contributors: test.credits})
+			require.ErrorIs(t, err, intErr.ErrBadRequest)
+		})
+	}
+}
@@ -0,0 +1,254 @@
+package codec
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/bkiran6398/library/internal/books/domain"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// ContentTypeBibTeX is the media type of BibTeX databases.
+	ContentTypeBibTeX = "application/x-bibtex"
+	// ContentTypeRIS is the media type of RIS (Research Information Systems) files.
+	ContentTypeRIS = "application/x-research-info-systems"
+	// ContentTypeCSLJSON is the media type of CSL-JSON citation data.
+	ContentTypeCSLJSON = "application/vnd.citationstyles.csl+json"
+)
+
+// titleStopWords are skipped when picking the title word of a citation key.
+var titleStopWords = map[string]bool{
+	"a": true, "an": true, "the": true, "of": true, "on": true, "in": true, "and": true,
+	"to": true, "for": true, "with": true, "der": true, "die": true, "das": true,
+	"le": true, "la": true, "les": true, "el": true, "los": true,
+}
+
+// CitationKeys returns a citation key per book, in order. A key is the author's family
+// name, the year (or "nd") and the first significant title word, folded to lower-case
+// ASCII, e.g. "donovan2015go". Books sharing a key within one batch are told apart by a
+// suffix taken from the book itself: the last four ISBN characters ("donovan2015go0440"),
+// or the last eight hex digits of the ID when those match too. A book's suffix therefore
+// never depends on which other books were requested or in what order.
+func CitationKeys(books []domain.Book) []string {
+	keys := make([]string, len(books))
+	positionsByKey := map[string][]int{}
+	for position, book := range books {
+		keys[position] = citationKey(book)
+		positionsByKey[keys[position]] = append(positionsByKey[keys[position]], position)
+	}
+
+	for key, positions := range positionsByKey {
+		if len(positions) < 2 {
+			continue
+		}
+		isbnSuffixCounts := map[string]int{}
+		for _, position := range positions {
+			isbnSuffixCounts[isbnSuffix(books[position])]++
+		}
+		for _, position := range positions {
+			suffix := isbnSuffix(books[position])
+			if suffix == "" || isbnSuffixCounts[suffix] > 1 {
+				suffix = idSuffix(books[position])
+			}
+			keys[position] = key + suffix
+		}
+	}
+	return keys
+}
+
+func citationKey(book domain.Book) string {
+	year := "nd"
+	if book.PublishedYear != nil {
+		year = strconv.Itoa(*book.PublishedYear)
+	}
+
+	titleWord := ""
+	for _, word := range strings.Fields(book.Title) {
+		if folded := foldASCII(word); folded != "" && !titleStopWords[folded] {
+			titleWord = folded
+			break
+		}
+	}
+
+	key := foldASCII(familyName(book.Author)) + year + titleWord
+	if key == year {
+		return "book" + year
+	}
+	return key
+}
+
+// isbnSuffix returns the last four characters of the book's ISBN, folded like the key.
+func isbnSuffix(book domain.Book) string {
+	isbn := foldASCII(book.ISBN)
+	return isbn[max(len(isbn)-4, 0):]
+}
+
+// idSuffix returns the last eight hex digits of the book's ID.
+func idSuffix(book domain.Book) string {
+	id := book.ID.String()
+	return id[len(id)-8:]
+}
+
+// familyName returns the part of "Family, Given" before the comma, or the last word of
+// "Given Family".
+func familyName(author string) string {
+	if family, _, found := strings.Cut(author, ","); found {
+		return family
+	}
+	words := strings.Fields(author)
+	if len(words) == 0 {
+		return ""
+	}
+	return words[len(words)-1]
+}
+
+// foldASCII strips diacritics and keeps only lower-case ASCII letters and digits.
+func foldASCII(value string) string {
+	var folded strings.Builder
+	for _, character := range norm.NFD.String(value) {
+		character = unicode.ToLower(character)
+		if character < unicode.MaxASCII && (unicode.IsLetter(character) || unicode.IsDigit(character)) {
+			folded.WriteRune(character)
+		}
+	}
+	return folded.String()
+}
+
+// WriteBibTeX writes one @book entry per book.
+func WriteBibTeX(destination io.Writer, books []domain.Book) error {
+	keys := CitationKeys(books)
+	for index, book := range books {
+		fields := [][2]string{
+			{"author", escapeBibTeX(book.Author)},
+			{"title", escapeBibTeX(book.Title)},
+		}
+		if book.PublishedYear != nil {
+			fields = append(fields, [2]string{"year", strconv.Itoa(*book.PublishedYear)})
+		}
+		fields = append(fields, [2]string{"isbn", escapeBibTeX(book.ISBN)})
+
+		var entry strings.Builder
+		if index > 0 {
+			entry.WriteString("\n")
+		}
+		fmt.Fprintf(&entry, "@book{%s,\n", keys[index])
+		for _, field := range fields {
+			fmt.Fprintf(&entry, "  %-6s = {%s},\n", field[0], field[1])
+		}
+		entry.WriteString("}\n")
+		if _, err := io.WriteString(destination, entry.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bibTeXEscaper escapes the characters LaTeX treats specially inside a braced field value.
+var bibTeXEscaper = strings.NewReplacer(
+	`\`, `\textbackslash{}`,
+	`{`, `\{`,
+	`}`, `\}`,
+	`&`, `\&`,
+	`%`, `\%`,
+	`$`, `\$`,
+	`#`, `\#`,
+	`_`, `\_`,
+	`~`, `\textasciitilde{}`,
+	`^`, `\textasciicircum{}`,
+)
+
+func escapeBibTeX(value string) string {
+	return bibTeXEscaper.Replace(singleLine(value))
+}
+
+// WriteRIS writes one BOOK reference per book with CRLF line endings, as the format expects.
+func WriteRIS(destination io.Writer, books []domain.Book) error {
+	keys := CitationKeys(books)
+	for index, book := range books {
+		var reference strings.Builder
+		writeTag := func(tag, value string) {
+			fmt.Fprintf(&reference, "%s  - %s\r\n", tag, value)
+		}
+		writeTag("TY", "BOOK")
+		writeTag("ID", keys[index])
+		writeTag("AU", singleLine(book.Author))
+		writeTag("TI", singleLine(book.Title))
+		if book.PublishedYear != nil {
+			writeTag("PY", strconv.Itoa(*book.PublishedYear))
+		}
+		writeTag("SN", singleLine(book.ISBN))
+		writeTag("ER", "")
+		if _, err := io.WriteString(destination, reference.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// singleLine replaces line breaks and other control characters, which would end a RIS tag
+// or a BibTeX field early, with spaces.
+func singleLine(value string) string {
+	return strings.TrimSpace(strings.Map(func(character rune) rune {
+		if unicode.IsControl(character) {
+			return ' '
+		}
+		return character
+	}, value))
+}
+
+// cslItem is a CSL-JSON item of type book.
+type cslItem struct {
+	ID     string    `json:"id"`
+	Type   string    `json:"type"`
+	Title  string    `json:"title"`
+	Author []cslName `json:"author,omitempty"`
+	Issued *cslDate  `json:"issued,omitempty"`
+	ISBN   string    `json:"ISBN,omitempty"`
+}
+
+// cslName is a structured "Family, Given" name, or a literal one such as an organisation.
+type cslName struct {
+	Family  string `json:"family,omitempty"`
+	Given   string `json:"given,omitempty"`
+	Literal string `json:"literal,omitempty"`
+}
+
+type cslDate struct {
+	DateParts [][]int `json:"date-parts"`
+}
+
+// WriteCSLJSON writes the books as a CSL-JSON array.
+func WriteCSLJSON(destination io.Writer, books []domain.Book) error {
+	keys := CitationKeys(books)
+	items := make([]cslItem, 0, len(books))
+	for index, book := range books {
+		item := cslItem{ID: keys[index], Type: "book", Title: book.Title, ISBN: book.ISBN}
+		if name := cslNameOf(book.Author); name != (cslName{}) {
+			item.Author = []cslName{name}
+		}
+		if book.PublishedYear != nil {
+			item.Issued = &cslDate{DateParts: [][]int{{*book.PublishedYear}}}
+		}
+		items = append(items, item)
+	}
+
+	encoder := json.NewEncoder(destination)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(items)
+}
+
+// cslNameOf splits "Family, Given"; names without a comma are kept literal, since
+// "Given Family" cannot be told apart from an organisation name.
+func cslNameOf(author string) cslName {
+	family, given, found := strings.Cut(author, ",")
+	if !found {
+		return cslName{Literal: strings.TrimSpace(author)}
+	}
+	return cslName{Family: strings.TrimSpace(family), Given: strings.TrimSpace(given)}
+}
@@ -0,0 +1,89 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/bkiran6398/library/internal/books/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func citationBook(id, title, author string, year int) domain.Book {
+	book := domain.Book{ID: uuid.MustParse(id), Title: title, Author: author, ISBN: "9780134190440"}
+	if year != 0 {
+		book.PublishedYear = &year
+	}
+	return book
+}
+
+func TestCitationKeys(t *testing.T) {
+	first := citationBook("00000000-0000-0000-0000-000000000002", "The Go Programming Language", "Donovan, Alan A. A.", 2015)
+	second := citationBook("00000000-0000-0000-0000-000000000001", "Go in Action", "Kennedy, William", 2015)
+	accented := citationBook("00000000-0000-0000-0000-000000000003", "Élan vital", "Gödel Müller", 0)
+	untitled := citationBook("00000000-0000-0000-0000-000000000004", "!!!", "", 1999)
+
+	require.Equal(t, []string{"donovan2015go", "kennedy2015go", "mullerndelan", "book1999"},
+		CitationKeys([]domain.Book{first, second, accented, untitled}))
+
+	reprint := citationBook("00000000-0000-0000-0000-000000000001", "The Go Programming Language", "Donovan, Alan", 2015)
+	reprint.ISBN = "978-0-13-419044-X"
+	require.Equal(t, []string{"donovan2015go0440", "donovan2015go044x"}, CitationKeys([]domain.Book{first, reprint}))
+	require.Equal(t, []string{"donovan2015go044x", "donovan2015go0440"}, CitationKeys([]domain.Book{reprint, first}))
+
+	sameISBN := citationBook("00000000-0000-0000-0000-0000abcdef01", "The Go Programming Language", "Donovan, A.", 2015)
+	require.Equal(t, []string{"donovan2015go00000002", "donovan2015goabcdef01", "donovan2015go044x"},
+		CitationKeys([]domain.Book{first, sameISBN, reprint}))
+}
+
+func TestWriteBibTeX_Escapes(t *testing.T) {
+	book := citationBook("00000000-0000-0000-0000-000000000001", "Profit & Loss: 100% {Real} $ums_#1 ~^\\", "O'Neil,\nCathy", 2016)
+
+	var output bytes.Buffer
+	require.NoError(t, WriteBibTeX(&output, []domain.Book{book}))
+
+	require.Equal(t, `@book{oneil2016profit,
+  author = {O'Neil, Cathy},
+  title  = {Profit \& Loss: 100\% \{Real\} \$ums\_\#1 \textasciitilde{}\textasciicircum{}\textbackslash{}},
+  year   = {2016},
+  isbn   = {9780134190440},
+}
+`, output.String())
+}
+
+func TestWriteRIS(t *testing.T) {
+	books := []domain.Book{
+		citationBook("00000000-0000-0000-0000-000000000001", "Line\r\nBreak", "Kennedy, William", 0),
+		citationBook("00000000-0000-0000-0000-000000000002", "Go in Action", "Kennedy, William", 2015),
+	}
+
+	var output bytes.Buffer
+	require.NoError(t, WriteRIS(&output, books))
+
+	require.Equal(t, "TY  - BOOK\r\nID  - kennedyndline\r\nAU  - Kennedy, William\r\nTI  - Line  Break\r\nSN  - 9780134190440\r\nER  - \r\n"+
+		"TY  - BOOK\r\nID  - kennedy2015go\r\nAU  - Kennedy, William\r\nTI  - Go in Action\r\nPY  - 2015\r\nSN  - 9780134190440\r\nER  - \r\n",
+		output.String())
+}
+
+func TestWriteCSLJSON(t *testing.T) {
+	books := []domain.Book{
+		citationBook("00000000-0000-0000-0000-000000000001", "Go <in> Action", "Kennedy, William", 2015),
+		citationBook("00000000-0000-0000-0000-000000000002", "Annual Report", "Example Corp", 0),
+	}
+
+	var output bytes.Buffer
+	require.NoError(t, WriteCSLJSON(&output, books))
+	require.Contains(t, output.String(), `"Go <in> Action"`)
+
+	var items []map[string]interface{}
+	require.NoError(t, json.Unmarshal(output.Bytes(), &items))
+	require.Len(t, items, 2)
+	require.Equal(t, "kennedy2015go", items[0]["id"])
+	require.Equal(t, "book", items[0]["type"])
+	require.Equal(t, []interface{}{map[string]interface{}{"family": "Kennedy", "given": "William"}}, items[0]["author"])
+	require.Equal(t, map[string]interface{}{"date-parts": []interface{}{[]interface{}{2015.0}}}, items[0]["issued"])
+	require.Equal(t, "9780134190440", items[0]["ISBN"])
+	require.Equal(t, []interface{}{map[string]interface{}{"literal": "Example Corp"}}, items[1]["author"])
+	require.NotContains(t, items[1], "issued")
+}
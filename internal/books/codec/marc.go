@@ -0,0 +1,140 @@
+// Package codec converts books to and from external bibliographic formats.
+package codec
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/bkiran6398/library/internal/books/domain"
+	"github.com/bkiran6398/library/internal/marc"
+)
+
+const (
+	// ContentTypeMARC is the media type of binary MARC 21 records.
+	ContentTypeMARC = "application/marc"
+	// ContentTypeMARCXML is the media type of MARCXML documents.
+	ContentTypeMARCXML = "application/marcxml+xml"
+)
+
+// yearPattern finds a four digit year in a free-text date such as "c2015." or "[2019?]".
+var yearPattern = regexp.MustCompile(`\d{4}`)
+
+// MARCRecord maps a book to a MARC 21 bibliographic record: 001 control number (the book
+// ID), 005 last update, 008 fixed-length data, 020 ISBN, 100 main author, 245 title and
+// 264 publication date.
+func MARCRecord(book domain.Book) marc.Record {
+	record := marc.Record{
+		ControlFields: []marc.ControlField{
+			{Tag: "001", Value: book.ID.String()},
+			{Tag: "005", Value: book.UpdatedAt.UTC().Format("20060102150405.0")},
+			{Tag: "008", Value: fixedLengthData(book)},
+		},
+		DataFields: []marc.DataField{
+			{Tag: "020", Ind1: ' ', Ind2: ' ', Subfields: []marc.Subfield{{Code: 'a', Value: book.ISBN}}},
+			{Tag: "100", Ind1: '1', Ind2: ' ', Subfields: []marc.Subfield{{Code: 'a', Value: book.Author}}},
+			{Tag: "245", Ind1: '1', Ind2: '0', Subfields: []marc.Subfield{{Code: 'a', Value: book.Title}}},
+		},
+	}
+	if book.PublishedYear != nil {
+		record.DataFields = append(record.DataFields, marc.DataField{
+			Tag: "264", Ind1: ' ', Ind2: '1',
+			Subfields: []marc.Subfield{{Code: 'c', Value: strconv.Itoa(*book.PublishedYear)}},
+		})
+	}
+	return record
+}
+
+// fixedLengthData builds the 40 character 008 field with the date entered and date 1; the
+// positions this catalog does not track are filled with "no attempt to code".
+func fixedLengthData(book domain.Book) string {
+	dateType, date := "n", "uuuu"
+	if book.PublishedYear != nil {
+		dateType, date = "s", fmt.Sprintf("%04d", *book.PublishedYear)
+	}
+	return book.CreatedAt.UTC().Format("060102") + dateType + date + "    xx " + strings.Repeat("|", 17) + "und|d"
+}
+
+// BookFromMARC maps a MARC 21 bibliographic record to a create request. Title comes from
+// 245 $a and $b, author from 100, 110 or 700 $a, ISBN from 020 $a and the year from 264 $c,
+// 260 $c or 008. Bibliographic records carry no holdings, so CopiesTotal is zero.
+func BookFromMARC(record marc.Record) (domain.CreateBookRequest, error) {
+	request := domain.CreateBookRequest{
+		Title:  marcTitle(record),
+		Author: firstSubfield(record, 'a', "100", "110", "700"),
+		ISBN:   marcISBN(record),
+	}
+	if request.Title == "" {
+		return request, errors.New("record has no title in 245 $a")
+	}
+	if request.ISBN == "" {
+		return request, errors.New("record has no ISBN in 020 $a")
+	}
+	if request.Author == "" {
+		return request, errors.New("record has no author in 100, 110 or 700 $a")
+	}
+	request.PublishedYear = marcYear(record)
+	return request, nil
+}
+
+func marcTitle(record marc.Record) string {
+	fields := record.DataFieldsByTag("245")
+	if len(fields) == 0 {
+		return ""
+	}
+	title, _ := fields[0].Subfield('a')
+	if remainder, ok := fields[0].Subfield('b'); ok {
+		title = trimPunctuation(title) + ": " + remainder
+	}
+	return trimPunctuation(title)
+}
+
+// marcISBN returns the first 020 $a without qualifiers such as "(pbk.)".
+func marcISBN(record marc.Record) string {
+	isbn := firstSubfield(record, 'a', "020")
+	if index := strings.IndexAny(isbn, " ("); index >= 0 {
+		isbn = isbn[:index]
+	}
+	return isbn
+}
+
+func marcYear(record marc.Record) *int {
+	candidates := []string{}
+	for _, field := range record.DataFieldsByTag("264") {
+		if field.Ind2 == '1' {
+			date, _ := field.Subfield('c')
+			candidates = append(candidates, date)
+		}
+	}
+	candidates = append(candidates, firstSubfield(record, 'c', "260"))
+	if fixed, ok := record.ControlField("008"); ok && len(fixed) >= 11 {
+		candidates = append(candidates, fixed[7:11])
+	}
+
+	for _, candidate := range candidates {
+		if match := yearPattern.FindString(candidate); match != "" {
+			year, _ := strconv.Atoi(match)
+			return &year
+		}
+	}
+	return nil
+}
+
+// firstSubfield returns the first non-empty subfield code from the first tag that has one.
+func firstSubfield(record marc.Record, code byte, tags ...string) string {
+	for _, tag := range tags {
+		for _, field := range record.DataFieldsByTag(tag) {
+			if value, ok := field.Subfield(code); ok && strings.TrimSpace(value) != "" {
+				return trimPunctuation(value)
+			}
+		}
+	}
+	return ""
+}
+
+// trimPunctuation removes the trailing ISBD punctuation catalogers put between subfields.
+func trimPunctuation(value string) string {
+	return strings.TrimSpace(strings.TrimRight(strings.TrimSpace(value), " /:;,="))
+}
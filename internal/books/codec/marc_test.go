@@ -0,0 +1,89 @@
+package codec
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bkiran6398/library/internal/books/domain"
+	"github.com/bkiran6398/library/internal/marc"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMARCRecord_RoundTrip(t *testing.T) {
+	year := 2015
+	book := domain.Book{
+		ID:            uuid.New(),
+		Title:         "The Go Programming Language",
+		Author:        "Donovan, Alan A. A.",
+		ISBN:          "9780134190440",
+		PublishedYear: &year,
+		CreatedAt:     time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		UpdatedAt:     time.Date(2025, 2, 3, 4, 5, 6, 0, time.UTC),
+	}
+
+	record := MARCRecord(book)
+	controlNumber, ok := record.ControlField("001")
+	require.True(t, ok)
+	require.Equal(t, book.ID.String(), controlNumber)
+	fixed, ok := record.ControlField("008")
+	require.True(t, ok)
+	require.Len(t, fixed, 40)
+	require.Equal(t, "250102s2015", fixed[:11])
+
+	encoded, err := marc.Marshal(record)
+	require.NoError(t, err)
+	decoded, err := marc.Unmarshal(encoded)
+	require.NoError(t, err)
+
+	request, err := BookFromMARC(decoded)
+	require.NoError(t, err)
+	require.Equal(t, book.Title, request.Title)
+	require.Equal(t, book.Author, request.Author)
+	require.Equal(t, book.ISBN, request.ISBN)
+	require.Equal(t, 2015, *request.PublishedYear)
+	require.Zero(t, request.CopiesTotal)
+}
+
+func TestBookFromMARC_CatalogerPunctuation(t *testing.T) {
+	record := marc.Record{
+		ControlFields: []marc.ControlField{{Tag: "008", Value: "250102s1999    xx ||||||||||||||||| und|d"}},
+		DataFields: []marc.DataField{
+			{Tag: "020", Ind1: ' ', Ind2: ' ', Subfields: []marc.Subfield{{Code: 'a', Value: "0262033844 (hardcover)"}}},
+			{Tag: "100", Ind1: '1', Ind2: ' ', Subfields: []marc.Subfield{{Code: 'a', Value: "Cormen, Thomas H.,"}, {Code: 'e', Value: "author."}}},
+			{Tag: "245", Ind1: '1', Ind2: '0', Subfields: []marc.Subfield{{Code: 'a', Value: "Introduction to algorithms /"}, {Code: 'c', Value: "Thomas H. Cormen ... [et al.]."}}},
+			{Tag: "264", Ind1: ' ', Ind2: '1', Subfields: []marc.Subfield{{Code: 'c', Value: "[2009]"}}},
+		},
+	}
+
+	request, err := BookFromMARC(record)
+	require.NoError(t, err)
+	require.Equal(t, "Introduction to algorithms", request.Title)
+	require.Equal(t, "Cormen, Thomas H.", request.Author)
+	require.Equal(t, "0262033844", request.ISBN)
+	require.Equal(t, 2009, *request.PublishedYear)
+}
+
+func TestBookFromMARC_SubtitleAndFallbacks(t *testing.T) {
+	record := marc.Record{
+		ControlFields: []marc.ControlField{{Tag: "008", Value: "250102s1999    xx ||||||||||||||||| und|d"}},
+		DataFields: []marc.DataField{
+			{Tag: "020", Ind1: ' ', Ind2: ' ', Subfields: []marc.Subfield{{Code: 'a', Value: "111"}}},
+			{Tag: "245", Ind1: '0', Ind2: '0', Subfields: []marc.Subfield{{Code: 'a', Value: "Refactoring :"}, {Code: 'b', Value: "improving the design of existing code /"}}},
+			{Tag: "700", Ind1: '1', Ind2: ' ', Subfields: []marc.Subfield{{Code: 'a', Value: "Fowler, Martin,"}}},
+		},
+	}
+
+	request, err := BookFromMARC(record)
+	require.NoError(t, err)
+	require.Equal(t, "Refactoring: improving the design of existing code", request.Title)
+	require.Equal(t, "Fowler, Martin", request.Author)
+	require.Equal(t, 1999, *request.PublishedYear)
+}
+
+func TestBookFromMARC_MissingFields(t *testing.T) {
+	_, err := BookFromMARC(marc.Record{
+		DataFields: []marc.DataField{{Tag: "245", Subfields: []marc.Subfield{{Code: 'a', Value: "Title"}}}},
+	})
+	require.ErrorContains(t, err, "020")
+}
@@ -6,37 +6,69 @@ import (
 	"github.com/google/uuid"
 )
 
-// Book represents a book entity in the library system.
+// BookFormat is the physical or digital form of an edition.
+type BookFormat string
+
+const (
+	BookFormatHardcover BookFormat = "hardcover"
+	BookFormatPaperback BookFormat = "paperback"
+	BookFormatEbook     BookFormat = "ebook"
+	BookFormatAudiobook BookFormat = "audiobook"
+)
+
+// Book represents a book entity in the library system. Each book is one edition; editions
+// of the same work share a WorkID.
 type Book struct {
-	ID              uuid.UUID `json:"id"`
-	Title           string    `json:"title" validate:"required,min=1"`
-	Author          string    `json:"author" validate:"required,min=1"`
-	ISBN            string    `json:"isbn" validate:"required"`
-	PublishedYear   *int      `json:"published_year,omitempty"`
-	CopiesTotal     int       `json:"copies_total" validate:"gte=0"`
-	CopiesAvailable int       `json:"copies_available" validate:"gte=0,ltefield=CopiesTotal"`
-	CreatedAt       time.Time `json:"created_at"`
-	UpdatedAt       time.Time `json:"updated_at"`
+	ID              uuid.UUID   `json:"id"`
+	Title           string      `json:"title" validate:"required,min=1"`
+	Author          string      `json:"author" validate:"required,min=1"`
+	ISBN            string      `json:"isbn" validate:"required,isbn"`
+	PublishedYear   *int        `json:"published_year,omitempty"`
+	CoverURL        *string     `json:"cover_url,omitempty" validate:"omitempty,url"`
+	WorkID          *uuid.UUID  `json:"work_id,omitempty"`
+	Publisher       *string     `json:"publisher,omitempty" validate:"omitempty,max=300"`
+	Edition         *string     `json:"edition,omitempty" validate:"omitempty,max=100"`
+	Format          *BookFormat `json:"format,omitempty" validate:"omitempty,oneof=hardcover paperback ebook audiobook"`
+	Language        *string     `json:"language,omitempty" validate:"omitempty,bcp47_language_tag"`
+	PageCount       *int        `json:"page_count,omitempty" validate:"omitempty,gt=0"`
+	CopiesTotal     int         `json:"copies_total" validate:"gte=0"`
+	CopiesAvailable int         `json:"copies_available" validate:"gte=0,ltefield=CopiesTotal"`
+	CreatedAt       time.Time   `json:"created_at"`
+	UpdatedAt       time.Time   `json:"updated_at"`
 }
 
 // CreateBookRequest represents the request payload for creating a new book.
 type CreateBookRequest struct {
-	Title           string `json:"title" validate:"required,min=1"`
-	Author          string `json:"author" validate:"required,min=1"`
-	ISBN            string `json:"isbn" validate:"required"`
-	PublishedYear   *int   `json:"published_year,omitempty"`
-	CopiesTotal     int    `json:"copies_total" validate:"gte=0"`
-	CopiesAvailable *int   `json:"copies_available,omitempty" validate:"omitempty,gte=0"`
+	Title           string      `json:"title" validate:"required,min=1"`
+	Author          string      `json:"author" validate:"required,min=1"`
+	ISBN            string      `json:"isbn" validate:"required,isbn"`
+	PublishedYear   *int        `json:"published_year,omitempty"`
+	CoverURL        *string     `json:"cover_url,omitempty" validate:"omitempty,url"`
+	WorkID          *uuid.UUID  `json:"work_id,omitempty"`
+	Publisher       *string     `json:"publisher,omitempty" validate:"omitempty,max=300"`
+	Edition         *string     `json:"edition,omitempty" validate:"omitempty,max=100"`
+	Format          *BookFormat `json:"format,omitempty" validate:"omitempty,oneof=hardcover paperback ebook audiobook"`
+	Language        *string     `json:"language,omitempty" validate:"omitempty,bcp47_language_tag"`
+	PageCount       *int        `json:"page_count,omitempty" validate:"omitempty,gt=0"`
+	CopiesTotal     int         `json:"copies_total" validate:"gte=0"`
+	CopiesAvailable *int        `json:"copies_available,omitempty" validate:"omitempty,gte=0"`
 }
 
 // UpdateBookRequest represents the request payload for updating an existing book.
 type UpdateBookRequest struct {
-	Title           string `json:"title" validate:"required,min=1"`
-	Author          string `json:"author" validate:"required,min=1"`
-	ISBN            string `json:"isbn" validate:"required"`
-	PublishedYear   *int   `json:"published_year,omitempty"`
-	CopiesTotal     int    `json:"copies_total" validate:"gte=0"`
-	CopiesAvailable int    `json:"copies_available" validate:"gte=0"`
+	Title           string      `json:"title" validate:"required,min=1"`
+	Author          string      `json:"author" validate:"required,min=1"`
+	ISBN            string      `json:"isbn" validate:"required,isbn"`
+	PublishedYear   *int        `json:"published_year,omitempty"`
+	CoverURL        *string     `json:"cover_url,omitempty" validate:"omitempty,url"`
+	WorkID          *uuid.UUID  `json:"work_id,omitempty"`
+	Publisher       *string     `json:"publisher,omitempty" validate:"omitempty,max=300"`
+	Edition         *string     `json:"edition,omitempty" validate:"omitempty,max=100"`
+	Format          *BookFormat `json:"format,omitempty" validate:"omitempty,oneof=hardcover paperback ebook audiobook"`
+	Language        *string     `json:"language,omitempty" validate:"omitempty,bcp47_language_tag"`
+	PageCount       *int        `json:"page_count,omitempty" validate:"omitempty,gt=0"`
+	CopiesTotal     int         `json:"copies_total" validate:"gte=0"`
+	CopiesAvailable int         `json:"copies_available" validate:"gte=0"`
 }
 
 // ListFilter represents filtering options for listing books.
@@ -44,6 +76,23 @@ type ListFilter struct {
 	Title  *string
 	Author *string
 	ISBN   *string
-	Limit  int
-	Offset int
+	// AuthorID keeps the books crediting this author in any role.
+	AuthorID *uuid.UUID
+	// SubjectID keeps the books filed under this subject or any of its descendants.
+	SubjectID *uuid.UUID
+	// Tag keeps the books carrying this tag.
+	Tag *string
+	// WorkID keeps the editions of this work.
+	WorkID *uuid.UUID
+	// AvailableAt keeps the books with a copy available at this branch.
+	AvailableAt *uuid.UUID
+	Limit       int
+	Offset      int
+}
+
+// CatalogStats holds aggregate figures across the whole catalog.
+type CatalogStats struct {
+	Books        int64
+	CopiesTotal  int64
+	CopiesOnLoan int64
 }
@@ -0,0 +1,10 @@
+package domain
+
+// CitationFormat is the bibliographic format of a citation.
+type CitationFormat string
+
+const (
+	CitationFormatBibTeX  CitationFormat = "bibtex"
+	CitationFormatRIS     CitationFormat = "ris"
+	CitationFormatCSLJSON CitationFormat = "csl-json"
+)
@@ -0,0 +1,14 @@
+package domain
+
+// ExportFormat is the encoding of a catalog export stream.
+type ExportFormat string
+
+const (
+	ExportFormatCSV    ExportFormat = "csv"
+	ExportFormatNDJSON ExportFormat = "ndjson"
+	// ExportFormatJSON writes a single JSON array.
+	ExportFormatJSON ExportFormat = "json"
+	// ExportFormatMARC writes binary MARC 21 records and ExportFormatMARCXML a MARCXML collection.
+	ExportFormatMARC    ExportFormat = "marc"
+	ExportFormatMARCXML ExportFormat = "marcxml"
+)
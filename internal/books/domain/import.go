@@ -0,0 +1,71 @@
+package domain
+
+import "github.com/google/uuid"
+
+// ImportFormat is the encoding of a bulk import stream.
+type ImportFormat string
+
+const (
+	ImportFormatCSV    ImportFormat = "csv"
+	ImportFormatNDJSON ImportFormat = "ndjson"
+	// ImportFormatMARC and ImportFormatMARCXML carry no holdings, so imported records
+	// leave the copies of existing books unchanged.
+	ImportFormatMARC    ImportFormat = "marc"
+	ImportFormatMARCXML ImportFormat = "marcxml"
+)
+
+// ImportOptions controls a bulk import.
+type ImportOptions struct {
+	Format ImportFormat
+	// DryRun validates and upserts every row inside a transaction that is rolled back.
+	DryRun bool
+}
+
+// ImportRowStatus is the outcome of a single import row.
+type ImportRowStatus string
+
+const (
+	ImportRowCreated ImportRowStatus = "created"
+	ImportRowUpdated ImportRowStatus = "updated"
+	ImportRowFailed  ImportRowStatus = "failed"
+)
+
+// ImportRowResult reports what happened to one row of an import.
+type ImportRowResult struct {
+	// Line is the line of the row in the source, counting a CSV header. For MARC sources
+	// it is the position of the record.
+	Line   int             `json:"line"`
+	ISBN   string          `json:"isbn,omitempty"`
+	Status ImportRowStatus `json:"status"`
+	BookID *uuid.UUID      `json:"book_id,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// ImportReport summarizes a bulk import row by row.
+type ImportReport struct {
+	DryRun  bool              `json:"dry_run"`
+	Created int               `json:"created"`
+	Updated int               `json:"updated"`
+	Failed  int               `json:"failed"`
+	Rows    []ImportRowResult `json:"rows"`
+}
+
+// UpsertBook is a validated book to create, or to update when its ISBN already exists.
+type UpsertBook struct {
+	// Book.ID is only used when the book is created.
+	Book Book
+	// KeepCopiesOnLoan ignores Book.CopiesAvailable on update and keeps the number of
+	// copies on loan instead; it is set when the import row left copies_available out.
+	KeepCopiesOnLoan bool
+	// KeepCopies ignores Book.CopiesTotal and Book.CopiesAvailable on update, for sources
+	// that carry no holdings such as MARC records.
+	KeepCopies bool
+}
+
+// UpsertResult is the outcome of upserting one UpsertBook.
+type UpsertResult struct {
+	ID      uuid.UUID
+	Created bool
+	// Err is set when the database rejected this row; the other rows are unaffected.
+	Err error
+}
@@ -0,0 +1,36 @@
+package domain
+
+import "github.com/google/uuid"
+
+// CopyAdjustmentReason records why the copies of a book were adjusted.
+type CopyAdjustmentReason string
+
+const (
+	CopyAdjustmentAcquired   CopyAdjustmentReason = "acquired"
+	CopyAdjustmentFound      CopyAdjustmentReason = "found"
+	CopyAdjustmentLost       CopyAdjustmentReason = "lost"
+	CopyAdjustmentDamaged    CopyAdjustmentReason = "damaged"
+	CopyAdjustmentWithdrawn  CopyAdjustmentReason = "withdrawn"
+	CopyAdjustmentCheckedOut CopyAdjustmentReason = "checked_out"
+	CopyAdjustmentReturned   CopyAdjustmentReason = "returned"
+	CopyAdjustmentCorrection CopyAdjustmentReason = "correction"
+)
+
+// AdjustCopiesRequest changes the copy counts of a book by the given deltas. Putting new
+// copies on the shelf raises both counts; a checkout only lowers copies_available.
+type AdjustCopiesRequest struct {
+	TotalDelta     int                  `json:"total_delta"`
+	AvailableDelta int                  `json:"available_delta"`
+	Reason         CopyAdjustmentReason `json:"reason" validate:"required,oneof=acquired found lost damaged withdrawn checked_out returned correction"`
+	Note           *string              `json:"note,omitempty" validate:"omitempty,max=500"`
+}
+
+// CopyAdjustment is an adjustment of a book's copies, recorded in the inventory ledger.
+type CopyAdjustment struct {
+	ID             uuid.UUID
+	BookID         uuid.UUID
+	TotalDelta     int
+	AvailableDelta int
+	Reason         CopyAdjustmentReason
+	Note           *string
+}
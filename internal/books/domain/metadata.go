@@ -0,0 +1,11 @@
+package domain
+
+// BookMetadata is bibliographic data about an ISBN from an external metadata provider.
+// Fields the provider does not know are left empty.
+type BookMetadata struct {
+	ISBN          string `json:"isbn"`
+	Title         string `json:"title,omitempty"`
+	Author        string `json:"author,omitempty"`
+	PublishedYear *int   `json:"published_year,omitempty"`
+	CoverURL      string `json:"cover_url,omitempty"`
+}
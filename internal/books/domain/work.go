@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Work is the abstract creation, such as a novel, that editions (books) are published from.
+type Work struct {
+	ID        uuid.UUID `json:"id"`
+	Title     string    `json:"title"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CreateWorkRequest creates a work and optionally groups existing books under it as editions.
+type CreateWorkRequest struct {
+	Title   string      `json:"title" validate:"required,min=1,max=500"`
+	BookIDs []uuid.UUID `json:"book_ids,omitempty" validate:"max=500"`
+}
+
+// WorkAvailability aggregates the copies of all editions of a work.
+type WorkAvailability struct {
+	Editions          int `json:"editions"`
+	EditionsAvailable int `json:"editions_available"`
+	CopiesTotal       int `json:"copies_total"`
+	CopiesAvailable   int `json:"copies_available"`
+}
+
+// WorkEditions is a work with its editions and their combined availability.
+type WorkEditions struct {
+	Work
+	Editions     []Book           `json:"editions"`
+	Availability WorkAvailability `json:"availability"`
+}
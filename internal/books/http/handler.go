@@ -1,13 +1,21 @@
 package http
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
 	"net/http"
 	"strconv"
+	"strings"
 
+	"github.com/bkiran6398/library/internal/books/codec"
 	"github.com/bkiran6398/library/internal/books/domain"
 	"github.com/bkiran6398/library/internal/books/service"
 	"github.com/bkiran6398/library/internal/http/response"
+	"github.com/bkiran6398/library/internal/marc"
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 )
@@ -23,7 +31,65 @@ func NewHandler(service service.Service) Handler {
 }
 
 func (handler Handler) List(w http.ResponseWriter, r *http.Request) {
-	filter := parseListQueryParameters(r)
+	filter, err := parseListQueryParameters(r)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Invalid list filter: "+err.Error(), nil)
+		return
+	}
+	handler.writeList(w, r, filter)
+}
+
+// ListByAuthor serves GET /authors/{id}/books, accepting the same filters as List.
+func (handler Handler) ListByAuthor(w http.ResponseWriter, r *http.Request) {
+	authorID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Invalid author ID", nil)
+		return
+	}
+	filter, err := parseListQueryParameters(r)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Invalid list filter: "+err.Error(), nil)
+		return
+	}
+	filter.AuthorID = &authorID
+	handler.writeList(w, r, filter)
+}
+
+// ListBySubject serves GET /subjects/{id}/books, accepting the same filters as List. Books
+// filed under descendants of the subject are included.
+func (handler Handler) ListBySubject(w http.ResponseWriter, r *http.Request) {
+	subjectID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Invalid subject ID", nil)
+		return
+	}
+	filter, err := parseListQueryParameters(r)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Invalid list filter: "+err.Error(), nil)
+		return
+	}
+	filter.SubjectID = &subjectID
+	handler.writeList(w, r, filter)
+}
+
+// ListAvailableAtBranch serves GET /branches/{id}/books, accepting the same filters as List.
+// Only books with a copy available at the branch are included.
+func (handler Handler) ListAvailableAtBranch(w http.ResponseWriter, r *http.Request) {
+	branchID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Invalid branch ID", nil)
+		return
+	}
+	filter, err := parseListQueryParameters(r)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Invalid list filter: "+err.Error(), nil)
+		return
+	}
+	filter.AvailableAt = &branchID
+	handler.writeList(w, r, filter)
+}
+
+func (handler Handler) writeList(w http.ResponseWriter, r *http.Request, filter domain.ListFilter) {
 	books, err := handler.service.List(r.Context(), filter)
 	if err != nil {
 		response.MapServiceErrorToHTTP(w, err)
@@ -32,17 +98,19 @@ func (handler Handler) List(w http.ResponseWriter, r *http.Request) {
 	response.JSON(w, http.StatusOK, books)
 }
 
-// parseListQueryParameters extracts and parses query parameters for listing books.
-func parseListQueryParameters(r *http.Request) domain.ListFilter {
+// parseListQueryParameters extracts and parses query parameters for listing books. Invalid
+// limit and offset values are ignored; invalid IDs are reported.
+func parseListQueryParameters(r *http.Request) (domain.ListFilter, error) {
 	queryParams := r.URL.Query()
 
 	title := queryParams.Get("title")
 	author := queryParams.Get("author")
 	isbn := queryParams.Get("isbn")
+	tag := queryParams.Get("tag")
 	limit, _ := strconv.Atoi(queryParams.Get("limit"))
 	offset, _ := strconv.Atoi(queryParams.Get("offset"))
 
-	var titlePtr, authorPtr, isbnPtr *string
+	var titlePtr, authorPtr, isbnPtr, tagPtr *string
 	if title != "" {
 		titlePtr = &title
 	}
@@ -52,14 +120,51 @@ func parseListQueryParameters(r *http.Request) domain.ListFilter {
 	if isbn != "" {
 		isbnPtr = &isbn
 	}
+	if tag != "" {
+		tagPtr = &tag
+	}
+
+	authorID, err := parseOptionalUUID(queryParams.Get("author_id"))
+	if err != nil {
+		return domain.ListFilter{}, errors.New("author_id is not a valid UUID")
+	}
+	subjectID, err := parseOptionalUUID(queryParams.Get("subject_id"))
+	if err != nil {
+		return domain.ListFilter{}, errors.New("subject_id is not a valid UUID")
+	}
+	workID, err := parseOptionalUUID(queryParams.Get("work_id"))
+	if err != nil {
+		return domain.ListFilter{}, errors.New("work_id is not a valid UUID")
+	}
+	availableAt, err := parseOptionalUUID(queryParams.Get("available_at"))
+	if err != nil {
+		return domain.ListFilter{}, errors.New("available_at is not a valid UUID")
+	}
 
 	return domain.ListFilter{
-		Title:  titlePtr,
-		Author: authorPtr,
-		ISBN:   isbnPtr,
-		Limit:  limit,
-		Offset: offset,
+		Title:       titlePtr,
+		Author:      authorPtr,
+		ISBN:        isbnPtr,
+		AuthorID:    authorID,
+		SubjectID:   subjectID,
+		Tag:         tagPtr,
+		WorkID:      workID,
+		AvailableAt: availableAt,
+		Limit:       limit,
+		Offset:      offset,
+	}, nil
+}
+
+// parseOptionalUUID parses value, returning nil when it is empty.
+func parseOptionalUUID(value string) (*uuid.UUID, error) {
+	if value == "" {
+		return nil, nil
 	}
+	parsed, err := uuid.Parse(value)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
 }
 
 func (handler Handler) Create(w http.ResponseWriter, r *http.Request) {
@@ -77,6 +182,130 @@ func (handler Handler) Create(w http.ResponseWriter, r *http.Request) {
 	response.JSON(w, http.StatusCreated, book)
 }
 
+// maxImportBodyBytes bounds the size of an import upload.
+const maxImportBodyBytes = 32 << 20
+
+// Import creates or updates books from a CSV or NDJSON body, chosen by the format query
+// parameter or the Content-Type, and responds with a per-row report. dry_run=true validates
+// the rows without saving them.
+func (handler Handler) Import(w http.ResponseWriter, r *http.Request) {
+	format, ok := importFormatOf(r)
+	if !ok {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Unsupported import format, use csv, ndjson, marc or marcxml", nil)
+		return
+	}
+	dryRun, err := parseOptionalBool(r.URL.Query().Get("dry_run"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Invalid dry_run parameter", nil)
+		return
+	}
+
+	source := http.MaxBytesReader(w, r.Body, maxImportBodyBytes)
+	report, err := handler.service.Import(r.Context(), source, domain.ImportOptions{Format: format, DryRun: dryRun})
+	if err != nil {
+		var maxBytesError *http.MaxBytesError
+		if errors.As(err, &maxBytesError) {
+			response.Error(w, http.StatusRequestEntityTooLarge, "payload_too_large", "Import body is too large", nil)
+			return
+		}
+		response.MapServiceErrorToHTTP(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, report)
+}
+
+// importFormatOf reads the import format from the format query parameter, falling back to
+// the request Content-Type.
+func importFormatOf(r *http.Request) (domain.ImportFormat, bool) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		switch mediaType {
+		case "text/csv":
+			format = string(domain.ImportFormatCSV)
+		case "application/x-ndjson", "application/jsonl":
+			format = string(domain.ImportFormatNDJSON)
+		case codec.ContentTypeMARC:
+			format = string(domain.ImportFormatMARC)
+		case codec.ContentTypeMARCXML:
+			format = string(domain.ImportFormatMARCXML)
+		}
+	}
+
+	switch domain.ImportFormat(format) {
+	case domain.ImportFormatCSV, domain.ImportFormatNDJSON, domain.ImportFormatMARC, domain.ImportFormatMARCXML:
+		return domain.ImportFormat(format), true
+	default:
+		return "", false
+	}
+}
+
+// parseOptionalBool parses a boolean query parameter, treating an empty value as false.
+func parseOptionalBool(value string) (bool, error) {
+	if value == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
+
+// exportMediaType is the response media type and download file extension of an export format.
+type exportMediaType struct {
+	contentType string
+	extension   string
+}
+
+var exportMediaTypes = map[domain.ExportFormat]exportMediaType{
+	domain.ExportFormatCSV:     {contentType: "text/csv; charset=utf-8", extension: "csv"},
+	domain.ExportFormatNDJSON:  {contentType: "application/x-ndjson", extension: "ndjson"},
+	domain.ExportFormatJSON:    {contentType: "application/json", extension: "json"},
+	domain.ExportFormatMARC:    {contentType: codec.ContentTypeMARC, extension: "mrc"},
+	domain.ExportFormatMARCXML: {contentType: codec.ContentTypeMARCXML, extension: "xml"},
+}
+
+// Export streams the books matching the list filters as csv, ndjson, json (the default),
+// marc or marcxml.
+// Once part of the body has been sent an error can no longer be reported, so the
+// connection is aborted instead and the client sees a truncated response.
+func (handler Handler) Export(w http.ResponseWriter, r *http.Request) {
+	format := domain.ExportFormat(r.URL.Query().Get("format"))
+	if format == "" {
+		format = domain.ExportFormatJSON
+	}
+	mediaType, ok := exportMediaTypes[format]
+	if !ok {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Unsupported export format, use csv, ndjson, json, marc or marcxml", nil)
+		return
+	}
+
+	filter, err := parseListQueryParameters(r)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Invalid list filter: "+err.Error(), nil)
+		return
+	}
+
+	w.Header().Set("Content-Type", mediaType.contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="books.%s"`, mediaType.extension))
+	body := &trackingWriter{writer: w}
+	if err := handler.service.Export(r.Context(), body, filter, format); err != nil {
+		if body.written {
+			panic(http.ErrAbortHandler)
+		}
+		w.Header().Del("Content-Disposition")
+		response.MapServiceErrorToHTTP(w, err)
+	}
+}
+
+// trackingWriter records whether any of the response body has been written.
+type trackingWriter struct {
+	writer  io.Writer
+	written bool
+}
+
+func (tracking *trackingWriter) Write(p []byte) (int, error) {
+	tracking.written = true
+	return tracking.writer.Write(p)
+}
+
 func (handler Handler) Get(w http.ResponseWriter, r *http.Request) {
 	bookID, err := parseBookIDFromPath(r)
 	if err != nil {
@@ -89,7 +318,114 @@ func (handler Handler) Get(w http.ResponseWriter, r *http.Request) {
 		response.MapServiceErrorToHTTP(w, err)
 		return
 	}
-	response.JSON(w, http.StatusOK, book)
+	writeBook(w, r, book)
+}
+
+// writeBook writes book as JSON, or as a MARC record when the Accept header prefers one.
+func writeBook(w http.ResponseWriter, r *http.Request, book domain.Book) {
+	contentType := response.NegotiateContentType(r, "application/json", codec.ContentTypeMARC, codec.ContentTypeMARCXML)
+	w.Header().Add("Vary", "Accept")
+
+	var body []byte
+	var err error
+	switch contentType {
+	case codec.ContentTypeMARC:
+		body, err = marc.Marshal(codec.MARCRecord(book))
+	case codec.ContentTypeMARCXML:
+		body, err = marc.MarshalXML(codec.MARCRecord(book))
+	default:
+		response.JSON(w, http.StatusOK, book)
+		return
+	}
+	if err != nil {
+		response.MapServiceErrorToHTTP(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+}
+
+// Lookup returns bibliographic metadata for the isbn query parameter from the metadata
+// provider, for filling in a new book.
+func (handler Handler) Lookup(w http.ResponseWriter, r *http.Request) {
+	isbn := r.URL.Query().Get("isbn")
+	if isbn == "" {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Missing isbn query parameter", nil)
+		return
+	}
+
+	bookMetadata, err := handler.service.Lookup(r.Context(), isbn)
+	if err != nil {
+		response.MapServiceErrorToHTTP(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, bookMetadata)
+}
+
+// citationContentTypes maps each citation format to its media type.
+var citationContentTypes = map[domain.CitationFormat]string{
+	domain.CitationFormatBibTeX:  codec.ContentTypeBibTeX,
+	domain.CitationFormatRIS:     codec.ContentTypeRIS,
+	domain.CitationFormatCSLJSON: codec.ContentTypeCSLJSON,
+}
+
+// Citation returns a citation of one book in the format given by the format query
+// parameter, or negotiated from the Accept header (BibTeX by default).
+func (handler Handler) Citation(w http.ResponseWriter, r *http.Request) {
+	bookID, err := parseBookIDFromPath(r)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Invalid book ID", nil)
+		return
+	}
+	handler.writeCitations(w, r, []uuid.UUID{bookID})
+}
+
+// Citations returns citations of the books listed in the ids query parameter, given as
+// comma-separated or repeated values.
+func (handler Handler) Citations(w http.ResponseWriter, r *http.Request) {
+	var bookIDs []uuid.UUID
+	for _, value := range r.URL.Query()["ids"] {
+		for _, bookIDString := range strings.Split(value, ",") {
+			bookID, err := uuid.Parse(strings.TrimSpace(bookIDString))
+			if err != nil {
+				response.Error(w, http.StatusBadRequest, "bad_request", fmt.Sprintf("Invalid book ID %q", bookIDString), nil)
+				return
+			}
+			bookIDs = append(bookIDs, bookID)
+		}
+	}
+	handler.writeCitations(w, r, bookIDs)
+}
+
+func (handler Handler) writeCitations(w http.ResponseWriter, r *http.Request, bookIDs []uuid.UUID) {
+	format := domain.CitationFormat(r.URL.Query().Get("format"))
+	if format == "" {
+		contentType := response.NegotiateContentType(r, codec.ContentTypeBibTeX, codec.ContentTypeRIS, codec.ContentTypeCSLJSON)
+		for candidate, candidateContentType := range citationContentTypes {
+			if candidateContentType == contentType {
+				format = candidate
+			}
+		}
+		w.Header().Add("Vary", "Accept")
+	}
+	contentType, ok := citationContentTypes[format]
+	if !ok {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Unsupported citation format, use bibtex, ris or csl-json", nil)
+		return
+	}
+
+	// Citations are small, so they are buffered to report errors as regular responses.
+	var body bytes.Buffer
+	if err := handler.service.Cite(r.Context(), &body, bookIDs, format); err != nil {
+		response.MapServiceErrorToHTTP(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType+"; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body.Bytes())
 }
 
 // parseBookIDFromPath extracts and parses the book ID from the request path.
@@ -132,3 +468,70 @@ func (handler Handler) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// AdjustCopies serves POST /books/{id}/copies:adjust.
+func (handler Handler) AdjustCopies(w http.ResponseWriter, r *http.Request) {
+	bookID, err := parseBookIDFromPath(r)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Invalid book ID", nil)
+		return
+	}
+
+	var adjustRequest domain.AdjustCopiesRequest
+	if err := json.NewDecoder(r.Body).Decode(&adjustRequest); err != nil {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Invalid JSON body", nil)
+		return
+	}
+
+	book, err := handler.service.AdjustCopies(r.Context(), bookID, adjustRequest)
+	if err != nil {
+		response.MapServiceErrorToHTTP(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, book)
+}
+
+func (handler Handler) CreateWork(w http.ResponseWriter, r *http.Request) {
+	var createRequest domain.CreateWorkRequest
+	if err := json.NewDecoder(r.Body).Decode(&createRequest); err != nil {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Invalid JSON body", nil)
+		return
+	}
+
+	work, err := handler.service.CreateWork(r.Context(), createRequest)
+	if err != nil {
+		response.MapServiceErrorToHTTP(w, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, work)
+}
+
+// GetWork serves GET /works/{id}: the work, all its editions and their combined availability.
+func (handler Handler) GetWork(w http.ResponseWriter, r *http.Request) {
+	workID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Invalid work ID", nil)
+		return
+	}
+
+	work, err := handler.service.GetWork(r.Context(), workID)
+	if err != nil {
+		response.MapServiceErrorToHTTP(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, work)
+}
+
+func (handler Handler) DeleteWork(w http.ResponseWriter, r *http.Request) {
+	workID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Invalid work ID", nil)
+		return
+	}
+
+	if err := handler.service.DeleteWork(r.Context(), workID); err != nil {
+		response.MapServiceErrorToHTTP(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
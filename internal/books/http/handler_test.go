@@ -2,15 +2,20 @@ package http
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/bkiran6398/library/internal/books/codec"
 	"github.com/bkiran6398/library/internal/books/domain"
 	"github.com/bkiran6398/library/internal/books/service/mocks"
 	intErr "github.com/bkiran6398/library/internal/errors"
+	"github.com/bkiran6398/library/internal/marc"
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/require"
@@ -189,6 +194,43 @@ func TestHandler_Get_Success(t *testing.T) {
 	require.Equal(t, expectedBook.Title, result.Title)
 }
 
+func TestHandler_Get_NegotiatesMARC(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := NewHandler(mockService)
+
+	bookID := uuid.New()
+	book := domain.Book{ID: bookID, Title: "Test Book", Author: "Test Author", ISBN: "111"}
+	mockService.EXPECT().Get(gomock.Any(), bookID).Return(book, nil).Times(2)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/books/"+bookID.String(), nil)
+	req.Header.Set("Accept", "application/marc")
+	req = mux.SetURLVars(req, map[string]string{"id": bookID.String()})
+	w := httptest.NewRecorder()
+
+	handler.Get(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, codec.ContentTypeMARC, w.Header().Get("Content-Type"))
+	record, err := marc.Unmarshal(w.Body.Bytes())
+	require.NoError(t, err)
+	controlNumber, _ := record.ControlField("001")
+	require.Equal(t, bookID.String(), controlNumber)
+
+	req = httptest.NewRequest(http.MethodGet, "/v1/books/"+bookID.String(), nil)
+	req.Header.Set("Accept", "application/marcxml+xml, application/json;q=0.5")
+	req = mux.SetURLVars(req, map[string]string{"id": bookID.String()})
+	w = httptest.NewRecorder()
+
+	handler.Get(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, codec.ContentTypeMARCXML, w.Header().Get("Content-Type"))
+	require.Contains(t, w.Body.String(), `<datafield tag="245" ind1="1" ind2="0"><subfield code="a">Test Book</subfield></datafield>`)
+}
+
 func TestHandler_Get_InvalidUUID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -238,6 +280,34 @@ func TestHandler_Get_NotFound(t *testing.T) {
 	require.Equal(t, "not_found", errorResponse["error"].(map[string]interface{})["code"])
 }
 
+func TestHandler_Get_Timeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := NewHandler(mockService)
+
+	bookID := uuid.New()
+
+	mockService.EXPECT().
+		Get(gomock.Any(), bookID).
+		Return(domain.Book{}, fmt.Errorf("get book: %w", context.DeadlineExceeded)).
+		Times(1)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/books/"+bookID.String(), nil)
+	req = mux.SetURLVars(req, map[string]string{"id": bookID.String()})
+	w := httptest.NewRecorder()
+
+	handler.Get(w, req)
+
+	require.Equal(t, http.StatusGatewayTimeout, w.Code)
+
+	var errorResponse map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	require.NoError(t, err)
+	require.Equal(t, "timeout", errorResponse["error"].(map[string]interface{})["code"])
+}
+
 func TestHandler_Update_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -607,6 +677,37 @@ func TestHandler_List_ServiceError(t *testing.T) {
 	require.Equal(t, "internal_error", errorResponse["error"].(map[string]interface{})["code"])
 }
 
+func TestHandler_ListByAuthor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := NewHandler(mockService)
+
+	authorID := uuid.New()
+	titleFilter := "Dispossessed"
+	mockService.EXPECT().
+		List(gomock.Any(), domain.ListFilter{Title: &titleFilter, AuthorID: &authorID, Limit: 10}).
+		Return([]domain.Book{{ID: uuid.New(), Title: "The Dispossessed", Author: "Ursula K. Le Guin"}}, nil).
+		Times(1)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/authors/"+authorID.String()+"/books?title=Dispossessed&limit=10", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": authorID.String()})
+	w := httptest.NewRecorder()
+
+	handler.ListByAuthor(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var result []domain.Book
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	require.Len(t, result, 1)
+
+	req = mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/v1/authors/nope/books", nil), map[string]string{"id": "nope"})
+	w = httptest.NewRecorder()
+	handler.ListByAuthor(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
+
 func TestParseListQueryParameters(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -662,7 +763,8 @@ func TestParseListQueryParameters(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			req := httptest.NewRequest(http.MethodGet, "/v1/books?"+tt.queryString, nil)
-			filter := parseListQueryParameters(req)
+			filter, err := parseListQueryParameters(req)
+			require.NoError(t, err)
 
 			require.Equal(t, tt.expectedFilter.Limit, filter.Limit)
 			require.Equal(t, tt.expectedFilter.Offset, filter.Offset)
@@ -691,6 +793,72 @@ func TestParseListQueryParameters(t *testing.T) {
 	}
 }
 
+func TestParseListQueryParameters_TaxonomyFilters(t *testing.T) {
+	subjectID, authorID := uuid.New(), uuid.New()
+	req := httptest.NewRequest(http.MethodGet, "/v1/books?subject_id="+subjectID.String()+"&author_id="+authorID.String()+"&tag=Sci+Fi", nil)
+	filter, err := parseListQueryParameters(req)
+	require.NoError(t, err)
+	require.Equal(t, subjectID, *filter.SubjectID)
+	require.Equal(t, authorID, *filter.AuthorID)
+	require.Equal(t, "Sci Fi", *filter.Tag)
+
+	_, err = parseListQueryParameters(httptest.NewRequest(http.MethodGet, "/v1/books?subject_id=fiction", nil))
+	require.Error(t, err)
+}
+
+func TestHandler_ListBySubject(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := NewHandler(mockService)
+
+	subjectID := uuid.New()
+	tag := "classics"
+	mockService.EXPECT().
+		List(gomock.Any(), domain.ListFilter{SubjectID: &subjectID, Tag: &tag}).
+		Return([]domain.Book{}, nil).
+		Times(1)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/subjects/"+subjectID.String()+"/books?tag=classics", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": subjectID.String()})
+	w := httptest.NewRecorder()
+
+	handler.ListBySubject(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/v1/books?subject_id=fiction", nil)
+	w = httptest.NewRecorder()
+	handler.List(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestHandler_ListAvailableAtBranch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := NewHandler(mockService)
+
+	branchID := uuid.New()
+	mockService.EXPECT().
+		List(gomock.Any(), domain.ListFilter{AvailableAt: &branchID, Limit: 10}).
+		Return([]domain.Book{}, nil).
+		Times(1)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/branches/"+branchID.String()+"/books?limit=10", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": branchID.String()})
+	w := httptest.NewRecorder()
+
+	handler.ListAvailableAtBranch(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/v1/books?available_at=central", nil)
+	w = httptest.NewRecorder()
+	handler.List(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
+
 func TestParseBookIDFromPath(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -736,3 +904,363 @@ func stringPtr(s string) *string {
 	return &s
 }
 
+
+func TestHandler_Import_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := NewHandler(mockService)
+
+	bookID := uuid.New()
+	expectedReport := domain.ImportReport{
+		DryRun:  true,
+		Created: 1,
+		Rows:    []domain.ImportRowResult{{Line: 2, ISBN: "ISBN-1", Status: domain.ImportRowCreated, BookID: &bookID}},
+	}
+
+	mockService.EXPECT().
+		Import(gomock.Any(), gomock.Any(), domain.ImportOptions{Format: domain.ImportFormatCSV, DryRun: true}).
+		Return(expectedReport, nil).
+		Times(1)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/books/import?dry_run=true", bytes.NewReader([]byte("title,author,isbn,copies_total\n")))
+	req.Header.Set("Content-Type", "text/csv; charset=utf-8")
+	w := httptest.NewRecorder()
+
+	handler.Import(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var result domain.ImportReport
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	require.Equal(t, expectedReport, result)
+}
+
+func TestHandler_Import_MARCXMLContentType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := NewHandler(mockService)
+
+	mockService.EXPECT().
+		Import(gomock.Any(), gomock.Any(), domain.ImportOptions{Format: domain.ImportFormatMARCXML}).
+		Return(domain.ImportReport{}, nil).
+		Times(1)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/books/import", bytes.NewReader([]byte("<collection/>")))
+	req.Header.Set("Content-Type", codec.ContentTypeMARCXML)
+	w := httptest.NewRecorder()
+
+	handler.Import(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestHandler_Import_UnsupportedFormat(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := NewHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/books/import?format=xlsx", bytes.NewReader(nil))
+	w := httptest.NewRecorder()
+
+	handler.Import(w, req)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestHandler_Export_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := NewHandler(mockService)
+
+	title := "Go"
+	mockService.EXPECT().
+		Export(gomock.Any(), gomock.Any(), domain.ListFilter{Title: &title}, domain.ExportFormatNDJSON).
+		DoAndReturn(func(ctx context.Context, destination io.Writer, filter domain.ListFilter, format domain.ExportFormat) error {
+			_, err := io.WriteString(destination, "{}\n")
+			return err
+		}).
+		Times(1)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/books/export?format=ndjson&title=Go", nil)
+	w := httptest.NewRecorder()
+
+	handler.Export(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+	require.Equal(t, `attachment; filename="books.ndjson"`, w.Header().Get("Content-Disposition"))
+	require.Equal(t, "{}\n", w.Body.String())
+}
+
+func TestHandler_Export_MARC(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := NewHandler(mockService)
+
+	mockService.EXPECT().
+		Export(gomock.Any(), gomock.Any(), domain.ListFilter{}, domain.ExportFormatMARC).
+		Return(nil).
+		Times(1)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/books/export?format=marc", nil)
+	w := httptest.NewRecorder()
+
+	handler.Export(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, codec.ContentTypeMARC, w.Header().Get("Content-Type"))
+	require.Equal(t, `attachment; filename="books.mrc"`, w.Header().Get("Content-Disposition"))
+}
+
+func TestHandler_Export_UnsupportedFormat(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := NewHandler(mocks.NewMockService(ctrl))
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/books/export?format=xml", nil)
+	w := httptest.NewRecorder()
+
+	handler.Export(w, req)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestHandler_Export_ErrorBeforeBody(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := NewHandler(mockService)
+
+	mockService.EXPECT().
+		Export(gomock.Any(), gomock.Any(), gomock.Any(), domain.ExportFormatJSON).
+		Return(errors.New("database unavailable")).
+		Times(1)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/books/export", nil)
+	w := httptest.NewRecorder()
+
+	handler.Export(w, req)
+
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+	require.Empty(t, w.Header().Get("Content-Disposition"))
+}
+
+func TestHandler_Export_ErrorMidStreamAborts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := NewHandler(mockService)
+
+	mockService.EXPECT().
+		Export(gomock.Any(), gomock.Any(), gomock.Any(), domain.ExportFormatCSV).
+		DoAndReturn(func(ctx context.Context, destination io.Writer, filter domain.ListFilter, format domain.ExportFormat) error {
+			_, _ = io.WriteString(destination, "id,title\n")
+			return errors.New("connection reset")
+		}).
+		Times(1)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/books/export?format=csv", nil)
+	w := httptest.NewRecorder()
+
+	require.PanicsWithValue(t, http.ErrAbortHandler, func() { handler.Export(w, req) })
+}
+
+func TestHandler_Citation_NegotiatesFormat(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := NewHandler(mockService)
+
+	bookID := uuid.New()
+	mockService.EXPECT().
+		Cite(gomock.Any(), gomock.Any(), []uuid.UUID{bookID}, domain.CitationFormatRIS).
+		DoAndReturn(func(ctx context.Context, destination io.Writer, bookIDs []uuid.UUID, format domain.CitationFormat) error {
+			_, err := io.WriteString(destination, "TY  - BOOK\r\nER  - \r\n")
+			return err
+		}).
+		Times(1)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/books/"+bookID.String()+"/citation", nil)
+	req.Header.Set("Accept", codec.ContentTypeRIS)
+	req = mux.SetURLVars(req, map[string]string{"id": bookID.String()})
+	w := httptest.NewRecorder()
+
+	handler.Citation(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, codec.ContentTypeRIS+"; charset=utf-8", w.Header().Get("Content-Type"))
+	require.Equal(t, "TY  - BOOK\r\nER  - \r\n", w.Body.String())
+}
+
+func TestHandler_Citations_Batch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := NewHandler(mockService)
+
+	firstID, secondID, thirdID := uuid.New(), uuid.New(), uuid.New()
+	mockService.EXPECT().
+		Cite(gomock.Any(), gomock.Any(), []uuid.UUID{firstID, secondID, thirdID}, domain.CitationFormatCSLJSON).
+		Return(nil).
+		Times(1)
+
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/v1/books/citations?format=csl-json&ids=%s,%s&ids=%s", firstID, secondID, thirdID), nil)
+	w := httptest.NewRecorder()
+
+	handler.Citations(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, codec.ContentTypeCSLJSON+"; charset=utf-8", w.Header().Get("Content-Type"))
+}
+
+func TestHandler_Citations_Errors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := NewHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/books/citations?ids=not-a-uuid", nil)
+	w := httptest.NewRecorder()
+	handler.Citations(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/v1/books/citations?format=apa&ids="+uuid.NewString(), nil)
+	w = httptest.NewRecorder()
+	handler.Citations(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	mockService.EXPECT().
+		Cite(gomock.Any(), gomock.Any(), gomock.Any(), domain.CitationFormatBibTeX).
+		Return(intErr.ErrNotFound).
+		Times(1)
+	req = httptest.NewRequest(http.MethodGet, "/v1/books/citations?ids="+uuid.NewString(), nil)
+	w = httptest.NewRecorder()
+	handler.Citations(w, req)
+	require.Equal(t, http.StatusNotFound, w.Code)
+	require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+}
+
+func TestHandler_Lookup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := NewHandler(mockService)
+
+	expected := domain.BookMetadata{ISBN: "9780134190440", Title: "The Go Programming Language"}
+	mockService.EXPECT().
+		Lookup(gomock.Any(), "0-13-419044-0").
+		Return(expected, nil).
+		Times(1)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/books/lookup?isbn=0-13-419044-0", nil)
+	w := httptest.NewRecorder()
+
+	handler.Lookup(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var result domain.BookMetadata
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	require.Equal(t, expected, result)
+}
+
+func TestHandler_Lookup_Errors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := NewHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/books/lookup", nil)
+	w := httptest.NewRecorder()
+	handler.Lookup(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	mockService.EXPECT().
+		Lookup(gomock.Any(), "9780134190440").
+		Return(domain.BookMetadata{}, intErr.ErrUnavailable).
+		Times(1)
+	req = httptest.NewRequest(http.MethodPost, "/v1/books/lookup?isbn=9780134190440", nil)
+	w = httptest.NewRecorder()
+	handler.Lookup(w, req)
+	require.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
+
+func TestHandler_GetWork(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := NewHandler(mockService)
+
+	workID := uuid.New()
+	mockService.EXPECT().
+		GetWork(gomock.Any(), workID).
+		Return(domain.WorkEditions{
+			Work:         domain.Work{ID: workID, Title: "Dune"},
+			Editions:     []domain.Book{{ID: uuid.New(), Title: "Dune", WorkID: &workID, CopiesTotal: 2, CopiesAvailable: 1}},
+			Availability: domain.WorkAvailability{Editions: 1, EditionsAvailable: 1, CopiesTotal: 2, CopiesAvailable: 1},
+		}, nil).
+		Times(1)
+
+	req := mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/v1/works/"+workID.String(), nil), map[string]string{"id": workID.String()})
+	w := httptest.NewRecorder()
+	handler.GetWork(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var result map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	require.Equal(t, "Dune", result["title"])
+	require.Len(t, result["editions"], 1)
+	require.Equal(t, float64(1), result["availability"].(map[string]any)["copies_available"])
+
+	mockService.EXPECT().GetWork(gomock.Any(), gomock.Any()).Return(domain.WorkEditions{}, intErr.ErrNotFound).Times(1)
+	w = httptest.NewRecorder()
+	handler.GetWork(w, req)
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestHandler_AdjustCopies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockService(ctrl)
+	handler := NewHandler(mockService)
+
+	bookID := uuid.New()
+	adjustRequest := domain.AdjustCopiesRequest{AvailableDelta: -1, Reason: domain.CopyAdjustmentCheckedOut}
+	mockService.EXPECT().
+		AdjustCopies(gomock.Any(), bookID, adjustRequest).
+		Return(domain.Book{ID: bookID, CopiesTotal: 2, CopiesAvailable: 0}, nil).
+		Times(1)
+
+	body := `{"available_delta":-1,"reason":"checked_out"}`
+	req := httptest.NewRequest(http.MethodPost, "/v1/books/"+bookID.String()+"/copies:adjust", bytes.NewBufferString(body))
+	req = mux.SetURLVars(req, map[string]string{"id": bookID.String()})
+	w := httptest.NewRecorder()
+	handler.AdjustCopies(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	mockService.EXPECT().AdjustCopies(gomock.Any(), bookID, adjustRequest).Return(domain.Book{}, intErr.ErrConflict).Times(1)
+	req = httptest.NewRequest(http.MethodPost, "/v1/books/"+bookID.String()+"/copies:adjust", bytes.NewBufferString(body))
+	req = mux.SetURLVars(req, map[string]string{"id": bookID.String()})
+	w = httptest.NewRecorder()
+	handler.AdjustCopies(w, req)
+	require.Equal(t, http.StatusConflict, w.Code)
+}
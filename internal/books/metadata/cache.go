@@ -0,0 +1,111 @@
+package metadata
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/bkiran6398/library/internal/books/domain"
+	"golang.org/x/sync/singleflight"
+)
+
+// Cache wraps a Provider with an in-memory LRU cache. Found and not-found answers are kept
+// for the TTL; failed lookups are not cached so they are retried on the next call.
+// Concurrent misses for the same ISBN share a single lookup.
+type Cache struct {
+	next       Provider
+	ttl        time.Duration
+	maxEntries int
+	now        func() time.Time
+
+	mutex   sync.Mutex
+	entries map[string]*list.Element
+	recency *list.List
+
+	inFlight singleflight.Group
+}
+
+type cacheEntry struct {
+	isbn      string
+	metadata  domain.BookMetadata
+	notFound  bool
+	expiresAt time.Time
+}
+
+// NewCache caches up to maxEntries answers from next for ttl each.
+func NewCache(next Provider, ttl time.Duration, maxEntries int) *Cache {
+	return &Cache{
+		next:       next,
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		now:        time.Now,
+		entries:    make(map[string]*list.Element),
+		recency:    list.New(),
+	}
+}
+
+func (cache *Cache) Lookup(ctx context.Context, isbn string) (domain.BookMetadata, error) {
+	if entry, ok := cache.get(isbn); ok {
+		if entry.notFound {
+			return domain.BookMetadata{}, ErrNotFound
+		}
+		return entry.metadata, nil
+	}
+
+	// The shared lookup must outlive any one caller's cancellation; each caller still stops
+	// waiting when its own context ends.
+	results := cache.inFlight.DoChan(isbn, func() (interface{}, error) {
+		bookMetadata, err := cache.next.Lookup(context.WithoutCancel(ctx), isbn)
+		switch {
+		case err == nil:
+			cache.put(cacheEntry{isbn: isbn, metadata: bookMetadata})
+		case errors.Is(err, ErrNotFound):
+			cache.put(cacheEntry{isbn: isbn, notFound: true})
+		}
+		return bookMetadata, err
+	})
+	select {
+	case <-ctx.Done():
+		return domain.BookMetadata{}, ctx.Err()
+	case result := <-results:
+		return result.Val.(domain.BookMetadata), result.Err
+	}
+}
+
+func (cache *Cache) get(isbn string) (cacheEntry, bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	element, ok := cache.entries[isbn]
+	if !ok {
+		return cacheEntry{}, false
+	}
+	entry := element.Value.(cacheEntry)
+	if !cache.now().Before(entry.expiresAt) {
+		cache.recency.Remove(element)
+		delete(cache.entries, isbn)
+		return cacheEntry{}, false
+	}
+	cache.recency.MoveToFront(element)
+	return entry, true
+}
+
+func (cache *Cache) put(entry cacheEntry) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	entry.expiresAt = cache.now().Add(cache.ttl)
+	if element, ok := cache.entries[entry.isbn]; ok {
+		element.Value = entry
+		cache.recency.MoveToFront(element)
+		return
+	}
+	cache.entries[entry.isbn] = cache.recency.PushFront(entry)
+	for cache.recency.Len() > cache.maxEntries {
+		oldest := cache.recency.Back()
+		cache.recency.Remove(oldest)
+		delete(cache.entries, oldest.Value.(cacheEntry).isbn)
+	}
+}
@@ -0,0 +1,119 @@
+package metadata
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bkiran6398/library/internal/books/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache_CachesAnswers(t *testing.T) {
+	fake := NewFake(domain.BookMetadata{ISBN: "9780134190440", Title: "Go"})
+	cache := NewCache(fake, time.Hour, 10)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+
+	for range 2 {
+		bookMetadata, err := cache.Lookup(context.Background(), "9780134190440")
+		require.NoError(t, err)
+		require.Equal(t, "Go", bookMetadata.Title)
+
+		_, err = cache.Lookup(context.Background(), "9780306406157")
+		require.ErrorIs(t, err, ErrNotFound)
+	}
+	require.Equal(t, 2, fake.Lookups())
+
+	now = now.Add(time.Hour)
+	_, err := cache.Lookup(context.Background(), "9780134190440")
+	require.NoError(t, err)
+	require.Equal(t, 3, fake.Lookups())
+}
+
+func TestCache_DoesNotCacheFailures(t *testing.T) {
+	fake := NewFake()
+	fake.Err = errors.New("provider down")
+	cache := NewCache(fake, time.Hour, 10)
+
+	for range 2 {
+		_, err := cache.Lookup(context.Background(), "9780134190440")
+		require.ErrorContains(t, err, "provider down")
+	}
+	require.Equal(t, 2, fake.Lookups())
+}
+
+func TestCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	fake := NewFake(
+		domain.BookMetadata{ISBN: "1"},
+		domain.BookMetadata{ISBN: "2"},
+		domain.BookMetadata{ISBN: "3"},
+	)
+	cache := NewCache(fake, time.Hour, 2)
+
+	for _, isbn := range []string{"1", "2", "1", "3"} {
+		_, err := cache.Lookup(context.Background(), isbn)
+		require.NoError(t, err)
+	}
+	require.Equal(t, 3, fake.Lookups())
+
+	// "2" was the least recently used entry when "3" was added.
+	_, _ = cache.Lookup(context.Background(), "1")
+	require.Equal(t, 3, fake.Lookups())
+	_, _ = cache.Lookup(context.Background(), "2")
+	require.Equal(t, 4, fake.Lookups())
+}
+
+// blockingProvider holds every lookup until release is closed.
+type blockingProvider struct {
+	release chan struct{}
+	lookups atomic.Int32
+}
+
+func (provider *blockingProvider) Lookup(_ context.Context, isbn string) (domain.BookMetadata, error) {
+	provider.lookups.Add(1)
+	<-provider.release
+	return domain.BookMetadata{ISBN: isbn, Title: "Go"}, nil
+}
+
+func TestCache_CoalescesConcurrentLookups(t *testing.T) {
+	provider := &blockingProvider{release: make(chan struct{})}
+	cache := NewCache(provider, time.Hour, 10)
+
+	var waitGroup sync.WaitGroup
+	titles := make([]string, 5)
+	for index := range titles {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			bookMetadata, _ := cache.Lookup(context.Background(), "9780134190440")
+			titles[index] = bookMetadata.Title
+		}()
+	}
+	require.Eventually(t, func() bool { return provider.lookups.Load() == 1 }, time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	close(provider.release)
+	waitGroup.Wait()
+
+	require.EqualValues(t, 1, provider.lookups.Load())
+	require.Equal(t, []string{"Go", "Go", "Go", "Go", "Go"}, titles)
+}
+
+func TestCache_CallerCancellationDoesNotAbortSharedLookup(t *testing.T) {
+	provider := &blockingProvider{release: make(chan struct{})}
+	cache := NewCache(provider, time.Hour, 10)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := cache.Lookup(ctx, "9780134190440")
+	require.ErrorIs(t, err, context.Canceled)
+
+	close(provider.release)
+	require.Eventually(t, func() bool {
+		_, cached := cache.get("9780134190440")
+		return cached
+	}, time.Second, time.Millisecond)
+}
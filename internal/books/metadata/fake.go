@@ -0,0 +1,50 @@
+package metadata
+
+import (
+	"context"
+	"sync"
+
+	"github.com/bkiran6398/library/internal/books/domain"
+)
+
+// Fake is an in-memory Provider for tests and local development. It answers from the
+// records it was given and counts the lookups it served.
+type Fake struct {
+	// Err, when set, is returned by every lookup instead of a record.
+	Err error
+
+	mutex   sync.Mutex
+	records map[string]domain.BookMetadata
+	lookups int
+}
+
+// NewFake creates a Fake that knows the given records, keyed by their ISBN.
+func NewFake(records ...domain.BookMetadata) *Fake {
+	fake := &Fake{records: make(map[string]domain.BookMetadata, len(records))}
+	for _, record := range records {
+		fake.records[record.ISBN] = record
+	}
+	return fake
+}
+
+func (fake *Fake) Lookup(_ context.Context, isbn string) (domain.BookMetadata, error) {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+	fake.lookups++
+
+	if fake.Err != nil {
+		return domain.BookMetadata{}, fake.Err
+	}
+	record, ok := fake.records[isbn]
+	if !ok {
+		return domain.BookMetadata{}, ErrNotFound
+	}
+	return record, nil
+}
+
+// Lookups returns how many lookups the fake has served.
+func (fake *Fake) Lookups() int {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+	return fake.lookups
+}
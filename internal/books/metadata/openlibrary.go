@@ -0,0 +1,112 @@
+package metadata
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bkiran6398/library/internal/books/domain"
+)
+
+// DefaultOpenLibraryURL is the public Open Library API.
+const DefaultOpenLibraryURL = "https://openlibrary.org"
+
+// maxOpenLibraryResponseBytes bounds the response body read for one ISBN.
+const maxOpenLibraryResponseBytes = 1 << 20
+
+// yearPattern finds a four digit year in a free-text date such as "October 26, 2015".
+var yearPattern = regexp.MustCompile(`\d{4}`)
+
+// OpenLibrary queries the books API of Open Library, or of any server implementing the same
+// `/api/books?bibkeys=ISBN:...&format=json&jscmd=data` endpoint.
+type OpenLibrary struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewOpenLibrary creates a provider for the API at baseURL; each lookup is bounded by timeout.
+func NewOpenLibrary(baseURL string, timeout time.Duration) *OpenLibrary {
+	return &OpenLibrary{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// openLibraryBook is the subset of a jscmd=data record that maps onto a book.
+type openLibraryBook struct {
+	Title       string `json:"title"`
+	Subtitle    string `json:"subtitle"`
+	PublishDate string `json:"publish_date"`
+	Authors     []struct {
+		Name string `json:"name"`
+	} `json:"authors"`
+	Cover struct {
+		Small  string `json:"small"`
+		Medium string `json:"medium"`
+		Large  string `json:"large"`
+	} `json:"cover"`
+}
+
+func (openLibrary *OpenLibrary) Lookup(ctx context.Context, isbn string) (domain.BookMetadata, error) {
+	bibKey := "ISBN:" + isbn
+	query := url.Values{"bibkeys": {bibKey}, "format": {"json"}, "jscmd": {"data"}}
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, openLibrary.baseURL+"/api/books?"+query.Encode(), nil)
+	if err != nil {
+		return domain.BookMetadata{}, err
+	}
+	request.Header.Set("Accept", "application/json")
+
+	httpResponse, err := openLibrary.httpClient.Do(request)
+	if err != nil {
+		return domain.BookMetadata{}, fmt.Errorf("open library lookup: %w", err)
+	}
+	defer httpResponse.Body.Close()
+	if httpResponse.StatusCode != http.StatusOK {
+		return domain.BookMetadata{}, fmt.Errorf("open library lookup: unexpected status %d", httpResponse.StatusCode)
+	}
+
+	var records map[string]openLibraryBook
+	if err := json.NewDecoder(io.LimitReader(httpResponse.Body, maxOpenLibraryResponseBytes)).Decode(&records); err != nil {
+		return domain.BookMetadata{}, fmt.Errorf("open library lookup: decode response: %w", err)
+	}
+	record, ok := records[bibKey]
+	if !ok {
+		return domain.BookMetadata{}, ErrNotFound
+	}
+	return record.toMetadata(isbn), nil
+}
+
+func (record openLibraryBook) toMetadata(isbn string) domain.BookMetadata {
+	bookMetadata := domain.BookMetadata{ISBN: isbn, Title: strings.TrimSpace(record.Title)}
+	if subtitle := strings.TrimSpace(record.Subtitle); subtitle != "" && bookMetadata.Title != "" {
+		bookMetadata.Title += ": " + subtitle
+	}
+
+	var authors []string
+	for _, author := range record.Authors {
+		if name := strings.TrimSpace(author.Name); name != "" {
+			authors = append(authors, name)
+		}
+	}
+	bookMetadata.Author = strings.Join(authors, " and ")
+
+	if match := yearPattern.FindString(record.PublishDate); match != "" {
+		year, _ := strconv.Atoi(match)
+		bookMetadata.PublishedYear = &year
+	}
+
+	for _, coverURL := range []string{record.Cover.Large, record.Cover.Medium, record.Cover.Small} {
+		if coverURL != "" {
+			bookMetadata.CoverURL = coverURL
+			break
+		}
+	}
+	return bookMetadata
+}
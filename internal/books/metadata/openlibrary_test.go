@@ -0,0 +1,77 @@
+package metadata
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bkiran6398/library/internal/books/domain"
+	"github.com/stretchr/testify/require"
+)
+
+// openLibraryStub serves canned jscmd=data responses keyed by the bibkeys parameter.
+func openLibraryStub(t *testing.T, responses map[string]string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/books", r.URL.Path)
+		require.Equal(t, "json", r.URL.Query().Get("format"))
+		require.Equal(t, "data", r.URL.Query().Get("jscmd"))
+		body, ok := responses[r.URL.Query().Get("bibkeys")]
+		if !ok {
+			body = "{}"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestOpenLibrary_Lookup(t *testing.T) {
+	server := openLibraryStub(t, map[string]string{
+		"ISBN:9780134190440": `{"ISBN:9780134190440": {
+			"title": "The Go Programming Language",
+			"subtitle": "A Practical Guide",
+			"publish_date": "October 26, 2015",
+			"authors": [{"name": "Alan A. A. Donovan"}, {"name": "Brian W. Kernighan"}],
+			"cover": {"small": "https://covers.example/s.jpg", "medium": "https://covers.example/m.jpg"}
+		}}`,
+	})
+	provider := NewOpenLibrary(server.URL+"/", time.Second)
+
+	bookMetadata, err := provider.Lookup(context.Background(), "9780134190440")
+	require.NoError(t, err)
+	year := 2015
+	require.Equal(t, domain.BookMetadata{
+		ISBN:          "9780134190440",
+		Title:         "The Go Programming Language: A Practical Guide",
+		Author:        "Alan A. A. Donovan and Brian W. Kernighan",
+		PublishedYear: &year,
+		CoverURL:      "https://covers.example/m.jpg",
+	}, bookMetadata)
+
+	_, err = provider.Lookup(context.Background(), "9780306406157")
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestOpenLibrary_Failures(t *testing.T) {
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer failing.Close()
+	_, err := NewOpenLibrary(failing.URL, time.Second).Lookup(context.Background(), "9780134190440")
+	require.ErrorContains(t, err, "unexpected status 502")
+	require.False(t, errors.Is(err, ErrNotFound))
+
+	release := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer slow.Close()
+	defer close(release)
+	_, err = NewOpenLibrary(slow.URL, 50*time.Millisecond).Lookup(context.Background(), "9780134190440")
+	require.Error(t, err)
+	require.False(t, errors.Is(err, ErrNotFound))
+}
@@ -0,0 +1,19 @@
+// Package metadata looks up bibliographic data about a book by ISBN from an external
+// provider, such as Open Library.
+package metadata
+
+import (
+	"context"
+	"errors"
+
+	"github.com/bkiran6398/library/internal/books/domain"
+)
+
+// ErrNotFound is returned when the provider has no record for an ISBN.
+var ErrNotFound = errors.New("no metadata for isbn")
+
+// Provider looks up metadata by canonical ISBN-13. Implementations return ErrNotFound when
+// the ISBN is unknown and any other error when the provider could not be asked.
+type Provider interface {
+	Lookup(ctx context.Context, isbn string) (domain.BookMetadata, error)
+}
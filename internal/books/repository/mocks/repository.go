@@ -42,6 +42,21 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AdjustCopies mocks base method.
+func (m *MockRepository) AdjustCopies(ctx context.Context, adjustment domain.CopyAdjustment) (domain.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdjustCopies", ctx, adjustment)
+	ret0, _ := ret[0].(domain.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdjustCopies indicates an expected call of AdjustCopies.
+func (mr *MockRepositoryMockRecorder) AdjustCopies(ctx, adjustment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdjustCopies", reflect.TypeOf((*MockRepository)(nil).AdjustCopies), ctx, adjustment)
+}
+
 // Create mocks base method.
 func (m *MockRepository) Create(ctx context.Context, book domain.Book) (domain.Book, error) {
 	m.ctrl.T.Helper()
@@ -57,6 +72,21 @@ func (mr *MockRepositoryMockRecorder) Create(ctx, book any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), ctx, book)
 }
 
+// CreateWork mocks base method.
+func (m *MockRepository) CreateWork(ctx context.Context, work domain.Work, bookIDs []uuid.UUID) (domain.Work, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWork", ctx, work, bookIDs)
+	ret0, _ := ret[0].(domain.Work)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWork indicates an expected call of CreateWork.
+func (mr *MockRepositoryMockRecorder) CreateWork(ctx, work, bookIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWork", reflect.TypeOf((*MockRepository)(nil).CreateWork), ctx, work, bookIDs)
+}
+
 // Delete mocks base method.
 func (m *MockRepository) Delete(ctx context.Context, bookID uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -71,6 +101,34 @@ func (mr *MockRepositoryMockRecorder) Delete(ctx, bookID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), ctx, bookID)
 }
 
+// DeleteWork mocks base method.
+func (m *MockRepository) DeleteWork(ctx context.Context, workID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWork", ctx, workID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWork indicates an expected call of DeleteWork.
+func (mr *MockRepositoryMockRecorder) DeleteWork(ctx, workID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWork", reflect.TypeOf((*MockRepository)(nil).DeleteWork), ctx, workID)
+}
+
+// Export mocks base method.
+func (m *MockRepository) Export(ctx context.Context, filter domain.ListFilter, each func(domain.Book) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, filter, each)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockRepositoryMockRecorder) Export(ctx, filter, each any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockRepository)(nil).Export), ctx, filter, each)
+}
+
 // Get mocks base method.
 func (m *MockRepository) Get(ctx context.Context, bookID uuid.UUID) (domain.Book, error) {
 	m.ctrl.T.Helper()
@@ -86,6 +144,36 @@ func (mr *MockRepositoryMockRecorder) Get(ctx, bookID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepository)(nil).Get), ctx, bookID)
 }
 
+// GetMany mocks base method.
+func (m *MockRepository) GetMany(ctx context.Context, bookIDs []uuid.UUID) ([]domain.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMany", ctx, bookIDs)
+	ret0, _ := ret[0].([]domain.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMany indicates an expected call of GetMany.
+func (mr *MockRepositoryMockRecorder) GetMany(ctx, bookIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMany", reflect.TypeOf((*MockRepository)(nil).GetMany), ctx, bookIDs)
+}
+
+// GetWork mocks base method.
+func (m *MockRepository) GetWork(ctx context.Context, workID uuid.UUID) (domain.Work, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWork", ctx, workID)
+	ret0, _ := ret[0].(domain.Work)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWork indicates an expected call of GetWork.
+func (mr *MockRepositoryMockRecorder) GetWork(ctx, workID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWork", reflect.TypeOf((*MockRepository)(nil).GetWork), ctx, workID)
+}
+
 // List mocks base method.
 func (m *MockRepository) List(ctx context.Context, filter domain.ListFilter) ([]domain.Book, error) {
 	m.ctrl.T.Helper()
@@ -101,6 +189,21 @@ func (mr *MockRepositoryMockRecorder) List(ctx, filter any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRepository)(nil).List), ctx, filter)
 }
 
+// Stats mocks base method.
+func (m *MockRepository) Stats(ctx context.Context) (domain.CatalogStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stats", ctx)
+	ret0, _ := ret[0].(domain.CatalogStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Stats indicates an expected call of Stats.
+func (mr *MockRepositoryMockRecorder) Stats(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockRepository)(nil).Stats), ctx)
+}
+
 // Update mocks base method.
 func (m *MockRepository) Update(ctx context.Context, book domain.Book) (domain.Book, error) {
 	m.ctrl.T.Helper()
@@ -115,3 +218,18 @@ func (mr *MockRepositoryMockRecorder) Update(ctx, book any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepository)(nil).Update), ctx, book)
 }
+
+// UpsertByISBN mocks base method.
+func (m *MockRepository) UpsertByISBN(ctx context.Context, books []domain.UpsertBook, dryRun bool) ([]domain.UpsertResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertByISBN", ctx, books, dryRun)
+	ret0, _ := ret[0].([]domain.UpsertResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertByISBN indicates an expected call of UpsertByISBN.
+func (mr *MockRepositoryMockRecorder) UpsertByISBN(ctx, books, dryRun any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertByISBN", reflect.TypeOf((*MockRepository)(nil).UpsertByISBN), ctx, books, dryRun)
+}
@@ -8,8 +8,8 @@ import (
 	"github.com/bkiran6398/library/internal/books/domain"
 	intErr "github.com/bkiran6398/library/internal/errors"
 	"github.com/google/uuid"
-	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -25,35 +25,119 @@ func NewPgRepository(dbPool *pgxpool.Pool) *pgRepository {
 }
 
 func (repository *pgRepository) Create(ctx context.Context, book domain.Book) (domain.Book, error) {
+	tx, err := repository.dbPool.Begin(ctx)
+	if err != nil {
+		return domain.Book{}, fmt.Errorf("begin create book: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
 	const insertQuery = `
-INSERT INTO books (id, title, author, isbn, published_year, copies_total, copies_available, created_at, updated_at)
-VALUES ($1,$2,$3,$4,$5,$6,$7,NOW(),NOW())
+INSERT INTO books (id, title, author, isbn, published_year, copies_total, copies_available, cover_url, work_id, publisher, edition, format, language, page_count, created_at, updated_at)
+VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,NOW(),NOW())
 RETURNING created_at, updated_at;
 `
-	row := repository.dbPool.QueryRow(ctx, insertQuery, book.ID, book.Title, book.Author, book.ISBN, book.PublishedYear, book.CopiesTotal, book.CopiesAvailable)
+	row := tx.QueryRow(ctx, insertQuery, book.ID, book.Title, book.Author, book.ISBN, book.PublishedYear, book.CopiesTotal, book.CopiesAvailable, book.CoverURL,
+		book.WorkID, book.Publisher, book.Edition, book.Format, book.Language, book.PageCount)
 	if err := row.Scan(&book.CreatedAt, &book.UpdatedAt); err != nil {
 		if isUniqueViolationError(err) {
 			return domain.Book{}, intErr.ErrConflict
 		}
+		if isForeignKeyViolationError(err) {
+			return domain.Book{}, errUnknownWork
+		}
 		return domain.Book{}, fmt.Errorf("insert book: %w", err)
 	}
+	if err := linkAuthorsByName(ctx, tx, []uuid.UUID{book.ID}); err != nil {
+		return domain.Book{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.Book{}, fmt.Errorf("commit create book: %w", err)
+	}
 	return book, nil
 }
 
+// normalizedAuthorSQL is the books.author text with surrounding whitespace trimmed and runs
+// of whitespace collapsed, the form author names are stored in.
+const normalizedAuthorSQL = `btrim(regexp_replace(books.author, '\s+', ' ', 'g'))`
+
+// creditedAuthorsSQL is the books.author text the author credits of a book produce, the
+// same text the authors repository writes when contributors change.
+const creditedAuthorsSQL = `(
+	SELECT string_agg(authors.name, ' and ' ORDER BY credit.ordinal)
+	FROM book_authors credit JOIN authors ON authors.id = credit.author_id
+	WHERE credit.book_id = books.id AND credit.role = 'author'
+)`
+
+// relinkAuthorsByName re-credits the books whose author text no longer matches their
+// author credits, e.g. after the text was edited, so that the credits do not later rewrite
+// the edit. Other contributor roles are kept.
+func relinkAuthorsByName(ctx context.Context, tx pgx.Tx, bookIDs []uuid.UUID) error {
+	const unlinkQuery = `
+DELETE FROM book_authors USING books
+WHERE book_authors.book_id = books.id AND books.id = ANY($1) AND book_authors.role = 'author'
+	AND ` + normalizedAuthorSQL + ` <> ` + creditedAuthorsSQL + `;
+`
+	if _, err := tx.Exec(ctx, unlinkQuery, bookIDs); err != nil {
+		return fmt.Errorf("unlink authors: %w", err)
+	}
+	return linkAuthorsByName(ctx, tx, bookIDs)
+}
+
+// linkAuthorsByName credits each of the books that has no author credit yet to the author
+// named by its author text, creating the author if needed.
+func linkAuthorsByName(ctx context.Context, tx pgx.Tx, bookIDs []uuid.UUID) error {
+	const insertAuthorsQuery = `
+INSERT INTO authors (id, name, created_at, updated_at)
+SELECT gen_random_uuid(), ` + normalizedAuthorSQL + `, NOW(), NOW()
+FROM books
+WHERE books.id = ANY($1) AND ` + normalizedAuthorSQL + ` <> ''
+	AND NOT EXISTS (SELECT 1 FROM book_authors WHERE book_authors.book_id = books.id AND book_authors.role = 'author')
+ON CONFLICT ((lower(name))) DO NOTHING;
+`
+	if _, err := tx.Exec(ctx, insertAuthorsQuery, bookIDs); err != nil {
+		return fmt.Errorf("insert authors: %w", err)
+	}
+
+	const linkQuery = `
+INSERT INTO book_authors (book_id, author_id, role, ordinal)
+SELECT books.id, authors.id, 'author', COALESCE((SELECT MAX(ordinal) FROM book_authors WHERE book_authors.book_id = books.id), 0) + 1
+FROM books JOIN authors ON lower(authors.name) = lower(` + normalizedAuthorSQL + `)
+WHERE books.id = ANY($1)
+	AND NOT EXISTS (SELECT 1 FROM book_authors WHERE book_authors.book_id = books.id AND book_authors.role = 'author');
+`
+	if _, err := tx.Exec(ctx, linkQuery, bookIDs); err != nil {
+		return fmt.Errorf("link authors: %w", err)
+	}
+	return nil
+}
+
 // isUniqueViolationError checks if the error is a PostgreSQL unique violation error.
 func isUniqueViolationError(err error) bool {
 	var pgError *pgconn.PgError
 	return errors.As(err, &pgError) && pgError.Code == "23505"
 }
 
+// isForeignKeyViolationError checks if the error is a PostgreSQL foreign key violation error.
+// work_id is the only foreign key written through books.
+func isForeignKeyViolationError(err error) bool {
+	var pgError *pgconn.PgError
+	return errors.As(err, &pgError) && pgError.Code == "23503"
+}
+
+var errUnknownWork = fmt.Errorf("%w: unknown work", intErr.ErrBadRequest)
+
+// branchManagedSQL holds for books whose copies are counted per branch; their copy counts
+// follow the branch holdings and cannot be set on the book.
+const branchManagedSQL = `(EXISTS (SELECT 1 FROM branch_holdings WHERE branch_holdings.book_id = books.id)
+	OR EXISTS (SELECT 1 FROM transfers WHERE transfers.book_id = books.id AND transfers.status = 'in_transit'))`
+
+var errBranchManagedCopies = fmt.Errorf("%w: copies of this book are managed per branch", intErr.ErrConflict)
+
 func (repository *pgRepository) Get(ctx context.Context, bookID uuid.UUID) (domain.Book, error) {
-	const selectQuery = `
-SELECT id, title, author, isbn, published_year, copies_total, copies_available, created_at, updated_at
-FROM books WHERE id=$1;
-`
-	var book domain.Book
-	row := repository.dbPool.QueryRow(ctx, selectQuery, bookID)
-	if err := row.Scan(&book.ID, &book.Title, &book.Author, &book.ISBN, &book.PublishedYear, &book.CopiesTotal, &book.CopiesAvailable, &book.CreatedAt, &book.UpdatedAt); err != nil {
+	const selectQuery = `SELECT ` + bookColumns + ` FROM books WHERE id=$1;`
+	book, err := scanBook(repository.dbPool.QueryRow(ctx, selectQuery, bookID))
+	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return domain.Book{}, intErr.ErrNotFound
 		}
@@ -62,22 +146,103 @@ FROM books WHERE id=$1;
 	return book, nil
 }
 
+func (repository *pgRepository) GetMany(ctx context.Context, bookIDs []uuid.UUID) ([]domain.Book, error) {
+	const selectQuery = `SELECT ` + bookColumns + ` FROM books WHERE id = ANY($1);`
+	rows, err := repository.dbPool.Query(ctx, selectQuery, bookIDs)
+	if err != nil {
+		return nil, fmt.Errorf("get books: %w", err)
+	}
+	defer rows.Close()
+	return scanBooksFromRows(rows)
+}
+
 func (repository *pgRepository) Update(ctx context.Context, book domain.Book) (domain.Book, error) {
+	tx, err := repository.dbPool.Begin(ctx)
+	if err != nil {
+		return domain.Book{}, fmt.Errorf("begin update book: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
 	const updateQuery = `
-UPDATE books SET title=$2, author=$3, isbn=$4, published_year=$5, copies_total=$6, copies_available=$7, updated_at=NOW()
-WHERE id=$1
+UPDATE books SET title=$2, author=$3, isbn=$4, published_year=$5, copies_total=$6, copies_available=$7, cover_url=$8,
+	work_id=$9, publisher=$10, edition=$11, format=$12, language=$13, page_count=$14, updated_at=NOW()
+WHERE id=$1 AND ((copies_total=$6 AND copies_available=$7) OR NOT ` + branchManagedSQL + `)
 RETURNING created_at, updated_at;
 `
-	row := repository.dbPool.QueryRow(ctx, updateQuery, book.ID, book.Title, book.Author, book.ISBN, book.PublishedYear, book.CopiesTotal, book.CopiesAvailable)
+	row := tx.QueryRow(ctx, updateQuery, book.ID, book.Title, book.Author, book.ISBN, book.PublishedYear, book.CopiesTotal, book.CopiesAvailable, book.CoverURL,
+		book.WorkID, book.Publisher, book.Edition, book.Format, book.Language, book.PageCount)
 	if err := row.Scan(&book.CreatedAt, &book.UpdatedAt); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return domain.Book{}, intErr.ErrNotFound
+			return domain.Book{}, repository.explainMiss(ctx, book.ID, intErr.ErrConflict)
 		}
 		if isUniqueViolationError(err) {
 			return domain.Book{}, intErr.ErrConflict
 		}
+		if isForeignKeyViolationError(err) {
+			return domain.Book{}, errUnknownWork
+		}
 		return domain.Book{}, fmt.Errorf("update book: %w", err)
 	}
+	if err := relinkAuthorsByName(ctx, tx, []uuid.UUID{book.ID}); err != nil {
+		return domain.Book{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.Book{}, fmt.Errorf("commit update book: %w", err)
+	}
+	return book, nil
+}
+
+// explainMiss explains a copy-changing update that matched no row: the book does not exist,
+// its copies are managed per branch, or otherwise.
+func (repository *pgRepository) explainMiss(ctx context.Context, bookID uuid.UUID, otherwise error) error {
+	var branchManaged bool
+	err := repository.dbPool.QueryRow(ctx, `SELECT `+branchManagedSQL+` FROM books WHERE id=$1;`, bookID).Scan(&branchManaged)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return intErr.ErrNotFound
+		}
+		return fmt.Errorf("check book: %w", err)
+	}
+	if branchManaged {
+		return errBranchManagedCopies
+	}
+	return otherwise
+}
+
+// adjustCopiesQuery applies the deltas only when the result satisfies the books CHECK
+// constraints, and writes the ledger entry in the same statement.
+const adjustCopiesQuery = `
+WITH adjusted AS (
+	UPDATE books SET
+		copies_total = copies_total + $3,
+		copies_available = copies_available + $4,
+		updated_at = NOW()
+	WHERE id = $2
+		AND copies_total + $3 >= 0
+		AND copies_available + $4 >= 0
+		AND copies_available + $4 <= copies_total + $3
+		AND NOT ` + branchManagedSQL + `
+	RETURNING ` + bookColumns + `
+), ledger AS (
+	INSERT INTO inventory_ledger (id, book_id, total_delta, available_delta, reason, note, copies_total, copies_available, created_at)
+	SELECT $1, id, $3, $4, $5, $6, copies_total, copies_available, NOW() FROM adjusted
+)
+SELECT ` + bookColumns + ` FROM adjusted;
+`
+
+var errNegativeCopies = fmt.Errorf("%w: the adjustment would leave a negative count or more copies available than in total", intErr.ErrConflict)
+
+func (repository *pgRepository) AdjustCopies(ctx context.Context, adjustment domain.CopyAdjustment) (domain.Book, error) {
+	row := repository.dbPool.QueryRow(ctx, adjustCopiesQuery, adjustment.ID, adjustment.BookID, adjustment.TotalDelta, adjustment.AvailableDelta,
+		string(adjustment.Reason), adjustment.Note)
+	book, err := scanBook(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Book{}, repository.explainMiss(ctx, adjustment.BookID, errNegativeCopies)
+		}
+		return domain.Book{}, fmt.Errorf("adjust copies: %w", err)
+	}
 	return book, nil
 }
 
@@ -108,12 +273,288 @@ func (repository *pgRepository) List(ctx context.Context, filter domain.ListFilt
 	return books, nil
 }
 
+func (repository *pgRepository) Stats(ctx context.Context) (domain.CatalogStats, error) {
+	const statsQuery = `
+SELECT COUNT(*), COALESCE(SUM(copies_total), 0), COALESCE(SUM(copies_total - copies_available), 0)
+FROM books;
+`
+	var stats domain.CatalogStats
+	if err := repository.dbPool.QueryRow(ctx, statsQuery).Scan(&stats.Books, &stats.CopiesTotal, &stats.CopiesOnLoan); err != nil {
+		return domain.CatalogStats{}, fmt.Errorf("catalog stats: %w", err)
+	}
+	return stats, nil
+}
+
+// exportFetchSize is the number of rows fetched from the export cursor per round trip.
+const exportFetchSize = 1000
+
+func (repository *pgRepository) Export(ctx context.Context, filter domain.ListFilter, each func(domain.Book) error) error {
+	tx, err := repository.dbPool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return fmt.Errorf("begin export: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query, queryArguments := buildListQuery(filter)
+	if _, err := tx.Exec(ctx, "DECLARE books_export NO SCROLL CURSOR FOR "+query, queryArguments...); err != nil {
+		return fmt.Errorf("declare export cursor: %w", err)
+	}
+
+	fetchQuery := fmt.Sprintf("FETCH FORWARD %d FROM books_export;", exportFetchSize)
+	for {
+		fetched, err := fetchAndEach(ctx, tx, fetchQuery, each)
+		if err != nil {
+			return err
+		}
+		if fetched < exportFetchSize {
+			return nil
+		}
+	}
+}
+
+// fetchAndEach runs one FETCH on the export cursor and calls each per row.
+func fetchAndEach(ctx context.Context, tx pgx.Tx, fetchQuery string, each func(domain.Book) error) (int, error) {
+	rows, err := tx.Query(ctx, fetchQuery)
+	if err != nil {
+		return 0, fmt.Errorf("fetch export rows: %w", err)
+	}
+	defer rows.Close()
+
+	fetched := 0
+	for rows.Next() {
+		book, err := scanBook(rows)
+		if err != nil {
+			return 0, fmt.Errorf("scan book row: %w", err)
+		}
+		if err := each(book); err != nil {
+			return 0, err
+		}
+		fetched++
+	}
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return fetched, nil
+}
+
+// upsertChunkSize bounds the number of books sent in one upsert statement.
+const upsertChunkSize = 500
+
+// upsertQuery inserts books from parallel arrays, updating those whose ISBN exists. A NULL
+// copies_total keeps the copies of an existing book (and means none for a new one). A NULL
+// copies_available keeps the copies on loan for an existing book and means "all copies" for
+// a new one. A NULL cover_url or edition detail keeps the existing value. xmax is 0 only for
+// freshly inserted rows.
+const upsertQuery = `
+WITH source AS (
+	SELECT * FROM unnest($1::uuid[], $2::text[], $3::text[], $4::text[], $5::int[], $6::int[], $7::int[], $8::text[],
+		$9::uuid[], $10::text[], $11::text[], $12::text[], $13::text[], $14::int[])
+		AS s(id, title, author, isbn, published_year, copies_total, copies_available, cover_url,
+			work_id, publisher, edition, format, language, page_count)
+)
+INSERT INTO books (id, title, author, isbn, published_year, copies_total, copies_available, cover_url,
+	work_id, publisher, edition, format, language, page_count, created_at, updated_at)
+SELECT id, title, author, isbn, published_year, COALESCE(copies_total, 0), COALESCE(copies_available, copies_total, 0), cover_url,
+	work_id, publisher, edition, format, language, page_count, NOW(), NOW()
+FROM source
+ON CONFLICT (isbn) DO UPDATE SET
+	title=EXCLUDED.title,
+	author=EXCLUDED.author,
+	published_year=EXCLUDED.published_year,
+	cover_url=COALESCE(EXCLUDED.cover_url, books.cover_url),
+	work_id=COALESCE(EXCLUDED.work_id, books.work_id),
+	publisher=COALESCE(EXCLUDED.publisher, books.publisher),
+	edition=COALESCE(EXCLUDED.edition, books.edition),
+	format=COALESCE(EXCLUDED.format, books.format),
+	language=COALESCE(EXCLUDED.language, books.language),
+	page_count=COALESCE(EXCLUDED.page_count, books.page_count),
+	copies_total=CASE
+		WHEN ` + branchManagedSQL + ` THEN books.copies_total
+		ELSE COALESCE((SELECT source.copies_total FROM source WHERE source.isbn=EXCLUDED.isbn), books.copies_total)
+	END,
+	copies_available=CASE
+		WHEN ` + branchManagedSQL + ` THEN books.copies_available
+		WHEN (SELECT source.copies_total FROM source WHERE source.isbn=EXCLUDED.isbn) IS NULL THEN books.copies_available
+		ELSE COALESCE(
+			(SELECT source.copies_available FROM source WHERE source.isbn=EXCLUDED.isbn),
+			GREATEST(0, books.copies_available + EXCLUDED.copies_total - books.copies_total)
+		)
+	END,
+	updated_at=NOW()
+RETURNING id, isbn, xmax = 0;
+`
+
+func (repository *pgRepository) UpsertByISBN(ctx context.Context, books []domain.UpsertBook, dryRun bool) ([]domain.UpsertResult, error) {
+	tx, err := repository.dbPool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin upsert: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	results := make([]domain.UpsertResult, 0, len(books))
+	for start := 0; start < len(books); start += upsertChunkSize {
+		chunk := books[start:min(start+upsertChunkSize, len(books))]
+		chunkResults, err := upsertChunk(ctx, tx, chunk)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, chunkResults...)
+	}
+
+	if dryRun {
+		return results, nil
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit upsert: %w", err)
+	}
+	return results, nil
+}
+
+// upsertChunk upserts a chunk in one statement. If the database rejects it, typically because
+// of one bad row or an ISBN repeated within the chunk, it retries row by row so only the
+// offending rows fail.
+func upsertChunk(ctx context.Context, tx pgx.Tx, chunk []domain.UpsertBook) ([]domain.UpsertResult, error) {
+	results, err := upsertInSavepoint(ctx, tx, chunk)
+	if err == nil {
+		return results, nil
+	}
+	if !isPgError(err) {
+		return nil, err
+	}
+
+	results = make([]domain.UpsertResult, len(chunk))
+	for index := range chunk {
+		rowResults, err := upsertInSavepoint(ctx, tx, chunk[index:index+1])
+		switch {
+		case err == nil:
+			results[index] = rowResults[0]
+		case isPgError(err):
+			results[index] = domain.UpsertResult{Err: mapUpsertError(err)}
+		default:
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+// upsertInSavepoint runs upsertQuery for books inside a savepoint, so a failure leaves the
+// surrounding transaction usable.
+func upsertInSavepoint(ctx context.Context, tx pgx.Tx, books []domain.UpsertBook) ([]domain.UpsertResult, error) {
+	savepoint, err := tx.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin upsert savepoint: %w", err)
+	}
+	defer savepoint.Rollback(ctx)
+
+	ids := make([]uuid.UUID, len(books))
+	titles := make([]string, len(books))
+	authors := make([]string, len(books))
+	isbns := make([]string, len(books))
+	publishedYears := make([]*int, len(books))
+	copiesTotal := make([]*int, len(books))
+	copiesAvailable := make([]*int, len(books))
+	coverURLs := make([]*string, len(books))
+	workIDs := make([]*uuid.UUID, len(books))
+	publishers := make([]*string, len(books))
+	editions := make([]*string, len(books))
+	formats := make([]*string, len(books))
+	languages := make([]*string, len(books))
+	pageCounts := make([]*int, len(books))
+	for index, upsert := range books {
+		book := upsert.Book
+		ids[index], titles[index], authors[index], isbns[index] = book.ID, book.Title, book.Author, book.ISBN
+		publishedYears[index], coverURLs[index] = book.PublishedYear, book.CoverURL
+		workIDs[index], publishers[index], editions[index] = book.WorkID, book.Publisher, book.Edition
+		languages[index], pageCounts[index] = book.Language, book.PageCount
+		if book.Format != nil {
+			format := string(*book.Format)
+			formats[index] = &format
+		}
+		if !upsert.KeepCopies {
+			copiesTotal[index] = &book.CopiesTotal
+		}
+		if !upsert.KeepCopies && !upsert.KeepCopiesOnLoan {
+			copiesAvailable[index] = &book.CopiesAvailable
+		}
+	}
+
+	rows, err := savepoint.Query(ctx, upsertQuery, ids, titles, authors, isbns, publishedYears, copiesTotal, copiesAvailable, coverURLs,
+		workIDs, publishers, editions, formats, languages, pageCounts)
+	if err != nil {
+		return nil, fmt.Errorf("upsert books: %w", err)
+	}
+	resultsByISBN := make(map[string]domain.UpsertResult, len(books))
+	for rows.Next() {
+		var isbn string
+		var result domain.UpsertResult
+		if err := rows.Scan(&result.ID, &isbn, &result.Created); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan upserted book: %w", err)
+		}
+		resultsByISBN[isbn] = result
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("upsert books: %w", err)
+	}
+	upsertedIDs := make([]uuid.UUID, 0, len(resultsByISBN))
+	for _, result := range resultsByISBN {
+		upsertedIDs = append(upsertedIDs, result.ID)
+	}
+	if err := relinkAuthorsByName(ctx, savepoint, upsertedIDs); err != nil {
+		return nil, err
+	}
+	if err := savepoint.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("release upsert savepoint: %w", err)
+	}
+
+	results := make([]domain.UpsertResult, len(books))
+	for index, upsert := range books {
+		results[index] = resultsByISBN[upsert.Book.ISBN]
+	}
+	return results, nil
+}
+
+// isPgError reports whether err was returned by the server for the statement, as opposed to
+// a connection or context failure.
+func isPgError(err error) bool {
+	var pgError *pgconn.PgError
+	return errors.As(err, &pgError)
+}
+
+// mapUpsertError converts a per-row database error to a service error.
+func mapUpsertError(err error) error {
+	var pgError *pgconn.PgError
+	errors.As(err, &pgError)
+	switch pgError.Code {
+	case "23505":
+		return fmt.Errorf("%w: %s", intErr.ErrConflict, pgError.Message)
+	case "23514", "22003":
+		return fmt.Errorf("%w: %s", intErr.ErrBadRequest, pgError.Message)
+	case "23503":
+		return errUnknownWork
+	default:
+		return fmt.Errorf("upsert book: %w", err)
+	}
+}
+
+// bookColumns lists the books columns in the order scanBook reads them.
+const bookColumns = `id, title, author, isbn, published_year, cover_url, work_id, publisher, edition, format, language, page_count, copies_total, copies_available, created_at, updated_at`
+
+// scanBook scans one row selected with bookColumns.
+func scanBook(row pgx.Row) (domain.Book, error) {
+	var book domain.Book
+	err := row.Scan(&book.ID, &book.Title, &book.Author, &book.ISBN, &book.PublishedYear, &book.CoverURL,
+		&book.WorkID, &book.Publisher, &book.Edition, &book.Format, &book.Language, &book.PageCount,
+		&book.CopiesTotal, &book.CopiesAvailable, &book.CreatedAt, &book.UpdatedAt)
+	return book, err
+}
+
 // scanBooksFromRows scans database rows into Book entities.
 func scanBooksFromRows(rows pgx.Rows) ([]domain.Book, error) {
 	var books []domain.Book
 	for rows.Next() {
-		var book domain.Book
-		if err := rows.Scan(&book.ID, &book.Title, &book.Author, &book.ISBN, &book.PublishedYear, &book.CopiesTotal, &book.CopiesAvailable, &book.CreatedAt, &book.UpdatedAt); err != nil {
+		book, err := scanBook(rows)
+		if err != nil {
 			return nil, fmt.Errorf("scan book row: %w", err)
 		}
 		books = append(books, book)
@@ -123,3 +564,61 @@ func scanBooksFromRows(rows pgx.Rows) ([]domain.Book, error) {
 	}
 	return books, nil
 }
+
+func (repository *pgRepository) CreateWork(ctx context.Context, work domain.Work, bookIDs []uuid.UUID) (domain.Work, error) {
+	tx, err := repository.dbPool.Begin(ctx)
+	if err != nil {
+		return domain.Work{}, fmt.Errorf("begin create work: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	const insertQuery = `
+INSERT INTO works (id, title, created_at, updated_at)
+VALUES ($1,$2,NOW(),NOW())
+RETURNING created_at, updated_at;
+`
+	if err := tx.QueryRow(ctx, insertQuery, work.ID, work.Title).Scan(&work.CreatedAt, &work.UpdatedAt); err != nil {
+		return domain.Work{}, fmt.Errorf("insert work: %w", err)
+	}
+
+	if len(bookIDs) > 0 {
+		const groupQuery = `UPDATE books SET work_id=$1, updated_at=NOW() WHERE id = ANY($2);`
+		result, err := tx.Exec(ctx, groupQuery, work.ID, bookIDs)
+		if err != nil {
+			return domain.Work{}, fmt.Errorf("group editions: %w", err)
+		}
+		if result.RowsAffected() != int64(len(bookIDs)) {
+			return domain.Work{}, fmt.Errorf("%w: unknown book in book_ids", intErr.ErrBadRequest)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.Work{}, fmt.Errorf("commit create work: %w", err)
+	}
+	return work, nil
+}
+
+func (repository *pgRepository) GetWork(ctx context.Context, workID uuid.UUID) (domain.Work, error) {
+	const selectQuery = `SELECT id, title, created_at, updated_at FROM works WHERE id=$1;`
+	var work domain.Work
+	err := repository.dbPool.QueryRow(ctx, selectQuery, workID).Scan(&work.ID, &work.Title, &work.CreatedAt, &work.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Work{}, intErr.ErrNotFound
+		}
+		return domain.Work{}, fmt.Errorf("get work: %w", err)
+	}
+	return work, nil
+}
+
+func (repository *pgRepository) DeleteWork(ctx context.Context, workID uuid.UUID) error {
+	const deleteQuery = `DELETE FROM works WHERE id=$1;`
+	result, err := repository.dbPool.Exec(ctx, deleteQuery, workID)
+	if err != nil {
+		return fmt.Errorf("delete work: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return intErr.ErrNotFound
+	}
+	return nil
+}
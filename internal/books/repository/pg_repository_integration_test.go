@@ -2,11 +2,13 @@ package repository
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/bkiran6398/library/internal/books/domain"
 	"github.com/bkiran6398/library/internal/db"
+	intErr "github.com/bkiran6398/library/internal/errors"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 	"github.com/testcontainers/testcontainers-go/modules/postgres"
@@ -40,7 +42,7 @@ func setupTestDB(t *testing.T) (repository *pgRepository, cleanup func()) {
 	port, err := pgContainer.MappedPort(ctx, "5432/tcp")
 	require.NoError(t, err)
 
-	db.MigrationDir = "../../../migrations"
+	db.SetMigrationDir("../../../migrations")
 	pool, err := db.ConnectAndMigrate(ctx, host, port.Int(), "library", "secret", "library", "disable", 5, 1)
 	require.NoError(t, err)
 
@@ -75,6 +77,26 @@ func TestPgRepository_CreateGet(t *testing.T) {
 	require.Equal(t, 2, got.CopiesAvailable)
 }
 
+func TestPgRepository_GetMany(t *testing.T) {
+	repository, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	coverURL := "https://covers.example/test.jpg"
+	book := defaultBook
+	book.CoverURL = &coverURL
+	_, err := repository.Create(ctx, book)
+	require.NoError(t, err)
+
+	got, err := repository.GetMany(ctx, []uuid.UUID{book.ID, uuid.New()})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	require.Equal(t, book.ID, got[0].ID)
+	require.Equal(t, "Test Book", got[0].Title)
+	require.Equal(t, coverURL, *got[0].CoverURL)
+}
+
 func TestPgRepository_CreateUpdate(t *testing.T) {
 	repository, cleanup := setupTestDB(t)
 	defer cleanup()
@@ -125,3 +147,263 @@ func TestPgRepository_CreateList(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, got, 2)
 }
+
+func TestPgRepository_UpsertByISBN(t *testing.T) {
+	repository, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	_, err := repository.Create(ctx, defaultBook)
+	require.NoError(t, err)
+
+	newBook := defaultBook
+	newBook.ID = uuid.New()
+	newBook.ISBN = "ISBN-NEW"
+	updatedBook := defaultBook
+	updatedBook.ID = uuid.New()
+	updatedBook.Title = "Updated Title"
+	updatedBook.CopiesTotal = 5
+	invalidBook := newBook
+	invalidBook.ID = uuid.New()
+	invalidBook.ISBN = "ISBN-INVALID"
+	invalidBook.CopiesTotal = -1
+
+	results, err := repository.UpsertByISBN(ctx, []domain.UpsertBook{
+		{Book: newBook},
+		{Book: updatedBook, KeepCopiesOnLoan: true},
+		{Book: invalidBook},
+	}, false)
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	require.True(t, results[0].Created)
+	require.Equal(t, newBook.ID, results[0].ID)
+	require.False(t, results[1].Created)
+	require.Equal(t, defaultBook.ID, results[1].ID)
+	require.Error(t, results[2].Err)
+
+	got, err := repository.Get(ctx, defaultBook.ID)
+	require.NoError(t, err)
+	require.Equal(t, "Updated Title", got.Title)
+	require.Equal(t, 5, got.CopiesTotal)
+	require.Equal(t, 4, got.CopiesAvailable)
+
+	dryRunBook := newBook
+	dryRunBook.ID = uuid.New()
+	dryRunBook.ISBN = "ISBN-DRY-RUN"
+	results, err = repository.UpsertByISBN(ctx, []domain.UpsertBook{{Book: dryRunBook}}, true)
+	require.NoError(t, err)
+	require.True(t, results[0].Created)
+
+	_, err = repository.Get(ctx, dryRunBook.ID)
+	require.ErrorIs(t, err, intErr.ErrNotFound)
+}
+
+func TestPgRepository_Export(t *testing.T) {
+	repository, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	for index := range exportFetchSize + 1 {
+		book := defaultBook
+		book.ID = uuid.New()
+		book.ISBN = fmt.Sprintf("ISBN-%d", index)
+		_, err := repository.Create(ctx, book)
+		require.NoError(t, err)
+	}
+
+	exported := 0
+	err := repository.Export(ctx, domain.ListFilter{}, func(domain.Book) error {
+		exported++
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, exportFetchSize+1, exported)
+
+	isbn := "ISBN-7"
+	var filtered []domain.Book
+	err = repository.Export(ctx, domain.ListFilter{ISBN: &isbn}, func(book domain.Book) error {
+		filtered = append(filtered, book)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, filtered, 1)
+	require.Equal(t, isbn, filtered[0].ISBN)
+}
+
+func TestPgRepository_CreateLinksAuthorByName(t *testing.T) {
+	repository, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	first := defaultBook
+	first.Author = "Ursula K. Le Guin"
+	_, err := repository.Create(ctx, first)
+	require.NoError(t, err)
+
+	results, err := repository.UpsertByISBN(ctx, []domain.UpsertBook{
+		{Book: domain.Book{ID: uuid.New(), Title: "The Lathe of Heaven", Author: " ursula k.  le guin", ISBN: "9780060512750"}},
+		{Book: domain.Book{ID: uuid.New(), Title: "Kindred", Author: "Octavia E. Butler", ISBN: "9780807083697"}},
+	}, false)
+	require.NoError(t, err)
+	require.True(t, results[0].Created)
+
+	var authorID uuid.UUID
+	err = repository.dbPool.QueryRow(ctx, `SELECT id FROM authors WHERE name='Ursula K. Le Guin';`).Scan(&authorID)
+	require.NoError(t, err)
+
+	byAuthor, err := repository.List(ctx, domain.ListFilter{AuthorID: &authorID})
+	require.NoError(t, err)
+	require.Len(t, byAuthor, 2)
+	require.ElementsMatch(t, []uuid.UUID{first.ID, results[0].ID}, []uuid.UUID{byAuthor[0].ID, byAuthor[1].ID})
+}
+
+func TestPgRepository_UpdateRelinksChangedAuthor(t *testing.T) {
+	repository, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	book := defaultBook
+	book.Author = "Alan Donovan"
+	_, err := repository.Create(ctx, book)
+	require.NoError(t, err)
+
+	book.Author = "Brian Kernighan"
+	_, err = repository.Update(ctx, book)
+	require.NoError(t, err)
+
+	var credited string
+	err = repository.dbPool.QueryRow(ctx, `
+SELECT authors.name FROM book_authors JOIN authors ON authors.id = book_authors.author_id
+WHERE book_authors.book_id=$1 AND book_authors.role='author';`, book.ID).Scan(&credited)
+	require.NoError(t, err)
+	require.Equal(t, "Brian Kernighan", credited)
+}
+
+func TestPgRepository_ListBySubjectAndTag(t *testing.T) {
+	repository, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	fiction, scienceFiction := uuid.New(), uuid.New()
+	_, err := repository.dbPool.Exec(ctx, `INSERT INTO subjects (id, name, parent_id) VALUES ($1, 'Fiction', NULL), ($2, 'Science Fiction', $1);`, fiction, scienceFiction)
+	require.NoError(t, err)
+
+	filed := defaultBook
+	_, err = repository.Create(ctx, filed)
+	require.NoError(t, err)
+	_, err = repository.dbPool.Exec(ctx, `INSERT INTO book_subjects (book_id, subject_id) VALUES ($1, $2);`, filed.ID, scienceFiction)
+	require.NoError(t, err)
+	_, err = repository.dbPool.Exec(ctx, `INSERT INTO book_tags (book_id, tag) VALUES ($1, 'space opera');`, filed.ID)
+	require.NoError(t, err)
+
+	unfiled := defaultBook
+	unfiled.ID, unfiled.ISBN = uuid.New(), "ISBN-456"
+	_, err = repository.Create(ctx, unfiled)
+	require.NoError(t, err)
+
+	for _, subjectID := range []uuid.UUID{fiction, scienceFiction} {
+		books, err := repository.List(ctx, domain.ListFilter{SubjectID: &subjectID})
+		require.NoError(t, err)
+		require.Len(t, books, 1)
+		require.Equal(t, filed.ID, books[0].ID)
+	}
+
+	tag := "space opera"
+	books, err := repository.List(ctx, domain.ListFilter{Tag: &tag, SubjectID: &fiction})
+	require.NoError(t, err)
+	require.Len(t, books, 1)
+}
+
+func TestPgRepository_Works(t *testing.T) {
+	repository, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	hardcover, publisher := domain.BookFormatHardcover, "Ace"
+	edition := defaultBook
+	edition.Format, edition.Publisher = &hardcover, &publisher
+	_, err := repository.Create(ctx, edition)
+	require.NoError(t, err)
+
+	_, err = repository.CreateWork(ctx, domain.Work{ID: uuid.New(), Title: "Dune"}, []uuid.UUID{edition.ID, uuid.New()})
+	require.ErrorIs(t, err, intErr.ErrBadRequest)
+
+	work, err := repository.CreateWork(ctx, domain.Work{ID: uuid.New(), Title: "Dune"}, []uuid.UUID{edition.ID})
+	require.NoError(t, err)
+
+	editions, err := repository.List(ctx, domain.ListFilter{WorkID: &work.ID})
+	require.NoError(t, err)
+	require.Len(t, editions, 1)
+	require.Equal(t, work.ID, *editions[0].WorkID)
+	require.Equal(t, hardcover, *editions[0].Format)
+	require.Equal(t, publisher, *editions[0].Publisher)
+
+	require.NoError(t, repository.DeleteWork(ctx, work.ID))
+	got, err := repository.Get(ctx, edition.ID)
+	require.NoError(t, err)
+	require.Nil(t, got.WorkID)
+}
+
+func TestPgRepository_BranchManagedCopies(t *testing.T) {
+	repository, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	book := defaultBook
+	_, err := repository.Create(ctx, book)
+	require.NoError(t, err)
+
+	central, eastside := uuid.New(), uuid.New()
+	_, err = repository.dbPool.Exec(ctx, `INSERT INTO branches (id, code, name) VALUES ($1, 'central', 'Central'), ($2, 'eastside', 'Eastside');`, central, eastside)
+	require.NoError(t, err)
+	_, err = repository.dbPool.Exec(ctx, `INSERT INTO branch_holdings (book_id, branch_id, copies_total, copies_available) VALUES ($1, $2, 2, 2), ($1, $3, 1, 0);`, book.ID, central, eastside)
+	require.NoError(t, err)
+
+	for branchID, expected := range map[uuid.UUID]int{central: 1, eastside: 0} {
+		books, err := repository.List(ctx, domain.ListFilter{AvailableAt: &branchID})
+		require.NoError(t, err)
+		require.Len(t, books, expected)
+	}
+
+	book.CopiesTotal++
+	_, err = repository.Update(ctx, book)
+	require.ErrorIs(t, err, intErr.ErrConflict)
+
+	book.CopiesTotal--
+	book.Title = "Renamed"
+	_, err = repository.Update(ctx, book)
+	require.NoError(t, err)
+}
+
+func TestPgRepository_AdjustCopies(t *testing.T) {
+	repository, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	book := defaultBook
+	_, err := repository.Create(ctx, book)
+	require.NoError(t, err)
+
+	adjusted, err := repository.AdjustCopies(ctx, domain.CopyAdjustment{
+		ID: uuid.New(), BookID: book.ID, AvailableDelta: -book.CopiesAvailable, Reason: domain.CopyAdjustmentCheckedOut,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 0, adjusted.CopiesAvailable)
+	require.Equal(t, book.CopiesTotal, adjusted.CopiesTotal)
+
+	_, err = repository.AdjustCopies(ctx, domain.CopyAdjustment{ID: uuid.New(), BookID: book.ID, AvailableDelta: -1, Reason: domain.CopyAdjustmentCheckedOut})
+	require.ErrorIs(t, err, intErr.ErrConflict)
+
+	_, err = repository.AdjustCopies(ctx, domain.CopyAdjustment{ID: uuid.New(), BookID: uuid.New(), TotalDelta: 1, Reason: domain.CopyAdjustmentAcquired})
+	require.ErrorIs(t, err, intErr.ErrNotFound)
+
+	var entries int
+	require.NoError(t, repository.dbPool.QueryRow(ctx, `SELECT count(*) FROM inventory_ledger WHERE book_id=$1;`, book.ID).Scan(&entries))
+	require.Equal(t, 1, entries)
+}
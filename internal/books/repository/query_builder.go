@@ -13,7 +13,7 @@ func buildListQuery(filter domain.ListFilter) (query string, args []any) {
 	queryArgs := buildQueryArguments(filter)
 
 	baseQuery := `
-SELECT id, title, author, isbn, published_year, copies_total, copies_available, created_at, updated_at
+SELECT ` + bookColumns + `
 FROM books`
 
 	if len(whereConditions) > 0 {
@@ -53,6 +53,38 @@ func buildWhereConditions(filter domain.ListFilter) []string {
 		placeholderIndex++
 	}
 
+	if filter.AuthorID != nil {
+		conditions = append(conditions, fmt.Sprintf("id IN (SELECT book_id FROM book_authors WHERE author_id = $%d)", placeholderIndex))
+		placeholderIndex++
+	}
+
+	if filter.SubjectID != nil {
+		conditions = append(conditions, fmt.Sprintf(`id IN (SELECT book_id FROM book_subjects WHERE subject_id IN (
+	WITH RECURSIVE subject_tree AS (
+		SELECT id FROM subjects WHERE id = $%d
+		UNION ALL
+		SELECT subjects.id FROM subjects JOIN subject_tree ON subjects.parent_id = subject_tree.id
+	)
+	SELECT id FROM subject_tree
+))`, placeholderIndex))
+		placeholderIndex++
+	}
+
+	if filter.Tag != nil && *filter.Tag != "" {
+		conditions = append(conditions, fmt.Sprintf("id IN (SELECT book_id FROM book_tags WHERE tag = $%d)", placeholderIndex))
+		placeholderIndex++
+	}
+
+	if filter.WorkID != nil {
+		conditions = append(conditions, fmt.Sprintf("work_id = $%d", placeholderIndex))
+		placeholderIndex++
+	}
+
+	if filter.AvailableAt != nil {
+		conditions = append(conditions, fmt.Sprintf("id IN (SELECT book_id FROM branch_holdings WHERE branch_id = $%d AND copies_available > 0)", placeholderIndex))
+		placeholderIndex++
+	}
+
 	return conditions
 }
 
@@ -72,5 +104,25 @@ func buildQueryArguments(filter domain.ListFilter) []any {
 		queryArguments = append(queryArguments, *filter.ISBN)
 	}
 
+	if filter.AuthorID != nil {
+		queryArguments = append(queryArguments, *filter.AuthorID)
+	}
+
+	if filter.SubjectID != nil {
+		queryArguments = append(queryArguments, *filter.SubjectID)
+	}
+
+	if filter.Tag != nil && *filter.Tag != "" {
+		queryArguments = append(queryArguments, *filter.Tag)
+	}
+
+	if filter.WorkID != nil {
+		queryArguments = append(queryArguments, *filter.WorkID)
+	}
+
+	if filter.AvailableAt != nil {
+		queryArguments = append(queryArguments, *filter.AvailableAt)
+	}
+
 	return queryArguments
 }
@@ -13,7 +13,31 @@ import (
 type Repository interface {
 	Create(ctx context.Context, book domain.Book) (domain.Book, error)
 	Get(ctx context.Context, bookID uuid.UUID) (domain.Book, error)
+	// GetMany returns the books with the given IDs in no particular order, leaving out IDs
+	// that do not exist.
+	GetMany(ctx context.Context, bookIDs []uuid.UUID) ([]domain.Book, error)
+	// Update fails with ErrConflict when it changes the copies of a book stocked at branches.
 	Update(ctx context.Context, book domain.Book) (domain.Book, error)
 	Delete(ctx context.Context, bookID uuid.UUID) error
+	// AdjustCopies applies the deltas of adjustment in a single conditional update and records
+	// it in the inventory ledger. It fails with ErrConflict when a count would go negative,
+	// more copies would be available than in total, or the book is stocked at branches.
+	AdjustCopies(ctx context.Context, adjustment domain.CopyAdjustment) (domain.Book, error)
 	List(ctx context.Context, filter domain.ListFilter) ([]domain.Book, error)
+	Stats(ctx context.Context) (domain.CatalogStats, error)
+	// UpsertByISBN creates or updates books keyed by ISBN in a single transaction and returns
+	// one result per book, in order. Rows rejected by the database are reported in their
+	// result without failing the others. Copies of books stocked at branches are kept. With
+	// dryRun the transaction is rolled back.
+	UpsertByISBN(ctx context.Context, books []domain.UpsertBook, dryRun bool) ([]domain.UpsertResult, error)
+	// Export calls each for every book matching filter, reading them through a server-side
+	// cursor over a consistent snapshot so the result set is never held in memory. It stops
+	// at the first error returned by each.
+	Export(ctx context.Context, filter domain.ListFilter, each func(domain.Book) error) error
+	// CreateWork inserts a work and makes the given books editions of it in one transaction.
+	// It fails with ErrBadRequest if any of the books does not exist.
+	CreateWork(ctx context.Context, work domain.Work, bookIDs []uuid.UUID) (domain.Work, error)
+	GetWork(ctx context.Context, workID uuid.UUID) (domain.Work, error)
+	// DeleteWork removes a work; its editions remain as standalone books.
+	DeleteWork(ctx context.Context, workID uuid.UUID) error
 }
@@ -1,7 +1,10 @@
 package service
 
 import (
+	"strings"
+
 	"github.com/bkiran6398/library/internal/books/domain"
+	"github.com/bkiran6398/library/internal/isbn"
 	"github.com/google/uuid"
 )
 
@@ -17,8 +20,15 @@ func mapCreateRequestToBook(request domain.CreateBookRequest) domain.Book {
 		ID:              uuid.New(),
 		Title:           request.Title,
 		Author:          request.Author,
-		ISBN:            request.ISBN,
+		ISBN:            canonicalISBN(request.ISBN),
 		PublishedYear:   request.PublishedYear,
+		CoverURL:        request.CoverURL,
+		WorkID:          request.WorkID,
+		Publisher:       request.Publisher,
+		Edition:         request.Edition,
+		Format:          request.Format,
+		Language:        request.Language,
+		PageCount:       request.PageCount,
 		CopiesTotal:     request.CopiesTotal,
 		CopiesAvailable: copiesAvailable,
 	}
@@ -28,9 +38,39 @@ func mapCreateRequestToBook(request domain.CreateBookRequest) domain.Book {
 func applyUpdateRequestToBook(existingBook domain.Book, updateRequest domain.UpdateBookRequest) domain.Book {
 	existingBook.Title = updateRequest.Title
 	existingBook.Author = updateRequest.Author
-	existingBook.ISBN = updateRequest.ISBN
+	existingBook.ISBN = canonicalISBN(updateRequest.ISBN)
 	existingBook.PublishedYear = updateRequest.PublishedYear
+	existingBook.CoverURL = updateRequest.CoverURL
+	existingBook.WorkID = updateRequest.WorkID
+	existingBook.Publisher = updateRequest.Publisher
+	existingBook.Edition = updateRequest.Edition
+	existingBook.Format = updateRequest.Format
+	existingBook.Language = updateRequest.Language
+	existingBook.PageCount = updateRequest.PageCount
 	existingBook.CopiesTotal = updateRequest.CopiesTotal
 	existingBook.CopiesAvailable = updateRequest.CopiesAvailable
 	return existingBook
 }
+
+// canonicalISBN returns the hyphen-free ISBN-13 form of a validated ISBN. Values that are
+// not valid ISBNs are returned unchanged.
+func canonicalISBN(value string) string {
+	if normalized, err := isbn.Normalize(value); err == nil {
+		return normalized
+	}
+	return value
+}
+
+// normalizeListFilter lets the isbn filter match either form of a valid ISBN, and the tag
+// filter match tags regardless of case and spacing, as they are stored.
+func normalizeListFilter(filter domain.ListFilter) domain.ListFilter {
+	if filter.ISBN != nil {
+		normalized := canonicalISBN(*filter.ISBN)
+		filter.ISBN = &normalized
+	}
+	if filter.Tag != nil {
+		normalized := strings.ToLower(strings.Join(strings.Fields(*filter.Tag), " "))
+		filter.Tag = &normalized
+	}
+	return filter
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/bkiran6398/library/internal/books/codec"
+	"github.com/bkiran6398/library/internal/books/domain"
+	intErr "github.com/bkiran6398/library/internal/errors"
+	"github.com/google/uuid"
+)
+
+const (
+	citeTimeout = 5 * time.Second
+	// maxCitationBatch caps the number of books cited in one request.
+	maxCitationBatch = 100
+)
+
+// citationWriters maps each citation format to its encoder.
+var citationWriters = map[domain.CitationFormat]func(io.Writer, []domain.Book) error{
+	domain.CitationFormatBibTeX:  codec.WriteBibTeX,
+	domain.CitationFormatRIS:     codec.WriteRIS,
+	domain.CitationFormatCSLJSON: codec.WriteCSLJSON,
+}
+
+func (serviceInstance *service) Cite(ctx context.Context, destination io.Writer, bookIDs []uuid.UUID, format domain.CitationFormat) error {
+	writeCitations, ok := citationWriters[format]
+	if !ok {
+		return fmt.Errorf("%w: unsupported citation format %q", intErr.ErrBadRequest, format)
+	}
+	bookIDs = uniqueBookIDs(bookIDs)
+	if len(bookIDs) == 0 {
+		return fmt.Errorf("%w: at least one book ID is required", intErr.ErrBadRequest)
+	}
+	if len(bookIDs) > maxCitationBatch {
+		return fmt.Errorf("%w: at most %d books can be cited at once", intErr.ErrBadRequest, maxCitationBatch)
+	}
+
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, citeTimeout)
+	defer cancel()
+	found, err := serviceInstance.repository.GetMany(ctxWithTimeout, bookIDs)
+	if err != nil {
+		return err
+	}
+
+	booksByID := make(map[uuid.UUID]domain.Book, len(found))
+	for _, book := range found {
+		booksByID[book.ID] = book
+	}
+	books := make([]domain.Book, 0, len(bookIDs))
+	var missing []string
+	for _, bookID := range bookIDs {
+		book, ok := booksByID[bookID]
+		if !ok {
+			missing = append(missing, bookID.String())
+			continue
+		}
+		books = append(books, book)
+	}
+	if len(missing) > 0 {
+		return intErr.ErrNotFound.WithDetails(map[string][]string{"missing_ids": missing})
+	}
+
+	return writeCitations(destination, books)
+}
+
+// uniqueBookIDs drops repeated IDs, keeping the first occurrence of each.
+func uniqueBookIDs(bookIDs []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(bookIDs))
+	unique := make([]uuid.UUID, 0, len(bookIDs))
+	for _, bookID := range bookIDs {
+		if !seen[bookID] {
+			seen[bookID] = true
+			unique = append(unique, bookID)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/bkiran6398/library/internal/books/domain"
+	"github.com/bkiran6398/library/internal/books/repository/mocks"
+	intErr "github.com/bkiran6398/library/internal/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCite_KeepsRequestedOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	service := NewService(mockRepo)
+	books := exportBooks()
+
+	mockRepo.EXPECT().
+		GetMany(gomock.Any(), []uuid.UUID{books[1].ID, books[0].ID}).
+		Return(books, nil).
+		Times(1)
+
+	var output bytes.Buffer
+	err := service.Cite(context.Background(), &output, []uuid.UUID{books[1].ID, books[0].ID, books[1].ID}, domain.CitationFormatRIS)
+	require.NoError(t, err)
+
+	citations := output.String()
+	require.Equal(t, 2, strings.Count(citations, "TY  - BOOK"))
+	require.Less(t, strings.Index(citations, "TI  - Second"), strings.Index(citations, "TI  - First, Book"))
+}
+
+func TestCite_MissingBooks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	service := NewService(mockRepo)
+	books := exportBooks()
+	missingID := uuid.New()
+
+	mockRepo.EXPECT().
+		GetMany(gomock.Any(), gomock.Any()).
+		Return(books[:1], nil).
+		Times(1)
+
+	var output bytes.Buffer
+	err := service.Cite(context.Background(), &output, []uuid.UUID{books[0].ID, missingID}, domain.CitationFormatBibTeX)
+	require.True(t, errors.Is(err, intErr.ErrNotFound))
+	require.Equal(t, map[string][]string{"missing_ids": {missingID.String()}}, intErr.Resolve(err).Details)
+	require.Zero(t, output.Len())
+}
+
+func TestCite_InvalidRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := NewService(mocks.NewMockRepository(ctrl))
+
+	err := service.Cite(context.Background(), &bytes.Buffer{}, []uuid.UUID{uuid.New()}, "apa")
+	require.True(t, errors.Is(err, intErr.ErrBadRequest))
+
+	err = service.Cite(context.Background(), &bytes.Buffer{}, nil, domain.CitationFormatBibTeX)
+	require.True(t, errors.Is(err, intErr.ErrBadRequest))
+
+	tooMany := make([]uuid.UUID, maxCitationBatch+1)
+	for index := range tooMany {
+		tooMany[index] = uuid.New()
+	}
+	err = service.Cite(context.Background(), &bytes.Buffer{}, tooMany, domain.CitationFormatBibTeX)
+	require.True(t, errors.Is(err, intErr.ErrBadRequest))
+}
@@ -0,0 +1,26 @@
+package service
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/bkiran6398/library/internal/books/domain"
+)
+
+// exportTimeout bounds a whole export, which may cover the entire catalog.
+const exportTimeout = 30 * time.Minute
+
+func (serviceInstance *service) Export(ctx context.Context, destination io.Writer, filter domain.ListFilter, format domain.ExportFormat) error {
+	writer, err := newExportWriter(destination, format)
+	if err != nil {
+		return err
+	}
+
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, exportTimeout)
+	defer cancel()
+	if err := serviceInstance.repository.Export(ctxWithTimeout, normalizeListFilter(filter), writer.Write); err != nil {
+		return err
+	}
+	return writer.Close()
+}
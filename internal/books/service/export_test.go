@@ -0,0 +1,175 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bkiran6398/library/internal/books/domain"
+	"github.com/bkiran6398/library/internal/books/repository/mocks"
+	intErr "github.com/bkiran6398/library/internal/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func exportBooks() []domain.Book {
+	year, pageCount := 1999, 320
+	coverURL, publisher, edition, language := "https://covers.example/1.jpg", "Plenum", "2nd", "en"
+	format := domain.BookFormatPaperback
+	workID := uuid.MustParse("8f14e45f-ceea-467f-a0e6-2b8b5f7e1c3d")
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	return []domain.Book{
+		{
+			ID: uuid.New(), Title: "First, Book", Author: "A", ISBN: "9780306406157", PublishedYear: &year,
+			CoverURL: &coverURL, WorkID: &workID, Publisher: &publisher, Edition: &edition, Format: &format,
+			Language: &language, PageCount: &pageCount, CopiesTotal: 2, CopiesAvailable: 1, CreatedAt: createdAt, UpdatedAt: createdAt,
+		},
+		{ID: uuid.New(), Title: "Second", Author: "B", ISBN: "9780134190440", CopiesTotal: 1, CopiesAvailable: 1, CreatedAt: createdAt, UpdatedAt: createdAt},
+	}
+}
+
+func expectExport(mockRepo *mocks.MockRepository, filter domain.ListFilter, books []domain.Book) {
+	mockRepo.EXPECT().
+		Export(gomock.Any(), filter, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, filter domain.ListFilter, each func(domain.Book) error) error {
+			for _, book := range books {
+				if err := each(book); err != nil {
+					return err
+				}
+			}
+			return nil
+		}).
+		Times(1)
+}
+
+func TestExport_CSV(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	service := NewService(mockRepo)
+	books := exportBooks()
+	author := "A"
+	filter := domain.ListFilter{Author: &author}
+	expectExport(mockRepo, filter, books)
+
+	var output bytes.Buffer
+	require.NoError(t, service.Export(context.Background(), &output, filter, domain.ExportFormatCSV))
+
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	require.Len(t, lines, 3)
+	require.Equal(t, "id,title,author,isbn,published_year,cover_url,work_id,publisher,edition,format,language,page_count,copies_total,copies_available,created_at,updated_at", lines[0])
+	require.Equal(t, books[0].ID.String()+`,"First, Book",A,9780306406157,1999,https://covers.example/1.jpg,8f14e45f-ceea-467f-a0e6-2b8b5f7e1c3d,Plenum,2nd,paperback,en,320,2,1,2025-01-02T03:04:05Z,2025-01-02T03:04:05Z`, lines[1])
+	require.Equal(t, books[1].ID.String()+`,Second,B,9780134190440,,,,,,,,,1,1,2025-01-02T03:04:05Z,2025-01-02T03:04:05Z`, lines[2])
+}
+
+func TestExport_CSVRoundTripsThroughImport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	service := NewService(mockRepo)
+	books := exportBooks()
+	expectExport(mockRepo, domain.ListFilter{}, books)
+
+	var output bytes.Buffer
+	require.NoError(t, service.Export(context.Background(), &output, domain.ListFilter{}, domain.ExportFormatCSV))
+
+	mockRepo.EXPECT().
+		UpsertByISBN(gomock.Any(), gomock.Len(2), true).
+		DoAndReturn(func(ctx context.Context, upserts []domain.UpsertBook, dryRun bool) ([]domain.UpsertResult, error) {
+			imported, exported := upserts[0].Book, books[0]
+			require.Equal(t, exported.CoverURL, imported.CoverURL)
+			require.Equal(t, exported.WorkID, imported.WorkID)
+			require.Equal(t, exported.Publisher, imported.Publisher)
+			require.Equal(t, exported.Edition, imported.Edition)
+			require.Equal(t, exported.Format, imported.Format)
+			require.Equal(t, exported.Language, imported.Language)
+			require.Equal(t, exported.PageCount, imported.PageCount)
+			require.Nil(t, upserts[1].Book.WorkID)
+			require.Nil(t, upserts[1].Book.Format)
+			return []domain.UpsertResult{{ID: uuid.New()}, {ID: uuid.New()}}, nil
+		}).
+		Times(1)
+	report, err := service.Import(context.Background(), &output, domain.ImportOptions{Format: domain.ImportFormatCSV, DryRun: true})
+	require.NoError(t, err)
+	require.Equal(t, 2, report.Updated)
+}
+
+func TestExport_JSONAndNDJSON(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	service := NewService(mockRepo)
+	books := exportBooks()
+
+	expectExport(mockRepo, domain.ListFilter{}, books)
+	var jsonOutput bytes.Buffer
+	require.NoError(t, service.Export(context.Background(), &jsonOutput, domain.ListFilter{}, domain.ExportFormatJSON))
+	var decoded []domain.Book
+	require.NoError(t, json.Unmarshal(jsonOutput.Bytes(), &decoded))
+	require.Len(t, decoded, 2)
+	require.Equal(t, books[1].ID, decoded[1].ID)
+
+	expectExport(mockRepo, domain.ListFilter{}, books)
+	var ndjsonOutput bytes.Buffer
+	require.NoError(t, service.Export(context.Background(), &ndjsonOutput, domain.ListFilter{}, domain.ExportFormatNDJSON))
+	require.Len(t, strings.Split(strings.TrimSpace(ndjsonOutput.String()), "\n"), 2)
+
+	expectExport(mockRepo, domain.ListFilter{}, nil)
+	var emptyOutput bytes.Buffer
+	require.NoError(t, service.Export(context.Background(), &emptyOutput, domain.ListFilter{}, domain.ExportFormatJSON))
+	require.Equal(t, "[]\n", emptyOutput.String())
+}
+
+func TestExport_MARCRoundTripsThroughImport(t *testing.T) {
+	formats := map[domain.ExportFormat]domain.ImportFormat{
+		domain.ExportFormatMARC:    domain.ImportFormatMARC,
+		domain.ExportFormatMARCXML: domain.ImportFormatMARCXML,
+	}
+	for exportFormat, importFormat := range formats {
+		t.Run(string(exportFormat), func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockRepository(ctrl)
+			service := NewService(mockRepo)
+			books := exportBooks()
+			expectExport(mockRepo, domain.ListFilter{}, books)
+
+			var output bytes.Buffer
+			require.NoError(t, service.Export(context.Background(), &output, domain.ListFilter{}, exportFormat))
+
+			mockRepo.EXPECT().
+				UpsertByISBN(gomock.Any(), gomock.Any(), false).
+				DoAndReturn(func(ctx context.Context, upserts []domain.UpsertBook, dryRun bool) ([]domain.UpsertResult, error) {
+					require.Len(t, upserts, 2)
+					require.Equal(t, "First, Book", upserts[0].Book.Title)
+					require.Equal(t, "9780306406157", upserts[0].Book.ISBN)
+					require.Equal(t, 1999, *upserts[0].Book.PublishedYear)
+					require.True(t, upserts[0].KeepCopies)
+					require.Equal(t, "B", upserts[1].Book.Author)
+					require.Nil(t, upserts[1].Book.PublishedYear)
+					return []domain.UpsertResult{{ID: books[0].ID}, {ID: books[1].ID}}, nil
+				}).
+				Times(1)
+			report, err := service.Import(context.Background(), &output, domain.ImportOptions{Format: importFormat})
+			require.NoError(t, err)
+			require.Equal(t, 2, report.Updated)
+		})
+	}
+}
+
+func TestExport_UnsupportedFormat(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := NewService(mocks.NewMockRepository(ctrl))
+	err := service.Export(context.Background(), &bytes.Buffer{}, domain.ListFilter{}, "xml")
+	require.ErrorIs(t, err, intErr.ErrBadRequest)
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/bkiran6398/library/internal/books/codec"
+	"github.com/bkiran6398/library/internal/books/domain"
+	intErr "github.com/bkiran6398/library/internal/errors"
+	"github.com/bkiran6398/library/internal/marc"
+)
+
+// exportWriter encodes books one at a time. Close finishes the document and flushes it.
+type exportWriter interface {
+	Write(book domain.Book) error
+	Close() error
+}
+
+// newExportWriter returns an encoder for format writing to destination.
+func newExportWriter(destination io.Writer, format domain.ExportFormat) (exportWriter, error) {
+	switch format {
+	case domain.ExportFormatCSV:
+		return &csvExportWriter{writer: csv.NewWriter(destination)}, nil
+	case domain.ExportFormatNDJSON:
+		return &jsonExportWriter{destination: destination, encoder: json.NewEncoder(destination)}, nil
+	case domain.ExportFormatJSON:
+		return &jsonExportWriter{destination: destination, encoder: json.NewEncoder(destination), array: true}, nil
+	case domain.ExportFormatMARC:
+		return &marcExportWriter{writer: marc.NewWriter(destination)}, nil
+	case domain.ExportFormatMARCXML:
+		return &marcXMLExportWriter{writer: marc.NewXMLWriter(destination)}, nil
+	default:
+		return nil, fmt.Errorf("%w: unsupported export format %q", intErr.ErrBadRequest, format)
+	}
+}
+
+// exportCSVHeader uses the import column names, so an export can be imported again.
+var exportCSVHeader = []string{
+	"id", "title", "author", "isbn", "published_year", "cover_url", "work_id", "publisher", "edition",
+	"format", "language", "page_count", "copies_total", "copies_available", "created_at", "updated_at",
+}
+
+type csvExportWriter struct {
+	writer        *csv.Writer
+	headerWritten bool
+}
+
+func (csvWriter *csvExportWriter) Write(book domain.Book) error {
+	if err := csvWriter.writeHeader(); err != nil {
+		return err
+	}
+	workID := ""
+	if book.WorkID != nil {
+		workID = book.WorkID.String()
+	}
+	format := ""
+	if book.Format != nil {
+		format = string(*book.Format)
+	}
+	return csvWriter.writer.Write([]string{
+		book.ID.String(),
+		book.Title,
+		book.Author,
+		book.ISBN,
+		optionalIntCell(book.PublishedYear),
+		optionalStringCell(book.CoverURL),
+		workID,
+		optionalStringCell(book.Publisher),
+		optionalStringCell(book.Edition),
+		format,
+		optionalStringCell(book.Language),
+		optionalIntCell(book.PageCount),
+		strconv.Itoa(book.CopiesTotal),
+		strconv.Itoa(book.CopiesAvailable),
+		book.CreatedAt.UTC().Format(time.RFC3339Nano),
+		book.UpdatedAt.UTC().Format(time.RFC3339Nano),
+	})
+}
+
+// optionalStringCell renders an optional column, leaving it empty when unset.
+func optionalStringCell(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+// optionalIntCell renders an optional numeric column, leaving it empty when unset.
+func optionalIntCell(value *int) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.Itoa(*value)
+}
+
+func (csvWriter *csvExportWriter) writeHeader() error {
+	if csvWriter.headerWritten {
+		return nil
+	}
+	csvWriter.headerWritten = true
+	return csvWriter.writer.Write(exportCSVHeader)
+}
+
+func (csvWriter *csvExportWriter) Close() error {
+	if err := csvWriter.writeHeader(); err != nil {
+		return err
+	}
+	csvWriter.writer.Flush()
+	return csvWriter.writer.Error()
+}
+
+// jsonExportWriter writes one JSON document per line, or a single array when array is set.
+type jsonExportWriter struct {
+	destination io.Writer
+	encoder     *json.Encoder
+	array       bool
+	count       int
+}
+
+func (jsonWriter *jsonExportWriter) Write(book domain.Book) error {
+	if jsonWriter.array {
+		separator := ","
+		if jsonWriter.count == 0 {
+			separator = "["
+		}
+		if _, err := io.WriteString(jsonWriter.destination, separator); err != nil {
+			return err
+		}
+	}
+	jsonWriter.count++
+	return jsonWriter.encoder.Encode(book)
+}
+
+func (jsonWriter *jsonExportWriter) Close() error {
+	if !jsonWriter.array {
+		return nil
+	}
+	closing := "]\n"
+	if jsonWriter.count == 0 {
+		closing = "[]\n"
+	}
+	_, err := io.WriteString(jsonWriter.destination, closing)
+	return err
+}
+
+type marcExportWriter struct {
+	writer *marc.Writer
+}
+
+func (marcWriter *marcExportWriter) Write(book domain.Book) error {
+	return marcWriter.writer.Write(codec.MARCRecord(book))
+}
+
+func (marcWriter *marcExportWriter) Close() error {
+	return nil
+}
+
+type marcXMLExportWriter struct {
+	writer *marc.XMLWriter
+}
+
+func (marcXMLWriter *marcXMLExportWriter) Write(book domain.Book) error {
+	return marcXMLWriter.writer.Write(codec.MARCRecord(book))
+}
+
+func (marcXMLWriter *marcXMLExportWriter) Close() error {
+	return marcXMLWriter.writer.Close()
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/bkiran6398/library/internal/books/domain"
+	intErr "github.com/bkiran6398/library/internal/errors"
+)
+
+const (
+	importTimeout = 5 * time.Minute
+	// maxImportRows bounds a single import, which is applied in one transaction.
+	maxImportRows = 50_000
+)
+
+// pendingUpsert is a validated row waiting to be written, with its index in the report.
+type pendingUpsert struct {
+	reportIndex int
+	upsert      domain.UpsertBook
+}
+
+func (serviceInstance *service) Import(ctx context.Context, source io.Reader, options domain.ImportOptions) (domain.ImportReport, error) {
+	reader, err := newImportReader(source, options.Format)
+	if err != nil {
+		return domain.ImportReport{}, err
+	}
+
+	report := domain.ImportReport{DryRun: options.DryRun, Rows: []domain.ImportRowResult{}}
+	var pending []pendingUpsert
+	for {
+		row, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return domain.ImportReport{}, err
+		}
+		if len(report.Rows) == maxImportRows {
+			return domain.ImportReport{}, fmt.Errorf("%w: imports are limited to %d rows", intErr.ErrBadRequest, maxImportRows)
+		}
+
+		result := domain.ImportRowResult{Line: row.line, ISBN: row.request.ISBN}
+		if row.err == nil {
+			row.err = serviceInstance.validateImportRow(row.request)
+		}
+		if row.err != nil {
+			result.Status, result.Error = domain.ImportRowFailed, row.err.Error()
+		} else {
+			pending = append(pending, pendingUpsert{
+				reportIndex: len(report.Rows),
+				upsert: domain.UpsertBook{
+					Book:             mapCreateRequestToBook(row.request),
+					KeepCopiesOnLoan: row.request.CopiesAvailable == nil,
+					KeepCopies:       row.keepCopies,
+				},
+			})
+		}
+		report.Rows = append(report.Rows, result)
+	}
+
+	if err := serviceInstance.applyImport(ctx, pending, &report); err != nil {
+		return domain.ImportReport{}, err
+	}
+	summarizeImport(&report)
+	return report, nil
+}
+
+// validateImportRow applies the same rules as Create.
+func (serviceInstance *service) validateImportRow(request domain.CreateBookRequest) error {
+	if err := validateCreateRequest(serviceInstance.validator, request); err != nil {
+		return err
+	}
+	book := mapCreateRequestToBook(request)
+	return validateCopiesAvailable(book.CopiesAvailable, book.CopiesTotal)
+}
+
+// applyImport upserts the validated rows and records each outcome in the report.
+func (serviceInstance *service) applyImport(ctx context.Context, pending []pendingUpsert, report *domain.ImportReport) error {
+	if len(pending) == 0 {
+		return nil
+	}
+
+	upserts := make([]domain.UpsertBook, len(pending))
+	for index, row := range pending {
+		upserts[index] = row.upsert
+	}
+
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, importTimeout)
+	defer cancel()
+	results, err := serviceInstance.repository.UpsertByISBN(ctxWithTimeout, upserts, report.DryRun)
+	if err != nil {
+		return err
+	}
+
+	for index, upsertResult := range results {
+		row := &report.Rows[pending[index].reportIndex]
+		switch {
+		case upsertResult.Err != nil:
+			row.Status, row.Error = domain.ImportRowFailed, upsertResult.Err.Error()
+		case upsertResult.Created:
+			row.Status, row.BookID = domain.ImportRowCreated, &upsertResult.ID
+		default:
+			row.Status, row.BookID = domain.ImportRowUpdated, &upsertResult.ID
+		}
+	}
+	return nil
+}
+
+// summarizeImport counts the row outcomes.
+func summarizeImport(report *domain.ImportReport) {
+	for _, row := range report.Rows {
+		switch row.Status {
+		case domain.ImportRowCreated:
+			report.Created++
+		case domain.ImportRowUpdated:
+			report.Updated++
+		case domain.ImportRowFailed:
+			report.Failed++
+		}
+	}
+}
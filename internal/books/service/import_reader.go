@@ -0,0 +1,243 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bkiran6398/library/internal/books/codec"
+	"github.com/bkiran6398/library/internal/books/domain"
+	intErr "github.com/bkiran6398/library/internal/errors"
+	"github.com/bkiran6398/library/internal/marc"
+	"github.com/google/uuid"
+)
+
+// maxImportLineBytes bounds a single NDJSON line.
+const maxImportLineBytes = 1 << 20
+
+// importRow is one decoded row of an import stream. Err is set when the row could not be
+// decoded; the stream itself is still readable.
+type importRow struct {
+	line    int
+	request domain.CreateBookRequest
+	// keepCopies is set for sources without holdings; see domain.UpsertBook.
+	keepCopies bool
+	err        error
+}
+
+// importReader decodes import rows one at a time; Next returns io.EOF after the last row.
+type importReader interface {
+	Next() (importRow, error)
+}
+
+// newImportReader returns a reader for format. It fails with ErrBadRequest when the stream
+// cannot be imported at all, such as a CSV header missing a required column.
+func newImportReader(source io.Reader, format domain.ImportFormat) (importReader, error) {
+	switch format {
+	case domain.ImportFormatCSV:
+		return newCSVImportReader(source)
+	case domain.ImportFormatNDJSON:
+		scanner := bufio.NewScanner(source)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxImportLineBytes)
+		return &ndjsonImportReader{scanner: scanner}, nil
+	case domain.ImportFormatMARC:
+		return &marcImportReader{records: marc.NewReader(source)}, nil
+	case domain.ImportFormatMARCXML:
+		return &marcImportReader{records: marc.NewXMLReader(source)}, nil
+	default:
+		return nil, fmt.Errorf("%w: unsupported import format %q", intErr.ErrBadRequest, format)
+	}
+}
+
+// csvColumns are the recognised CSV header names. id, created_at and updated_at are
+// accepted so an export can be imported again, but they are ignored.
+var csvColumns = []string{
+	"id", "title", "author", "isbn", "published_year", "cover_url", "work_id", "publisher", "edition",
+	"format", "language", "page_count", "copies_total", "copies_available", "created_at", "updated_at",
+}
+
+var requiredCSVColumns = []string{"title", "author", "isbn", "copies_total"}
+
+type csvImportReader struct {
+	reader  *csv.Reader
+	columns map[string]int
+}
+
+func newCSVImportReader(source io.Reader) (*csvImportReader, error) {
+	reader := csv.NewReader(source)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: read csv header: %w", intErr.ErrBadRequest, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for index, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if !slices.Contains(csvColumns, name) {
+			return nil, fmt.Errorf("%w: unknown csv column %q", intErr.ErrBadRequest, name)
+		}
+		columns[name] = index
+	}
+	for _, name := range requiredCSVColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("%w: csv header is missing column %q", intErr.ErrBadRequest, name)
+		}
+	}
+
+	return &csvImportReader{reader: reader, columns: columns}, nil
+}
+
+func (csvReader *csvImportReader) Next() (importRow, error) {
+	record, err := csvReader.reader.Read()
+	if errors.Is(err, io.EOF) {
+		return importRow{}, io.EOF
+	}
+	var parseError *csv.ParseError
+	if errors.As(err, &parseError) {
+		return importRow{line: parseError.StartLine, err: err}, nil
+	}
+	if err != nil {
+		return importRow{}, fmt.Errorf("read csv: %w", err)
+	}
+
+	line, _ := csvReader.reader.FieldPos(0)
+	row := importRow{line: line}
+	row.request, row.err = csvReader.parseRecord(record)
+	return row, nil
+}
+
+// parseRecord maps a CSV record to a create request by header name.
+func (csvReader *csvImportReader) parseRecord(record []string) (domain.CreateBookRequest, error) {
+	field := func(name string) string {
+		index, ok := csvReader.columns[name]
+		if !ok || index >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[index])
+	}
+
+	request := domain.CreateBookRequest{
+		Title:     field("title"),
+		Author:    field("author"),
+		ISBN:      field("isbn"),
+		CoverURL:  optionalString(field("cover_url")),
+		Publisher: optionalString(field("publisher")),
+		Edition:   optionalString(field("edition")),
+		Language:  optionalString(field("language")),
+	}
+	if format := field("format"); format != "" {
+		bookFormat := domain.BookFormat(format)
+		request.Format = &bookFormat
+	}
+
+	var err error
+	if request.PublishedYear, err = parseOptionalInt("published_year", field("published_year")); err != nil {
+		return request, err
+	}
+	if request.PageCount, err = parseOptionalInt("page_count", field("page_count")); err != nil {
+		return request, err
+	}
+	if workID := field("work_id"); workID != "" {
+		parsed, err := uuid.Parse(workID)
+		if err != nil {
+			return request, fmt.Errorf("invalid work_id %q", workID)
+		}
+		request.WorkID = &parsed
+	}
+	if request.CopiesAvailable, err = parseOptionalInt("copies_available", field("copies_available")); err != nil {
+		return request, err
+	}
+	copiesTotal, err := parseOptionalInt("copies_total", field("copies_total"))
+	if err != nil {
+		return request, err
+	}
+	if copiesTotal == nil {
+		return request, errors.New("copies_total is required")
+	}
+	request.CopiesTotal = *copiesTotal
+	return request, nil
+}
+
+// optionalString returns nil for an empty column value.
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// parseOptionalInt parses an integer column, returning nil for an empty value.
+func parseOptionalInt(column, value string) (*int, error) {
+	if value == "" {
+		return nil, nil
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q", column, value)
+	}
+	return &number, nil
+}
+
+type ndjsonImportReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func (ndjsonReader *ndjsonImportReader) Next() (importRow, error) {
+	for ndjsonReader.scanner.Scan() {
+		ndjsonReader.line++
+		content := bytes.TrimSpace(ndjsonReader.scanner.Bytes())
+		if len(content) == 0 {
+			continue
+		}
+
+		row := importRow{line: ndjsonReader.line}
+		decoder := json.NewDecoder(bytes.NewReader(content))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&row.request); err != nil {
+			row.err = fmt.Errorf("invalid JSON: %v", err)
+		}
+		return row, nil
+	}
+	if err := ndjsonReader.scanner.Err(); err != nil {
+		return importRow{}, fmt.Errorf("%w: read ndjson: %w", intErr.ErrBadRequest, err)
+	}
+	return importRow{}, io.EOF
+}
+
+// marcImportReader maps binary MARC 21 or MARCXML records to rows, numbering them by position.
+type marcImportReader struct {
+	records interface {
+		Read() (marc.Record, error)
+	}
+	position int
+}
+
+func (marcReader *marcImportReader) Next() (importRow, error) {
+	record, err := marcReader.records.Read()
+	if errors.Is(err, io.EOF) {
+		return importRow{}, io.EOF
+	}
+	marcReader.position++
+	row := importRow{line: marcReader.position, keepCopies: true}
+	if errors.Is(err, marc.ErrMalformed) {
+		row.err = err
+		return row, nil
+	}
+	if err != nil {
+		return importRow{}, fmt.Errorf("%w: %w", intErr.ErrBadRequest, err)
+	}
+
+	row.request, row.err = codec.BookFromMARC(record)
+	return row, nil
+}
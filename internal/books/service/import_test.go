@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/bkiran6398/library/internal/books/domain"
+	"github.com/bkiran6398/library/internal/books/repository/mocks"
+	intErr "github.com/bkiran6398/library/internal/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestImport_CSV(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	service := NewService(mockRepo)
+
+	source := strings.NewReader(`isbn,title,author,copies_total,copies_available,published_year
+0-306-40615-2,New Book,Author,3,,2001
+9780134190440,,Author,1,,
+978-1-4493-7332-0,Existing Book,Author,2,1,
+9780262510875,Taken Book,Author,1,,
+9780135957059,Bad Count,Author,x,,
+`)
+	createdID, updatedID := uuid.New(), uuid.New()
+
+	mockRepo.EXPECT().
+		UpsertByISBN(gomock.Any(), gomock.Any(), true).
+		DoAndReturn(func(ctx context.Context, books []domain.UpsertBook, dryRun bool) ([]domain.UpsertResult, error) {
+			require.Len(t, books, 3)
+			require.Equal(t, "9780306406157", books[0].Book.ISBN)
+			require.Equal(t, 3, books[0].Book.CopiesAvailable)
+			require.True(t, books[0].KeepCopiesOnLoan)
+			require.Equal(t, 2001, *books[0].Book.PublishedYear)
+			require.Equal(t, "9781449373320", books[1].Book.ISBN)
+			require.Equal(t, 1, books[1].Book.CopiesAvailable)
+			require.False(t, books[1].KeepCopiesOnLoan)
+			return []domain.UpsertResult{
+				{ID: createdID, Created: true},
+				{ID: updatedID},
+				{Err: intErr.ErrConflict},
+			}, nil
+		}).
+		Times(1)
+
+	report, err := service.Import(context.Background(), source, domain.ImportOptions{Format: domain.ImportFormatCSV, DryRun: true})
+	require.NoError(t, err)
+	require.True(t, report.DryRun)
+	require.Equal(t, 1, report.Created)
+	require.Equal(t, 1, report.Updated)
+	require.Equal(t, 3, report.Failed)
+	require.Len(t, report.Rows, 5)
+
+	require.Equal(t, domain.ImportRowResult{Line: 2, ISBN: "0-306-40615-2", Status: domain.ImportRowCreated, BookID: &createdID}, report.Rows[0])
+	require.Equal(t, 3, report.Rows[1].Line)
+	require.Equal(t, domain.ImportRowFailed, report.Rows[1].Status)
+	require.Contains(t, report.Rows[1].Error, "Title")
+	require.Equal(t, domain.ImportRowUpdated, report.Rows[2].Status)
+	require.Equal(t, &updatedID, report.Rows[2].BookID)
+	require.Equal(t, domain.ImportRowFailed, report.Rows[3].Status)
+	require.Contains(t, report.Rows[4].Error, "invalid copies_total")
+}
+
+func TestImport_CSVEditionColumns(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	service := NewService(mockRepo)
+
+	source := strings.NewReader(`isbn,title,author,copies_total,cover_url,work_id,publisher,edition,format,language,page_count
+9780306406157,Edition,Author,1,https://covers.example/1.jpg,8f14e45f-ceea-467f-a0e6-2b8b5f7e1c3d,Plenum,2nd,ebook,en,320
+9780134190440,Bad Work,Author,1,,not-a-uuid,,,,,
+9781449373320,Bad Pages,Author,1,,,,,,,many
+`)
+
+	mockRepo.EXPECT().
+		UpsertByISBN(gomock.Any(), gomock.Len(1), true).
+		DoAndReturn(func(ctx context.Context, books []domain.UpsertBook, dryRun bool) ([]domain.UpsertResult, error) {
+			book := books[0].Book
+			require.Equal(t, "https://covers.example/1.jpg", *book.CoverURL)
+			require.Equal(t, uuid.MustParse("8f14e45f-ceea-467f-a0e6-2b8b5f7e1c3d"), *book.WorkID)
+			require.Equal(t, "Plenum", *book.Publisher)
+			require.Equal(t, "2nd", *book.Edition)
+			require.Equal(t, domain.BookFormatEbook, *book.Format)
+			require.Equal(t, "en", *book.Language)
+			require.Equal(t, 320, *book.PageCount)
+			return []domain.UpsertResult{{ID: uuid.New(), Created: true}}, nil
+		}).
+		Times(1)
+
+	report, err := service.Import(context.Background(), source, domain.ImportOptions{Format: domain.ImportFormatCSV, DryRun: true})
+	require.NoError(t, err)
+	require.Equal(t, 1, report.Created)
+	require.Contains(t, report.Rows[1].Error, "invalid work_id")
+	require.Contains(t, report.Rows[2].Error, "invalid page_count")
+}
+
+func TestImport_NDJSON(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	service := NewService(mockRepo)
+
+	source := strings.NewReader(`{"title":"T","author":"A","isbn":"9780306406157","copies_total":2}
+
+{"title":"T","author":"A","isbn":"9780134190440","copies_total":1,"copies_available":5}
+not json
+`)
+
+	mockRepo.EXPECT().
+		UpsertByISBN(gomock.Any(), gomock.Len(1), false).
+		Return([]domain.UpsertResult{{ID: uuid.New(), Created: true}}, nil).
+		Times(1)
+
+	report, err := service.Import(context.Background(), source, domain.ImportOptions{Format: domain.ImportFormatNDJSON})
+	require.NoError(t, err)
+	require.Equal(t, 1, report.Created)
+	require.Equal(t, 2, report.Failed)
+	require.Equal(t, []int{1, 3, 4}, []int{report.Rows[0].Line, report.Rows[1].Line, report.Rows[2].Line})
+	require.Contains(t, report.Rows[1].Error, "copies_available")
+	require.Contains(t, report.Rows[2].Error, "invalid JSON")
+}
+
+func TestImport_InvalidStream(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := NewService(mocks.NewMockRepository(ctrl))
+
+	_, err := service.Import(context.Background(), strings.NewReader("title,author\n"), domain.ImportOptions{Format: domain.ImportFormatCSV})
+	require.True(t, errors.Is(err, intErr.ErrBadRequest))
+
+	_, err = service.Import(context.Background(), strings.NewReader(""), domain.ImportOptions{Format: "xml"})
+	require.True(t, errors.Is(err, intErr.ErrBadRequest))
+}
+
+func TestImport_MARCXMLReportsUnmappableRecords(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	service := NewService(mockRepo)
+
+	source := strings.NewReader(`<collection xmlns="http://www.loc.gov/MARC21/slim">
+<record><leader>00000nam a2200000 i 4500</leader>
+<datafield tag="020" ind1=" " ind2=" "><subfield code="a">9780306406157</subfield></datafield>
+<datafield tag="100" ind1="1" ind2=" "><subfield code="a">Author,</subfield></datafield>
+<datafield tag="245" ind1="1" ind2="0"><subfield code="a">Title /</subfield></datafield>
+</record>
+<record><leader>00000nam a2200000 i 4500</leader>
+<datafield tag="245" ind1="1" ind2="0"><subfield code="a">No ISBN</subfield></datafield>
+</record>
+</collection>`)
+
+	mockRepo.EXPECT().
+		UpsertByISBN(gomock.Any(), gomock.Len(1), true).
+		Return([]domain.UpsertResult{{ID: uuid.New(), Created: true}}, nil).
+		Times(1)
+
+	report, err := service.Import(context.Background(), source, domain.ImportOptions{Format: domain.ImportFormatMARCXML, DryRun: true})
+	require.NoError(t, err)
+	require.Equal(t, 1, report.Created)
+	require.Equal(t, 1, report.Failed)
+	require.Equal(t, 2, report.Rows[1].Line)
+	require.Contains(t, report.Rows[1].Error, "020")
+}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bkiran6398/library/internal/books/domain"
+	intErr "github.com/bkiran6398/library/internal/errors"
+	"github.com/google/uuid"
+)
+
+const adjustCopiesTimeout = 5 * time.Second
+
+func (serviceInstance *service) AdjustCopies(ctx context.Context, bookID uuid.UUID, adjustRequest domain.AdjustCopiesRequest) (domain.Book, error) {
+	if err := serviceInstance.validator.Struct(adjustRequest); err != nil {
+		return domain.Book{}, fmt.Errorf("%w: %v", intErr.ErrBadRequest, err)
+	}
+	if adjustRequest.TotalDelta == 0 && adjustRequest.AvailableDelta == 0 {
+		return domain.Book{}, fmt.Errorf("%w: total_delta or available_delta must be non-zero", intErr.ErrBadRequest)
+	}
+
+	adjustment := domain.CopyAdjustment{
+		ID:             uuid.New(),
+		BookID:         bookID,
+		TotalDelta:     adjustRequest.TotalDelta,
+		AvailableDelta: adjustRequest.AvailableDelta,
+		Reason:         adjustRequest.Reason,
+		Note:           adjustRequest.Note,
+	}
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, adjustCopiesTimeout)
+	defer cancel()
+	return serviceInstance.repository.AdjustCopies(ctxWithTimeout, adjustment)
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bkiran6398/library/internal/books/domain"
+	"github.com/bkiran6398/library/internal/books/repository/mocks"
+	intErr "github.com/bkiran6398/library/internal/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAdjustCopies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	service := NewService(mockRepo)
+
+	bookID := uuid.New()
+	note := "water damage"
+	mockRepo.EXPECT().
+		AdjustCopies(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, adjustment domain.CopyAdjustment) (domain.Book, error) {
+			require.NotEqual(t, uuid.Nil, adjustment.ID)
+			require.Equal(t, bookID, adjustment.BookID)
+			require.Equal(t, -1, adjustment.TotalDelta)
+			require.Equal(t, -1, adjustment.AvailableDelta)
+			require.Equal(t, domain.CopyAdjustmentDamaged, adjustment.Reason)
+			require.Equal(t, &note, adjustment.Note)
+			return domain.Book{ID: bookID, CopiesTotal: 2, CopiesAvailable: 1}, nil
+		}).
+		Times(1)
+
+	book, err := service.AdjustCopies(context.Background(), bookID, domain.AdjustCopiesRequest{
+		TotalDelta: -1, AvailableDelta: -1, Reason: domain.CopyAdjustmentDamaged, Note: &note,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, book.CopiesTotal)
+}
+
+func TestAdjustCopies_Invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	service := NewService(mockRepo)
+
+	for _, adjustRequest := range []domain.AdjustCopiesRequest{
+		{TotalDelta: 1, AvailableDelta: 1},
+		{TotalDelta: 1, Reason: "stolen"},
+		{Reason: domain.CopyAdjustmentCorrection},
+	} {
+		_, err := service.AdjustCopies(context.Background(), uuid.New(), adjustRequest)
+		require.ErrorIs(t, err, intErr.ErrBadRequest)
+	}
+}
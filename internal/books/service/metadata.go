@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bkiran6398/library/internal/books/domain"
+	"github.com/bkiran6398/library/internal/books/metadata"
+	intErr "github.com/bkiran6398/library/internal/errors"
+	"github.com/bkiran6398/library/internal/isbn"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// lookupTimeout bounds a metadata lookup, cached or not.
+const lookupTimeout = 5 * time.Second
+
+func (serviceInstance *service) Lookup(ctx context.Context, isbnValue string) (domain.BookMetadata, error) {
+	if serviceInstance.metadataProvider == nil {
+		return domain.BookMetadata{}, intErr.New(intErr.KindUnavailable, "metadata lookup is not configured")
+	}
+	normalized, err := isbn.Normalize(isbnValue)
+	if err != nil {
+		return domain.BookMetadata{}, fmt.Errorf("%w: %v", intErr.ErrBadRequest, err)
+	}
+
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, lookupTimeout)
+	defer cancel()
+	bookMetadata, err := serviceInstance.metadataProvider.Lookup(ctxWithTimeout, normalized)
+	switch {
+	case errors.Is(err, metadata.ErrNotFound):
+		return domain.BookMetadata{}, intErr.ErrNotFound
+	case err != nil:
+		return domain.BookMetadata{}, intErr.Wrap(err, intErr.KindUnavailable, "metadata provider unavailable").WithRetryable(true)
+	}
+	return bookMetadata, nil
+}
+
+// enrich fills the fields createRequest leaves out from the metadata provider. Enrichment is
+// best effort: when the lookup fails the request is returned unchanged and the error is
+// recorded on the current span, so validation reports whatever is still missing.
+func (serviceInstance *service) enrich(ctx context.Context, createRequest domain.CreateBookRequest) domain.CreateBookRequest {
+	complete := createRequest.Title != "" && createRequest.Author != "" && createRequest.PublishedYear != nil && createRequest.CoverURL != nil
+	if complete || !isbn.Valid(createRequest.ISBN) {
+		return createRequest
+	}
+
+	bookMetadata, err := serviceInstance.Lookup(ctx, createRequest.ISBN)
+	if err != nil {
+		if !errors.Is(err, intErr.ErrNotFound) {
+			trace.SpanFromContext(ctx).RecordError(err)
+		}
+		return createRequest
+	}
+
+	if createRequest.Title == "" {
+		createRequest.Title = bookMetadata.Title
+	}
+	if createRequest.Author == "" {
+		createRequest.Author = bookMetadata.Author
+	}
+	if createRequest.PublishedYear == nil {
+		createRequest.PublishedYear = bookMetadata.PublishedYear
+	}
+	if createRequest.CoverURL == nil && bookMetadata.CoverURL != "" {
+		createRequest.CoverURL = &bookMetadata.CoverURL
+	}
+	return createRequest
+}
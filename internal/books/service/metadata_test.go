@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bkiran6398/library/internal/books/domain"
+	"github.com/bkiran6398/library/internal/books/metadata"
+	"github.com/bkiran6398/library/internal/books/repository/mocks"
+	intErr "github.com/bkiran6398/library/internal/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func goBookMetadata() domain.BookMetadata {
+	year := 2015
+	return domain.BookMetadata{
+		ISBN:          "9780134190440",
+		Title:         "The Go Programming Language",
+		Author:        "Alan A. A. Donovan and Brian W. Kernighan",
+		PublishedYear: &year,
+		CoverURL:      "https://covers.example/go.jpg",
+	}
+}
+
+func TestLookup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	provider := metadata.NewFake(goBookMetadata())
+	service := NewService(mocks.NewMockRepository(ctrl), WithMetadataProvider(provider, false))
+
+	bookMetadata, err := service.Lookup(context.Background(), "0-13-419044-0")
+	require.NoError(t, err)
+	require.Equal(t, goBookMetadata(), bookMetadata)
+
+	_, err = service.Lookup(context.Background(), "9780306406157")
+	require.True(t, errors.Is(err, intErr.ErrNotFound))
+
+	_, err = service.Lookup(context.Background(), "abc")
+	require.True(t, errors.Is(err, intErr.ErrBadRequest))
+
+	provider.Err = errors.New("connection refused")
+	_, err = service.Lookup(context.Background(), "9780134190440")
+	require.True(t, errors.Is(err, intErr.ErrUnavailable))
+	require.True(t, intErr.Resolve(err).Retryable)
+}
+
+func TestLookup_NotConfigured(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := NewService(mocks.NewMockRepository(ctrl))
+	_, err := service.Lookup(context.Background(), "9780134190440")
+	require.True(t, errors.Is(err, intErr.ErrUnavailable))
+}
+
+func TestCreate_AutoEnrichFillsMissingFields(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	service := NewService(mockRepo, WithMetadataProvider(metadata.NewFake(goBookMetadata()), true))
+
+	mockRepo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, book domain.Book) (domain.Book, error) {
+			require.Equal(t, "The Go Programming Language", book.Title)
+			require.Equal(t, "Donovan, Alan", book.Author)
+			require.Equal(t, 2015, *book.PublishedYear)
+			require.Equal(t, "https://covers.example/go.jpg", *book.CoverURL)
+			return book, nil
+		}).
+		Times(1)
+
+	_, err := service.Create(context.Background(), domain.CreateBookRequest{
+		Author:      "Donovan, Alan",
+		ISBN:        "978-0-13-419044-0",
+		CopiesTotal: 1,
+	})
+	require.NoError(t, err)
+}
+
+func TestCreate_AutoEnrichFailureKeepsRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	provider := metadata.NewFake()
+	provider.Err = errors.New("timeout")
+	service := NewService(mocks.NewMockRepository(ctrl), WithMetadataProvider(provider, true))
+
+	_, err := service.Create(context.Background(), domain.CreateBookRequest{ISBN: "9780134190440", CopiesTotal: 1})
+	require.True(t, errors.Is(err, intErr.ErrBadRequest))
+	require.Contains(t, err.Error(), "Title")
+	require.Equal(t, 1, provider.Lookups())
+}
+
+func TestCreate_CompleteRequestSkipsLookup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	provider := metadata.NewFake(goBookMetadata())
+	service := NewService(mockRepo, WithMetadataProvider(provider, true))
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(domain.Book{}, nil).Times(1)
+
+	year, coverURL := 2016, "https://covers.example/own.jpg"
+	_, err := service.Create(context.Background(), domain.CreateBookRequest{
+		Title: "Title", Author: "Author", ISBN: "9780134190440", PublishedYear: &year, CoverURL: &coverURL, CopiesTotal: 1,
+	})
+	require.NoError(t, err)
+	require.Zero(t, provider.Lookups())
+}
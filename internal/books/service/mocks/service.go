@@ -11,6 +11,7 @@ package mocks
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
 
 	domain "github.com/bkiran6398/library/internal/books/domain"
@@ -42,6 +43,35 @@ func (m *MockService) EXPECT() *MockServiceMockRecorder {
 	return m.recorder
 }
 
+// AdjustCopies mocks base method.
+func (m *MockService) AdjustCopies(ctx context.Context, bookID uuid.UUID, adjustRequest domain.AdjustCopiesRequest) (domain.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdjustCopies", ctx, bookID, adjustRequest)
+	ret0, _ := ret[0].(domain.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdjustCopies indicates an expected call of AdjustCopies.
+func (mr *MockServiceMockRecorder) AdjustCopies(ctx, bookID, adjustRequest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdjustCopies", reflect.TypeOf((*MockService)(nil).AdjustCopies), ctx, bookID, adjustRequest)
+}
+
+// Cite mocks base method.
+func (m *MockService) Cite(ctx context.Context, destination io.Writer, bookIDs []uuid.UUID, format domain.CitationFormat) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cite", ctx, destination, bookIDs, format)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Cite indicates an expected call of Cite.
+func (mr *MockServiceMockRecorder) Cite(ctx, destination, bookIDs, format any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cite", reflect.TypeOf((*MockService)(nil).Cite), ctx, destination, bookIDs, format)
+}
+
 // Create mocks base method.
 func (m *MockService) Create(ctx context.Context, createRequest domain.CreateBookRequest) (domain.Book, error) {
 	m.ctrl.T.Helper()
@@ -57,6 +87,21 @@ func (mr *MockServiceMockRecorder) Create(ctx, createRequest any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockService)(nil).Create), ctx, createRequest)
 }
 
+// CreateWork mocks base method.
+func (m *MockService) CreateWork(ctx context.Context, createRequest domain.CreateWorkRequest) (domain.WorkEditions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWork", ctx, createRequest)
+	ret0, _ := ret[0].(domain.WorkEditions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWork indicates an expected call of CreateWork.
+func (mr *MockServiceMockRecorder) CreateWork(ctx, createRequest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWork", reflect.TypeOf((*MockService)(nil).CreateWork), ctx, createRequest)
+}
+
 // Delete mocks base method.
 func (m *MockService) Delete(ctx context.Context, bookID uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -71,6 +116,34 @@ func (mr *MockServiceMockRecorder) Delete(ctx, bookID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockService)(nil).Delete), ctx, bookID)
 }
 
+// DeleteWork mocks base method.
+func (m *MockService) DeleteWork(ctx context.Context, workID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWork", ctx, workID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWork indicates an expected call of DeleteWork.
+func (mr *MockServiceMockRecorder) DeleteWork(ctx, workID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWork", reflect.TypeOf((*MockService)(nil).DeleteWork), ctx, workID)
+}
+
+// Export mocks base method.
+func (m *MockService) Export(ctx context.Context, destination io.Writer, filter domain.ListFilter, format domain.ExportFormat) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, destination, filter, format)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockServiceMockRecorder) Export(ctx, destination, filter, format any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockService)(nil).Export), ctx, destination, filter, format)
+}
+
 // Get mocks base method.
 func (m *MockService) Get(ctx context.Context, bookID uuid.UUID) (domain.Book, error) {
 	m.ctrl.T.Helper()
@@ -86,6 +159,36 @@ func (mr *MockServiceMockRecorder) Get(ctx, bookID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockService)(nil).Get), ctx, bookID)
 }
 
+// GetWork mocks base method.
+func (m *MockService) GetWork(ctx context.Context, workID uuid.UUID) (domain.WorkEditions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWork", ctx, workID)
+	ret0, _ := ret[0].(domain.WorkEditions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWork indicates an expected call of GetWork.
+func (mr *MockServiceMockRecorder) GetWork(ctx, workID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWork", reflect.TypeOf((*MockService)(nil).GetWork), ctx, workID)
+}
+
+// Import mocks base method.
+func (m *MockService) Import(ctx context.Context, source io.Reader, options domain.ImportOptions) (domain.ImportReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", ctx, source, options)
+	ret0, _ := ret[0].(domain.ImportReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockServiceMockRecorder) Import(ctx, source, options any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockService)(nil).Import), ctx, source, options)
+}
+
 // List mocks base method.
 func (m *MockService) List(ctx context.Context, filter domain.ListFilter) ([]domain.Book, error) {
 	m.ctrl.T.Helper()
@@ -101,6 +204,21 @@ func (mr *MockServiceMockRecorder) List(ctx, filter any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockService)(nil).List), ctx, filter)
 }
 
+// Lookup mocks base method.
+func (m *MockService) Lookup(ctx context.Context, isbn string) (domain.BookMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lookup", ctx, isbn)
+	ret0, _ := ret[0].(domain.BookMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Lookup indicates an expected call of Lookup.
+func (mr *MockServiceMockRecorder) Lookup(ctx, isbn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lookup", reflect.TypeOf((*MockService)(nil).Lookup), ctx, isbn)
+}
+
 // Update mocks base method.
 func (m *MockService) Update(ctx context.Context, bookID uuid.UUID, updateRequest domain.UpdateBookRequest) (domain.Book, error) {
 	m.ctrl.T.Helper()
@@ -3,6 +3,7 @@ package service
 
 import (
 	"context"
+	"io"
 
 	"github.com/bkiran6398/library/internal/books/domain"
 	"github.com/google/uuid"
@@ -15,5 +16,25 @@ type Service interface {
 	Get(ctx context.Context, bookID uuid.UUID) (domain.Book, error)
 	Update(ctx context.Context, bookID uuid.UUID, updateRequest domain.UpdateBookRequest) (domain.Book, error)
 	Delete(ctx context.Context, bookID uuid.UUID) error
+	// AdjustCopies changes the copy counts of a book by the requested deltas atomically and
+	// records the change in the inventory ledger.
+	AdjustCopies(ctx context.Context, bookID uuid.UUID, adjustRequest domain.AdjustCopiesRequest) (domain.Book, error)
 	List(ctx context.Context, filter domain.ListFilter) ([]domain.Book, error)
+	// Import creates or updates books by ISBN from a CSV or NDJSON stream and reports the
+	// outcome of every row. Invalid rows are reported as failed without stopping the import.
+	Import(ctx context.Context, source io.Reader, options domain.ImportOptions) (domain.ImportReport, error)
+	// Export streams every book matching filter to destination in format. The filter's
+	// limit and offset apply; a zero limit exports everything.
+	Export(ctx context.Context, destination io.Writer, filter domain.ListFilter, format domain.ExportFormat) error
+	// Cite writes citations of the given books to destination in format, in the order
+	// requested. Nothing is written when any of the books does not exist.
+	Cite(ctx context.Context, destination io.Writer, bookIDs []uuid.UUID, format domain.CitationFormat) error
+	// Lookup fetches bibliographic metadata for an ISBN-10 or ISBN-13 from the configured
+	// metadata provider without creating a book.
+	Lookup(ctx context.Context, isbn string) (domain.BookMetadata, error)
+	// CreateWork creates a work, making the given books its editions.
+	CreateWork(ctx context.Context, createRequest domain.CreateWorkRequest) (domain.WorkEditions, error)
+	// GetWork returns a work with all its editions and their aggregated availability.
+	GetWork(ctx context.Context, workID uuid.UUID) (domain.WorkEditions, error)
+	DeleteWork(ctx context.Context, workID uuid.UUID) error
 }
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/bkiran6398/library/internal/books/domain"
+	"github.com/bkiran6398/library/internal/books/metadata"
 	"github.com/bkiran6398/library/internal/books/repository"
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
@@ -20,20 +21,41 @@ const (
 
 // service is the implementation of Service.
 type service struct {
-	repository repository.Repository
-	validator  *validator.Validate
+	repository       repository.Repository
+	validator        *validator.Validate
+	metadataProvider metadata.Provider
+	autoEnrich       bool
+}
+
+// Option configures optional dependencies of the service.
+type Option func(*service)
+
+// WithMetadataProvider enables Lookup through provider. With autoEnrich, Create also fills
+// in the fields a request leaves out from the provider.
+func WithMetadataProvider(provider metadata.Provider, autoEnrich bool) Option {
+	return func(serviceInstance *service) {
+		serviceInstance.metadataProvider = provider
+		serviceInstance.autoEnrich = autoEnrich
+	}
 }
 
 // NewService creates a new Service implementation.
 // This constructor is the only place where consumers should depend on the concrete type.
-func NewService(repository repository.Repository) Service {
-	return &service{
+func NewService(repository repository.Repository, options ...Option) Service {
+	serviceInstance := &service{
 		repository: repository,
-		validator:  validator.New(),
+		validator:  newValidator(),
+	}
+	for _, option := range options {
+		option(serviceInstance)
 	}
+	return serviceInstance
 }
 
 func (serviceInstance *service) Create(ctx context.Context, createRequest domain.CreateBookRequest) (domain.Book, error) {
+	if serviceInstance.autoEnrich {
+		createRequest = serviceInstance.enrich(ctx, createRequest)
+	}
 	if err := validateCreateRequest(serviceInstance.validator, createRequest); err != nil {
 		return domain.Book{}, err
 	}
@@ -83,5 +105,5 @@ func (serviceInstance *service) Delete(ctx context.Context, bookID uuid.UUID) er
 func (serviceInstance *service) List(ctx context.Context, filter domain.ListFilter) ([]domain.Book, error) {
 	ctxWithTimeout, cancel := context.WithTimeout(ctx, listTimeout)
 	defer cancel()
-	return serviceInstance.repository.List(ctxWithTimeout, filter)
+	return serviceInstance.repository.List(ctxWithTimeout, normalizeListFilter(filter))
 }
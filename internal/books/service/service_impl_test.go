@@ -24,7 +24,7 @@ func TestCreate_DefaultCopiesAvailable(t *testing.T) {
 	createRequest := domain.CreateBookRequest{
 		Title:       "T",
 		Author:      "A",
-		ISBN:        "123",
+		ISBN:        "978-0-306-40615-7",
 		CopiesTotal: 5,
 	}
 
@@ -32,7 +32,7 @@ func TestCreate_DefaultCopiesAvailable(t *testing.T) {
 		ID:              uuid.New(),
 		Title:           "T",
 		Author:          "A",
-		ISBN:            "123",
+		ISBN:            "9780306406157",
 		CopiesTotal:     5,
 		CopiesAvailable: 5,
 		CreatedAt:       time.Now(),
@@ -44,7 +44,7 @@ func TestCreate_DefaultCopiesAvailable(t *testing.T) {
 		DoAndReturn(func(ctx context.Context, book domain.Book) (domain.Book, error) {
 			require.Equal(t, "T", book.Title)
 			require.Equal(t, "A", book.Author)
-			require.Equal(t, "123", book.ISBN)
+			require.Equal(t, "9780306406157", book.ISBN)
 			require.Equal(t, 5, book.CopiesTotal)
 			require.Equal(t, 5, book.CopiesAvailable)
 			return expectedBook, nil
@@ -68,7 +68,7 @@ func TestCreate_ValidateCopiesAvailable(t *testing.T) {
 	createRequest := domain.CreateBookRequest{
 		Title:           "T",
 		Author:          "A",
-		ISBN:            "123",
+		ISBN:            "9780306406157",
 		CopiesTotal:     5,
 		CopiesAvailable: &avail,
 	}
@@ -88,7 +88,7 @@ func TestCreate_WithExplicitCopiesAvailable(t *testing.T) {
 	createRequest := domain.CreateBookRequest{
 		Title:           "Test Book",
 		Author:          "Test Author",
-		ISBN:            "ISBN-123",
+		ISBN:            "9780306406157",
 		CopiesTotal:     5,
 		CopiesAvailable: &avail,
 	}
@@ -97,7 +97,7 @@ func TestCreate_WithExplicitCopiesAvailable(t *testing.T) {
 		ID:              uuid.New(),
 		Title:           "Test Book",
 		Author:          "Test Author",
-		ISBN:            "ISBN-123",
+		ISBN:            "9780306406157",
 		CopiesTotal:     5,
 		CopiesAvailable: 3,
 		CreatedAt:       time.Now(),
@@ -127,7 +127,7 @@ func TestCreate_ValidationError_EmptyTitle(t *testing.T) {
 	createRequest := domain.CreateBookRequest{
 		Title:       "", // Empty title
 		Author:      "Author",
-		ISBN:        "ISBN-123",
+		ISBN:        "9780306406157",
 		CopiesTotal: 5,
 	}
 
@@ -146,7 +146,7 @@ func TestCreate_ValidationError_EmptyAuthor(t *testing.T) {
 	createRequest := domain.CreateBookRequest{
 		Title:       "Title",
 		Author:      "", // Empty author
-		ISBN:        "ISBN-123",
+		ISBN:        "9780306406157",
 		CopiesTotal: 5,
 	}
 
@@ -174,6 +174,27 @@ func TestCreate_ValidationError_EmptyISBN(t *testing.T) {
 	require.Contains(t, err.Error(), "bad request")
 }
 
+func TestCreate_ValidationError_InvalidISBNChecksum(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	service := NewService(mockRepo)
+
+	for _, invalidISBN := range []string{"abc", "978-0-306-40615-8", "0-306-40615-3"} {
+		createRequest := domain.CreateBookRequest{
+			Title:       "Title",
+			Author:      "Author",
+			ISBN:        invalidISBN,
+			CopiesTotal: 5,
+		}
+
+		_, err := service.Create(context.Background(), createRequest)
+		require.ErrorIs(t, err, intErr.ErrBadRequest, invalidISBN)
+		require.Contains(t, err.Error(), "'isbn' tag")
+	}
+}
+
 func TestCreate_ValidationError_NegativeCopiesTotal(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -184,7 +205,7 @@ func TestCreate_ValidationError_NegativeCopiesTotal(t *testing.T) {
 	createRequest := domain.CreateBookRequest{
 		Title:       "Title",
 		Author:      "Author",
-		ISBN:        "ISBN-123",
+		ISBN:        "9780306406157",
 		CopiesTotal: -1, // Negative
 	}
 
@@ -203,7 +224,7 @@ func TestCreate_RepositoryError(t *testing.T) {
 	createRequest := domain.CreateBookRequest{
 		Title:       "Title",
 		Author:      "Author",
-		ISBN:        "ISBN-123",
+		ISBN:        "9780306406157",
 		CopiesTotal: 5,
 	}
 
@@ -230,7 +251,7 @@ func TestGet_Success(t *testing.T) {
 		ID:              bookID,
 		Title:           "Test Book",
 		Author:          "Test Author",
-		ISBN:            "ISBN-123",
+		ISBN:            "9780306406157",
 		CopiesTotal:     5,
 		CopiesAvailable: 3,
 		CreatedAt:       time.Now(),
@@ -279,7 +300,7 @@ func TestUpdate_Success(t *testing.T) {
 		ID:              bookID,
 		Title:           "Old Title",
 		Author:          "Old Author",
-		ISBN:            "ISBN-OLD",
+		ISBN:            "9780134190440",
 		CopiesTotal:     5,
 		CopiesAvailable: 3,
 		CreatedAt:       time.Now(),
@@ -289,7 +310,7 @@ func TestUpdate_Success(t *testing.T) {
 	updateRequest := domain.UpdateBookRequest{
 		Title:           "New Title",
 		Author:          "New Author",
-		ISBN:            "ISBN-NEW",
+		ISBN:            "1-4493-7332-1",
 		CopiesTotal:     10,
 		CopiesAvailable: 8,
 	}
@@ -298,7 +319,7 @@ func TestUpdate_Success(t *testing.T) {
 		ID:              bookID,
 		Title:           "New Title",
 		Author:          "New Author",
-		ISBN:            "ISBN-NEW",
+		ISBN:            "9781449373320",
 		CopiesTotal:     10,
 		CopiesAvailable: 8,
 		CreatedAt:       existingBook.CreatedAt,
@@ -315,7 +336,7 @@ func TestUpdate_Success(t *testing.T) {
 		DoAndReturn(func(ctx context.Context, book domain.Book) (domain.Book, error) {
 			require.Equal(t, "New Title", book.Title)
 			require.Equal(t, "New Author", book.Author)
-			require.Equal(t, "ISBN-NEW", book.ISBN)
+			require.Equal(t, "9781449373320", book.ISBN)
 			require.Equal(t, 10, book.CopiesTotal)
 			require.Equal(t, 8, book.CopiesAvailable)
 			return updatedBook, nil
@@ -339,7 +360,7 @@ func TestUpdate_ValidationError_EmptyTitle(t *testing.T) {
 	updateRequest := domain.UpdateBookRequest{
 		Title:           "", // Empty title
 		Author:          "Author",
-		ISBN:            "ISBN-123",
+		ISBN:            "9780306406157",
 		CopiesTotal:     5,
 		CopiesAvailable: 3,
 	}
@@ -360,7 +381,7 @@ func TestUpdate_CopiesAvailableExceedsTotal(t *testing.T) {
 	updateRequest := domain.UpdateBookRequest{
 		Title:           "Title",
 		Author:          "Author",
-		ISBN:            "ISBN-123",
+		ISBN:            "9780306406157",
 		CopiesTotal:     5,
 		CopiesAvailable: 6, // Exceeds total
 	}
@@ -382,7 +403,7 @@ func TestUpdate_BookNotFound(t *testing.T) {
 	updateRequest := domain.UpdateBookRequest{
 		Title:           "Title",
 		Author:          "Author",
-		ISBN:            "ISBN-123",
+		ISBN:            "9780306406157",
 		CopiesTotal:     5,
 		CopiesAvailable: 3,
 	}
@@ -409,7 +430,7 @@ func TestUpdate_RepositoryUpdateError(t *testing.T) {
 		ID:              bookID,
 		Title:           "Old Title",
 		Author:          "Old Author",
-		ISBN:            "ISBN-OLD",
+		ISBN:            "9780134190440",
 		CopiesTotal:     5,
 		CopiesAvailable: 3,
 		CreatedAt:       time.Now(),
@@ -419,7 +440,7 @@ func TestUpdate_RepositoryUpdateError(t *testing.T) {
 	updateRequest := domain.UpdateBookRequest{
 		Title:           "New Title",
 		Author:          "New Author",
-		ISBN:            "ISBN-NEW",
+		ISBN:            "9781449373320",
 		CopiesTotal:     10,
 		CopiesAvailable: 8,
 	}
@@ -488,7 +509,7 @@ func TestList_Success_NoFilter(t *testing.T) {
 			ID:              uuid.New(),
 			Title:           "Book 1",
 			Author:          "Author 1",
-			ISBN:            "ISBN-1",
+			ISBN:            "9780262510875",
 			CopiesTotal:     5,
 			CopiesAvailable: 3,
 			CreatedAt:       time.Now(),
@@ -498,7 +519,7 @@ func TestList_Success_NoFilter(t *testing.T) {
 			ID:              uuid.New(),
 			Title:           "Book 2",
 			Author:          "Author 2",
-			ISBN:            "ISBN-2",
+			ISBN:            "9780135957059",
 			CopiesTotal:     10,
 			CopiesAvailable: 8,
 			CreatedAt:       time.Now(),
@@ -532,7 +553,7 @@ func TestList_Success_WithFilter(t *testing.T) {
 			ID:              uuid.New(),
 			Title:           "The Great Gatsby",
 			Author:          "F. Scott Fitzgerald",
-			ISBN:            "ISBN-1",
+			ISBN:            "9780262510875",
 			CopiesTotal:     5,
 			CopiesAvailable: 3,
 			CreatedAt:       time.Now(),
@@ -555,6 +576,52 @@ func TestList_Success_WithFilter(t *testing.T) {
 	require.Equal(t, "The Great Gatsby", got[0].Title)
 }
 
+func TestList_ISBNFilterAcceptsEitherForm(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	service := NewService(mockRepo)
+
+	canonical := "9780306406157"
+	for _, isbnFilter := range []string{"0-306-40615-2", "978-0-306-40615-7", canonical} {
+		mockRepo.EXPECT().
+			List(gomock.Any(), domain.ListFilter{ISBN: &canonical}).
+			Return(nil, nil).
+			Times(1)
+
+		_, err := service.List(context.Background(), domain.ListFilter{ISBN: &isbnFilter})
+		require.NoError(t, err)
+	}
+
+	// Values that are not ISBNs are passed through and simply match nothing.
+	unknown := "not-an-isbn"
+	mockRepo.EXPECT().
+		List(gomock.Any(), domain.ListFilter{ISBN: &unknown}).
+		Return(nil, nil).
+		Times(1)
+	_, err := service.List(context.Background(), domain.ListFilter{ISBN: &unknown})
+	require.NoError(t, err)
+}
+
+func TestList_TagFilterIsNormalized(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	service := NewService(mockRepo)
+
+	normalized := "science fiction"
+	mockRepo.EXPECT().
+		List(gomock.Any(), domain.ListFilter{Tag: &normalized}).
+		Return(nil, nil).
+		Times(1)
+
+	tag := "  Science   Fiction "
+	_, err := service.List(context.Background(), domain.ListFilter{Tag: &tag})
+	require.NoError(t, err)
+}
+
 func TestList_RepositoryError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
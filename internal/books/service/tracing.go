@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"io"
+
+	"github.com/bkiran6398/library/internal/books/domain"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const spanNamePrefix = "books.Service/"
+
+// tracingService decorates a Service with a child span per method.
+type tracingService struct {
+	next   Service
+	tracer trace.Tracer
+}
+
+// NewTracingService wraps next so every call is recorded as a span.
+func NewTracingService(next Service, tracer trace.Tracer) Service {
+	return &tracingService{next: next, tracer: tracer}
+}
+
+func (tracing *tracingService) Create(ctx context.Context, createRequest domain.CreateBookRequest) (domain.Book, error) {
+	ctx, span := tracing.tracer.Start(ctx, spanNamePrefix+"Create", trace.WithAttributes(attribute.String("book.isbn", createRequest.ISBN)))
+	defer span.End()
+	book, err := tracing.next.Create(ctx, createRequest)
+	return book, recordError(span, err)
+}
+
+func (tracing *tracingService) Get(ctx context.Context, bookID uuid.UUID) (domain.Book, error) {
+	ctx, span := tracing.tracer.Start(ctx, spanNamePrefix+"Get", trace.WithAttributes(attribute.String("book.id", bookID.String())))
+	defer span.End()
+	book, err := tracing.next.Get(ctx, bookID)
+	return book, recordError(span, err)
+}
+
+func (tracing *tracingService) Update(ctx context.Context, bookID uuid.UUID, updateRequest domain.UpdateBookRequest) (domain.Book, error) {
+	ctx, span := tracing.tracer.Start(ctx, spanNamePrefix+"Update", trace.WithAttributes(attribute.String("book.id", bookID.String())))
+	defer span.End()
+	book, err := tracing.next.Update(ctx, bookID, updateRequest)
+	return book, recordError(span, err)
+}
+
+func (tracing *tracingService) Delete(ctx context.Context, bookID uuid.UUID) error {
+	ctx, span := tracing.tracer.Start(ctx, spanNamePrefix+"Delete", trace.WithAttributes(attribute.String("book.id", bookID.String())))
+	defer span.End()
+	return recordError(span, tracing.next.Delete(ctx, bookID))
+}
+
+func (tracing *tracingService) AdjustCopies(ctx context.Context, bookID uuid.UUID, adjustRequest domain.AdjustCopiesRequest) (domain.Book, error) {
+	ctx, span := tracing.tracer.Start(ctx, spanNamePrefix+"AdjustCopies", trace.WithAttributes(
+		attribute.String("book.id", bookID.String()),
+		attribute.String("adjustment.reason", string(adjustRequest.Reason)),
+	))
+	defer span.End()
+	book, err := tracing.next.AdjustCopies(ctx, bookID, adjustRequest)
+	return book, recordError(span, err)
+}
+
+func (tracing *tracingService) List(ctx context.Context, filter domain.ListFilter) ([]domain.Book, error) {
+	ctx, span := tracing.tracer.Start(ctx, spanNamePrefix+"List", trace.WithAttributes(
+		attribute.Int("list.limit", filter.Limit),
+		attribute.Int("list.offset", filter.Offset),
+	))
+	defer span.End()
+	books, err := tracing.next.List(ctx, filter)
+	span.SetAttributes(attribute.Int("list.results", len(books)))
+	return books, recordError(span, err)
+}
+
+func (tracing *tracingService) Import(ctx context.Context, source io.Reader, options domain.ImportOptions) (domain.ImportReport, error) {
+	ctx, span := tracing.tracer.Start(ctx, spanNamePrefix+"Import", trace.WithAttributes(
+		attribute.String("import.format", string(options.Format)),
+		attribute.Bool("import.dry_run", options.DryRun),
+	))
+	defer span.End()
+	report, err := tracing.next.Import(ctx, source, options)
+	span.SetAttributes(
+		attribute.Int("import.created", report.Created),
+		attribute.Int("import.updated", report.Updated),
+		attribute.Int("import.failed", report.Failed),
+	)
+	return report, recordError(span, err)
+}
+
+func (tracing *tracingService) Export(ctx context.Context, destination io.Writer, filter domain.ListFilter, format domain.ExportFormat) error {
+	ctx, span := tracing.tracer.Start(ctx, spanNamePrefix+"Export", trace.WithAttributes(attribute.String("export.format", string(format))))
+	defer span.End()
+	return recordError(span, tracing.next.Export(ctx, destination, filter, format))
+}
+
+func (tracing *tracingService) Cite(ctx context.Context, destination io.Writer, bookIDs []uuid.UUID, format domain.CitationFormat) error {
+	ctx, span := tracing.tracer.Start(ctx, spanNamePrefix+"Cite", trace.WithAttributes(
+		attribute.String("citation.format", string(format)),
+		attribute.Int("citation.books", len(bookIDs)),
+	))
+	defer span.End()
+	return recordError(span, tracing.next.Cite(ctx, destination, bookIDs, format))
+}
+
+func (tracing *tracingService) Lookup(ctx context.Context, isbn string) (domain.BookMetadata, error) {
+	ctx, span := tracing.tracer.Start(ctx, spanNamePrefix+"Lookup", trace.WithAttributes(attribute.String("book.isbn", isbn)))
+	defer span.End()
+	bookMetadata, err := tracing.next.Lookup(ctx, isbn)
+	return bookMetadata, recordError(span, err)
+}
+
+func (tracing *tracingService) CreateWork(ctx context.Context, createRequest domain.CreateWorkRequest) (domain.WorkEditions, error) {
+	ctx, span := tracing.tracer.Start(ctx, spanNamePrefix+"CreateWork", trace.WithAttributes(attribute.Int("work.editions", len(createRequest.BookIDs))))
+	defer span.End()
+	work, err := tracing.next.CreateWork(ctx, createRequest)
+	return work, recordError(span, err)
+}
+
+func (tracing *tracingService) GetWork(ctx context.Context, workID uuid.UUID) (domain.WorkEditions, error) {
+	ctx, span := tracing.tracer.Start(ctx, spanNamePrefix+"GetWork", trace.WithAttributes(attribute.String("work.id", workID.String())))
+	defer span.End()
+	work, err := tracing.next.GetWork(ctx, workID)
+	return work, recordError(span, err)
+}
+
+func (tracing *tracingService) DeleteWork(ctx context.Context, workID uuid.UUID) error {
+	ctx, span := tracing.tracer.Start(ctx, spanNamePrefix+"DeleteWork", trace.WithAttributes(attribute.String("work.id", workID.String())))
+	defer span.End()
+	return recordError(span, tracing.next.DeleteWork(ctx, workID))
+}
+
+// recordError marks the span as failed when err is non-nil and returns err unchanged.
+func recordError(span trace.Span, err error) error {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
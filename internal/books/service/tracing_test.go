@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bkiran6398/library/internal/books/domain"
+	"github.com/bkiran6398/library/internal/books/repository/mocks"
+	intErr "github.com/bkiran6398/library/internal/errors"
+	"github.com/bkiran6398/library/internal/tracing"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTracingService_RecordsChildSpans(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider, err := tracing.NewProviderWithExporter(tracing.Config{ServiceName: "test", SampleRatio: 1}, sdktrace.WithSyncer(exporter))
+	require.NoError(t, err)
+	tracer := provider.Tracer()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	service := NewTracingService(NewService(mockRepo), tracer)
+
+	bookID := uuid.New()
+	mockRepo.EXPECT().Get(gomock.Any(), bookID).Return(domain.Book{}, intErr.ErrNotFound).Times(1)
+
+	ctx, parent := tracer.Start(context.Background(), "GET /v1/books/{id}")
+	_, err = service.Get(ctx, bookID)
+	parent.End()
+	require.ErrorIs(t, err, intErr.ErrNotFound)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	child := spans[0]
+	require.Equal(t, "books.Service/Get", child.Name)
+	require.Equal(t, parent.SpanContext().SpanID(), child.Parent.SpanID())
+	require.Equal(t, codes.Error, child.Status.Code)
+}
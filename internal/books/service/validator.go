@@ -5,9 +5,23 @@ import (
 
 	"github.com/bkiran6398/library/internal/books/domain"
 	intErr "github.com/bkiran6398/library/internal/errors"
+	"github.com/bkiran6398/library/internal/isbn"
 	"github.com/go-playground/validator/v10"
 )
 
+// newValidator returns a validator with the book tags registered. The isbn tag replaces
+// the built-in one so that validation accepts exactly what isbn.Normalize can canonicalize:
+// an ISBN-10 or ISBN-13 with a correct check digit, with or without hyphens and spaces.
+func newValidator() *validator.Validate {
+	validatorInstance := validator.New()
+	if err := validatorInstance.RegisterValidation("isbn", func(field validator.FieldLevel) bool {
+		return isbn.Valid(field.Field().String())
+	}); err != nil {
+		panic(err)
+	}
+	return validatorInstance
+}
+
 // validateCreateRequest validates a CreateBookRequest and returns an error if validation fails.
 func validateCreateRequest(validatorInstance *validator.Validate, request domain.CreateBookRequest) error {
 	if err := validatorInstance.Struct(request); err != nil {
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bkiran6398/library/internal/books/domain"
+	intErr "github.com/bkiran6398/library/internal/errors"
+	"github.com/google/uuid"
+)
+
+const (
+	createWorkTimeout = 5 * time.Second
+	getWorkTimeout    = 5 * time.Second
+	deleteWorkTimeout = 5 * time.Second
+)
+
+func (serviceInstance *service) CreateWork(ctx context.Context, createRequest domain.CreateWorkRequest) (domain.WorkEditions, error) {
+	if err := serviceInstance.validator.Struct(createRequest); err != nil {
+		return domain.WorkEditions{}, fmt.Errorf("%w: %v", intErr.ErrBadRequest, err)
+	}
+
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, createWorkTimeout)
+	defer cancel()
+	work, err := serviceInstance.repository.CreateWork(ctxWithTimeout, domain.Work{ID: uuid.New(), Title: createRequest.Title}, uniqueBookIDs(createRequest.BookIDs))
+	if err != nil {
+		return domain.WorkEditions{}, err
+	}
+	return serviceInstance.withEditions(ctxWithTimeout, work)
+}
+
+func (serviceInstance *service) GetWork(ctx context.Context, workID uuid.UUID) (domain.WorkEditions, error) {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, getWorkTimeout)
+	defer cancel()
+	work, err := serviceInstance.repository.GetWork(ctxWithTimeout, workID)
+	if err != nil {
+		return domain.WorkEditions{}, err
+	}
+	return serviceInstance.withEditions(ctxWithTimeout, work)
+}
+
+func (serviceInstance *service) DeleteWork(ctx context.Context, workID uuid.UUID) error {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, deleteWorkTimeout)
+	defer cancel()
+	return serviceInstance.repository.DeleteWork(ctxWithTimeout, workID)
+}
+
+// withEditions loads the editions of work and aggregates their availability.
+func (serviceInstance *service) withEditions(ctx context.Context, work domain.Work) (domain.WorkEditions, error) {
+	editions, err := serviceInstance.repository.List(ctx, domain.ListFilter{WorkID: &work.ID})
+	if err != nil {
+		return domain.WorkEditions{}, err
+	}
+	if editions == nil {
+		editions = []domain.Book{}
+	}
+	return domain.WorkEditions{Work: work, Editions: editions, Availability: aggregateAvailability(editions)}, nil
+}
+
+// aggregateAvailability sums the copies of the editions and counts those with a copy on the shelf.
+func aggregateAvailability(editions []domain.Book) domain.WorkAvailability {
+	availability := domain.WorkAvailability{Editions: len(editions)}
+	for _, edition := range editions {
+		availability.CopiesTotal += edition.CopiesTotal
+		availability.CopiesAvailable += edition.CopiesAvailable
+		if edition.CopiesAvailable > 0 {
+			availability.EditionsAvailable++
+		}
+	}
+	return availability
+}
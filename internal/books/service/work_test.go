@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bkiran6398/library/internal/books/domain"
+	"github.com/bkiran6398/library/internal/books/repository/mocks"
+	intErr "github.com/bkiran6398/library/internal/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetWork_AggregatesEditionAvailability(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	service := NewService(mockRepo)
+
+	work := domain.Work{ID: uuid.New(), Title: "The Left Hand of Darkness"}
+	hardcover, ebook := domain.BookFormatHardcover, domain.BookFormatEbook
+	editions := []domain.Book{
+		{ID: uuid.New(), WorkID: &work.ID, Format: &hardcover, CopiesTotal: 3, CopiesAvailable: 0},
+		{ID: uuid.New(), WorkID: &work.ID, Format: &ebook, CopiesTotal: 5, CopiesAvailable: 2},
+		{ID: uuid.New(), WorkID: &work.ID, CopiesTotal: 1, CopiesAvailable: 1},
+	}
+	mockRepo.EXPECT().GetWork(gomock.Any(), work.ID).Return(work, nil).Times(1)
+	mockRepo.EXPECT().List(gomock.Any(), domain.ListFilter{WorkID: &work.ID}).Return(editions, nil).Times(1)
+
+	got, err := service.GetWork(context.Background(), work.ID)
+	require.NoError(t, err)
+	require.Equal(t, work, got.Work)
+	require.Len(t, got.Editions, 3)
+	require.Equal(t, domain.WorkAvailability{Editions: 3, EditionsAvailable: 2, CopiesTotal: 9, CopiesAvailable: 3}, got.Availability)
+}
+
+func TestGetWork_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	service := NewService(mockRepo)
+
+	mockRepo.EXPECT().GetWork(gomock.Any(), gomock.Any()).Return(domain.Work{}, intErr.ErrNotFound).Times(1)
+	_, err := service.GetWork(context.Background(), uuid.New())
+	require.ErrorIs(t, err, intErr.ErrNotFound)
+}
+
+func TestCreateWork_GroupsUniqueEditions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	service := NewService(mockRepo)
+
+	first, second := uuid.New(), uuid.New()
+	mockRepo.EXPECT().
+		CreateWork(gomock.Any(), gomock.Any(), []uuid.UUID{first, second}).
+		DoAndReturn(func(ctx context.Context, work domain.Work, bookIDs []uuid.UUID) (domain.Work, error) {
+			require.Equal(t, "Dune", work.Title)
+			return work, nil
+		}).
+		Times(1)
+	mockRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+
+	created, err := service.CreateWork(context.Background(), domain.CreateWorkRequest{Title: "Dune", BookIDs: []uuid.UUID{first, second, first}})
+	require.NoError(t, err)
+	require.Empty(t, created.Editions)
+	require.NotNil(t, created.Editions)
+
+	_, err = service.CreateWork(context.Background(), domain.CreateWorkRequest{})
+	require.ErrorIs(t, err, intErr.ErrBadRequest)
+}
+
+func TestCreate_ValidatesEditionDetails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	service := NewService(mockRepo)
+
+	vinyl := domain.BookFormat("vinyl")
+	language := "not a language!"
+	pages := 0
+	for _, request := range []domain.CreateBookRequest{
+		{Title: "Dune", Author: "Frank Herbert", ISBN: "9780306406157", Format: &vinyl},
+		{Title: "Dune", Author: "Frank Herbert", ISBN: "9780306406157", Language: &language},
+		{Title: "Dune", Author: "Frank Herbert", ISBN: "9780306406157", PageCount: &pages},
+	} {
+		_, err := service.Create(context.Background(), request)
+		require.ErrorIs(t, err, intErr.ErrBadRequest)
+	}
+
+	paperback, english, pageCount, publisher := domain.BookFormatPaperback, "en-GB", 412, "Chilton"
+	mockRepo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, book domain.Book) (domain.Book, error) {
+			require.Equal(t, paperback, *book.Format)
+			require.Equal(t, english, *book.Language)
+			require.Equal(t, pageCount, *book.PageCount)
+			require.Equal(t, publisher, *book.Publisher)
+			return book, nil
+		}).
+		Times(1)
+	_, err := service.Create(context.Background(), domain.CreateBookRequest{
+		Title: "Dune", Author: "Frank Herbert", ISBN: "9780306406157",
+		Format: &paperback, Language: &english, PageCount: &pageCount, Publisher: &publisher,
+	})
+	require.NoError(t, err)
+}
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Branch is a library location holding copies of books.
+type Branch struct {
+	ID        uuid.UUID `json:"id"`
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CreateBranchRequest represents the request payload for creating a new branch. Code is a
+// short lower-case identifier such as "central".
+type CreateBranchRequest struct {
+	Code string `json:"code" validate:"required,max=32"`
+	Name string `json:"name" validate:"required,min=1,max=200"`
+}
+
+// UpdateBranchRequest represents the request payload for updating a branch.
+type UpdateBranchRequest struct {
+	Code string `json:"code" validate:"required,max=32"`
+	Name string `json:"name" validate:"required,min=1,max=200"`
+}
+
+// Holding is the number of copies of a book kept at a branch.
+type Holding struct {
+	BookID          uuid.UUID `json:"book_id"`
+	BranchID        uuid.UUID `json:"branch_id"`
+	CopiesTotal     int       `json:"copies_total"`
+	CopiesAvailable int       `json:"copies_available"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// SetHoldingRequest sets the copies of a book at a branch. When CopiesAvailable is left out,
+// the copies on loan at the branch are kept.
+type SetHoldingRequest struct {
+	CopiesTotal     int  `json:"copies_total" validate:"gte=0"`
+	CopiesAvailable *int `json:"copies_available,omitempty" validate:"omitempty,gte=0,ltefield=CopiesTotal"`
+}
+
+// BranchAvailability is the availability of a book at one branch. CopiesIncoming counts the
+// copies in transit to the branch.
+type BranchAvailability struct {
+	BranchID        uuid.UUID `json:"branch_id"`
+	Code            string    `json:"code"`
+	Name            string    `json:"name"`
+	CopiesTotal     int       `json:"copies_total"`
+	CopiesAvailable int       `json:"copies_available"`
+	CopiesIncoming  int       `json:"copies_incoming"`
+}
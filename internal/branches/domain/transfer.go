@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TransferStatus is the state of an inter-branch transfer.
+type TransferStatus string
+
+const (
+	// TransferRequested transfers have not left the source branch; copies are still there.
+	TransferRequested TransferStatus = "requested"
+	// TransferInTransit copies have left the source branch and are not available anywhere.
+	TransferInTransit TransferStatus = "in_transit"
+	// TransferReceived copies have arrived and are available at the destination branch.
+	TransferReceived TransferStatus = "received"
+	// TransferCancelled transfers were called off; shipped copies went back to the source.
+	TransferCancelled TransferStatus = "cancelled"
+)
+
+// transferTransitions lists the statuses each status can move to.
+var transferTransitions = map[TransferStatus][]TransferStatus{
+	TransferRequested: {TransferInTransit, TransferCancelled},
+	TransferInTransit: {TransferReceived, TransferCancelled},
+}
+
+// CanTransition reports whether a transfer in status from can move to status to.
+func CanTransition(from, to TransferStatus) bool {
+	for _, allowed := range transferTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Transfer moves copies of a book from one branch to another.
+type Transfer struct {
+	ID           uuid.UUID      `json:"id"`
+	BookID       uuid.UUID      `json:"book_id"`
+	FromBranchID uuid.UUID      `json:"from_branch_id"`
+	ToBranchID   uuid.UUID      `json:"to_branch_id"`
+	Copies       int            `json:"copies"`
+	Status       TransferStatus `json:"status"`
+	RequestedAt  time.Time      `json:"requested_at"`
+	ShippedAt    *time.Time     `json:"shipped_at,omitempty"`
+	ReceivedAt   *time.Time     `json:"received_at,omitempty"`
+	CancelledAt  *time.Time     `json:"cancelled_at,omitempty"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+}
+
+// CreateTransferRequest requests copies of a book to be moved between branches.
+type CreateTransferRequest struct {
+	BookID       uuid.UUID `json:"book_id" validate:"required"`
+	FromBranchID uuid.UUID `json:"from_branch_id" validate:"required"`
+	ToBranchID   uuid.UUID `json:"to_branch_id" validate:"required"`
+	Copies       int       `json:"copies" validate:"gt=0"`
+}
+
+// TransferFilter represents filtering options for listing transfers.
+type TransferFilter struct {
+	BookID   *uuid.UUID
+	BranchID *uuid.UUID
+	Status   *TransferStatus
+	Limit    int
+	Offset   int
+}
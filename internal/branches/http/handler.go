@@ -0,0 +1,255 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/bkiran6398/library/internal/branches/domain"
+	"github.com/bkiran6398/library/internal/branches/service"
+	"github.com/bkiran6398/library/internal/http/response"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// Handler handles HTTP requests for branches, holdings and transfers.
+type Handler struct {
+	service service.Service
+}
+
+// NewHandler creates a new Handler instance.
+func NewHandler(service service.Service) Handler {
+	return Handler{service: service}
+}
+
+func (handler Handler) List(w http.ResponseWriter, r *http.Request) {
+	branches, err := handler.service.ListBranches(r.Context())
+	if err != nil {
+		response.MapServiceErrorToHTTP(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, branches)
+}
+
+func (handler Handler) Create(w http.ResponseWriter, r *http.Request) {
+	var createRequest domain.CreateBranchRequest
+	if err := json.NewDecoder(r.Body).Decode(&createRequest); err != nil {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Invalid JSON body", nil)
+		return
+	}
+
+	branch, err := handler.service.CreateBranch(r.Context(), createRequest)
+	if err != nil {
+		response.MapServiceErrorToHTTP(w, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, branch)
+}
+
+func (handler Handler) Get(w http.ResponseWriter, r *http.Request) {
+	branchID, err := parseIDFromPath(r)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Invalid branch ID", nil)
+		return
+	}
+
+	branch, err := handler.service.GetBranch(r.Context(), branchID)
+	if err != nil {
+		response.MapServiceErrorToHTTP(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, branch)
+}
+
+func (handler Handler) Update(w http.ResponseWriter, r *http.Request) {
+	branchID, err := parseIDFromPath(r)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Invalid branch ID", nil)
+		return
+	}
+
+	var updateRequest domain.UpdateBranchRequest
+	if err := json.NewDecoder(r.Body).Decode(&updateRequest); err != nil {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Invalid JSON body", nil)
+		return
+	}
+
+	branch, err := handler.service.UpdateBranch(r.Context(), branchID, updateRequest)
+	if err != nil {
+		response.MapServiceErrorToHTTP(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, branch)
+}
+
+func (handler Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	branchID, err := parseIDFromPath(r)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Invalid branch ID", nil)
+		return
+	}
+
+	if err := handler.service.DeleteBranch(r.Context(), branchID); err != nil {
+		response.MapServiceErrorToHTTP(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Availability serves GET /books/{id}/availability.
+func (handler Handler) Availability(w http.ResponseWriter, r *http.Request) {
+	bookID, err := parseIDFromPath(r)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Invalid book ID", nil)
+		return
+	}
+
+	availability, err := handler.service.Availability(r.Context(), bookID)
+	if err != nil {
+		response.MapServiceErrorToHTTP(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, availability)
+}
+
+// SetHolding serves PUT /books/{id}/holdings/{branch_id}.
+func (handler Handler) SetHolding(w http.ResponseWriter, r *http.Request) {
+	bookID, err := parseIDFromPath(r)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Invalid book ID", nil)
+		return
+	}
+	branchID, err := uuid.Parse(mux.Vars(r)["branch_id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Invalid branch ID", nil)
+		return
+	}
+
+	var setRequest domain.SetHoldingRequest
+	if err := json.NewDecoder(r.Body).Decode(&setRequest); err != nil {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Invalid JSON body", nil)
+		return
+	}
+
+	holding, err := handler.service.SetHolding(r.Context(), bookID, branchID, setRequest)
+	if err != nil {
+		response.MapServiceErrorToHTTP(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, holding)
+}
+
+// ListTransfers serves GET /transfers, filtered by the book_id, branch_id and status query
+// parameters.
+func (handler Handler) ListTransfers(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseTransferFilter(r)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Invalid transfer filter: "+err.Error(), nil)
+		return
+	}
+
+	transfers, err := handler.service.ListTransfers(r.Context(), filter)
+	if err != nil {
+		response.MapServiceErrorToHTTP(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, transfers)
+}
+
+// CreateTransfer serves POST /transfers.
+func (handler Handler) CreateTransfer(w http.ResponseWriter, r *http.Request) {
+	var createRequest domain.CreateTransferRequest
+	if err := json.NewDecoder(r.Body).Decode(&createRequest); err != nil {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Invalid JSON body", nil)
+		return
+	}
+
+	transfer, err := handler.service.CreateTransfer(r.Context(), createRequest)
+	if err != nil {
+		response.MapServiceErrorToHTTP(w, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, transfer)
+}
+
+// GetTransfer serves GET /transfers/{id}.
+func (handler Handler) GetTransfer(w http.ResponseWriter, r *http.Request) {
+	transferID, err := parseIDFromPath(r)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Invalid transfer ID", nil)
+		return
+	}
+
+	transfer, err := handler.service.GetTransfer(r.Context(), transferID)
+	if err != nil {
+		response.MapServiceErrorToHTTP(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, transfer)
+}
+
+// ShipTransfer serves POST /transfers/{id}/ship.
+func (handler Handler) ShipTransfer(w http.ResponseWriter, r *http.Request) {
+	handler.advanceTransfer(w, r, handler.service.ShipTransfer)
+}
+
+// ReceiveTransfer serves POST /transfers/{id}/receive.
+func (handler Handler) ReceiveTransfer(w http.ResponseWriter, r *http.Request) {
+	handler.advanceTransfer(w, r, handler.service.ReceiveTransfer)
+}
+
+// CancelTransfer serves POST /transfers/{id}/cancel.
+func (handler Handler) CancelTransfer(w http.ResponseWriter, r *http.Request) {
+	handler.advanceTransfer(w, r, handler.service.CancelTransfer)
+}
+
+func (handler Handler) advanceTransfer(w http.ResponseWriter, r *http.Request, advance func(ctx context.Context, transferID uuid.UUID) (domain.Transfer, error)) {
+	transferID, err := parseIDFromPath(r)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Invalid transfer ID", nil)
+		return
+	}
+
+	transfer, err := advance(r.Context(), transferID)
+	if err != nil {
+		response.MapServiceErrorToHTTP(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, transfer)
+}
+
+// parseTransferFilter builds a TransferFilter from the request query. Unparsable limit and
+// offset values are ignored; invalid IDs are reported.
+func parseTransferFilter(r *http.Request) (domain.TransferFilter, error) {
+	queryParams := r.URL.Query()
+	limit, _ := strconv.Atoi(queryParams.Get("limit"))
+	offset, _ := strconv.Atoi(queryParams.Get("offset"))
+	filter := domain.TransferFilter{Limit: limit, Offset: offset}
+
+	if value := queryParams.Get("book_id"); value != "" {
+		bookID, err := uuid.Parse(value)
+		if err != nil {
+			return domain.TransferFilter{}, errors.New("book_id is not a valid UUID")
+		}
+		filter.BookID = &bookID
+	}
+	if value := queryParams.Get("branch_id"); value != "" {
+		branchID, err := uuid.Parse(value)
+		if err != nil {
+			return domain.TransferFilter{}, errors.New("branch_id is not a valid UUID")
+		}
+		filter.BranchID = &branchID
+	}
+	if value := queryParams.Get("status"); value != "" {
+		status := domain.TransferStatus(value)
+		filter.Status = &status
+	}
+	return filter, nil
+}
+
+// parseIDFromPath extracts and parses the branch, book or transfer ID from the request path.
+func parseIDFromPath(r *http.Request) (uuid.UUID, error) {
+	return uuid.Parse(mux.Vars(r)["id"])
+}
@@ -0,0 +1,207 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=repository.go -destination=mocks/repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/bkiran6398/library/internal/branches/domain"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AdvanceTransfer mocks base method.
+func (m *MockRepository) AdvanceTransfer(ctx context.Context, transferID uuid.UUID, status domain.TransferStatus) (domain.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdvanceTransfer", ctx, transferID, status)
+	ret0, _ := ret[0].(domain.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdvanceTransfer indicates an expected call of AdvanceTransfer.
+func (mr *MockRepositoryMockRecorder) AdvanceTransfer(ctx, transferID, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdvanceTransfer", reflect.TypeOf((*MockRepository)(nil).AdvanceTransfer), ctx, transferID, status)
+}
+
+// Availability mocks base method.
+func (m *MockRepository) Availability(ctx context.Context, bookID uuid.UUID) ([]domain.BranchAvailability, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Availability", ctx, bookID)
+	ret0, _ := ret[0].([]domain.BranchAvailability)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Availability indicates an expected call of Availability.
+func (mr *MockRepositoryMockRecorder) Availability(ctx, bookID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Availability", reflect.TypeOf((*MockRepository)(nil).Availability), ctx, bookID)
+}
+
+// CreateBranch mocks base method.
+func (m *MockRepository) CreateBranch(ctx context.Context, branch domain.Branch) (domain.Branch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBranch", ctx, branch)
+	ret0, _ := ret[0].(domain.Branch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBranch indicates an expected call of CreateBranch.
+func (mr *MockRepositoryMockRecorder) CreateBranch(ctx, branch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBranch", reflect.TypeOf((*MockRepository)(nil).CreateBranch), ctx, branch)
+}
+
+// CreateTransfer mocks base method.
+func (m *MockRepository) CreateTransfer(ctx context.Context, transfer domain.Transfer) (domain.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransfer", ctx, transfer)
+	ret0, _ := ret[0].(domain.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTransfer indicates an expected call of CreateTransfer.
+func (mr *MockRepositoryMockRecorder) CreateTransfer(ctx, transfer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransfer", reflect.TypeOf((*MockRepository)(nil).CreateTransfer), ctx, transfer)
+}
+
+// DeleteBranch mocks base method.
+func (m *MockRepository) DeleteBranch(ctx context.Context, branchID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBranch", ctx, branchID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBranch indicates an expected call of DeleteBranch.
+func (mr *MockRepositoryMockRecorder) DeleteBranch(ctx, branchID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBranch", reflect.TypeOf((*MockRepository)(nil).DeleteBranch), ctx, branchID)
+}
+
+// GetBranch mocks base method.
+func (m *MockRepository) GetBranch(ctx context.Context, branchID uuid.UUID) (domain.Branch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBranch", ctx, branchID)
+	ret0, _ := ret[0].(domain.Branch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBranch indicates an expected call of GetBranch.
+func (mr *MockRepositoryMockRecorder) GetBranch(ctx, branchID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBranch", reflect.TypeOf((*MockRepository)(nil).GetBranch), ctx, branchID)
+}
+
+// GetTransfer mocks base method.
+func (m *MockRepository) GetTransfer(ctx context.Context, transferID uuid.UUID) (domain.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransfer", ctx, transferID)
+	ret0, _ := ret[0].(domain.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransfer indicates an expected call of GetTransfer.
+func (mr *MockRepositoryMockRecorder) GetTransfer(ctx, transferID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransfer", reflect.TypeOf((*MockRepository)(nil).GetTransfer), ctx, transferID)
+}
+
+// ListBranches mocks base method.
+func (m *MockRepository) ListBranches(ctx context.Context) ([]domain.Branch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBranches", ctx)
+	ret0, _ := ret[0].([]domain.Branch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBranches indicates an expected call of ListBranches.
+func (mr *MockRepositoryMockRecorder) ListBranches(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBranches", reflect.TypeOf((*MockRepository)(nil).ListBranches), ctx)
+}
+
+// ListTransfers mocks base method.
+func (m *MockRepository) ListTransfers(ctx context.Context, filter domain.TransferFilter) ([]domain.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransfers", ctx, filter)
+	ret0, _ := ret[0].([]domain.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransfers indicates an expected call of ListTransfers.
+func (mr *MockRepositoryMockRecorder) ListTransfers(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransfers", reflect.TypeOf((*MockRepository)(nil).ListTransfers), ctx, filter)
+}
+
+// SetHolding mocks base method.
+func (m *MockRepository) SetHolding(ctx context.Context, bookID, branchID uuid.UUID, copiesTotal int, copiesAvailable *int) (domain.Holding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHolding", ctx, bookID, branchID, copiesTotal, copiesAvailable)
+	ret0, _ := ret[0].(domain.Holding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetHolding indicates an expected call of SetHolding.
+func (mr *MockRepositoryMockRecorder) SetHolding(ctx, bookID, branchID, copiesTotal, copiesAvailable any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHolding", reflect.TypeOf((*MockRepository)(nil).SetHolding), ctx, bookID, branchID, copiesTotal, copiesAvailable)
+}
+
+// UpdateBranch mocks base method.
+func (m *MockRepository) UpdateBranch(ctx context.Context, branch domain.Branch) (domain.Branch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBranch", ctx, branch)
+	ret0, _ := ret[0].(domain.Branch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBranch indicates an expected call of UpdateBranch.
+func (mr *MockRepositoryMockRecorder) UpdateBranch(ctx, branch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBranch", reflect.TypeOf((*MockRepository)(nil).UpdateBranch), ctx, branch)
+}
@@ -0,0 +1,442 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bkiran6398/library/internal/branches/domain"
+	intErr "github.com/bkiran6398/library/internal/errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	branchColumns   = `id, code, name, created_at, updated_at`
+	transferColumns = `id, book_id, from_branch_id, to_branch_id, copies, status, requested_at, shipped_at, received_at, cancelled_at, updated_at`
+)
+
+// syncBookCopiesQuery recomputes the catalog-wide copy counts of book $1 from its holdings
+// and the copies in transit.
+const syncBookCopiesQuery = `
+UPDATE books SET
+	copies_total = totals.held + totals.in_transit,
+	copies_available = totals.available,
+	updated_at = NOW()
+FROM (
+	SELECT
+		COALESCE((SELECT SUM(copies_total) FROM branch_holdings WHERE book_id=$1), 0) AS held,
+		COALESCE((SELECT SUM(copies_available) FROM branch_holdings WHERE book_id=$1), 0) AS available,
+		COALESCE((SELECT SUM(copies) FROM transfers WHERE book_id=$1 AND status='in_transit'), 0) AS in_transit
+) AS totals
+WHERE books.id=$1;
+`
+
+// addCopiesQuery puts $3 copies of book $1 on the shelf at branch $2.
+const addCopiesQuery = `
+INSERT INTO branch_holdings (book_id, branch_id, copies_total, copies_available, updated_at)
+VALUES ($1,$2,$3,$3,NOW())
+ON CONFLICT (book_id, branch_id) DO UPDATE SET
+	copies_total = branch_holdings.copies_total + EXCLUDED.copies_total,
+	copies_available = branch_holdings.copies_available + EXCLUDED.copies_available,
+	updated_at = NOW();
+`
+
+// pgRepository is the PostgreSQL implementation of Repository.
+type pgRepository struct {
+	dbPool *pgxpool.Pool
+}
+
+// NewPgRepository creates a new PostgreSQL-based Repository implementation.
+// This constructor is the only place where consumers should depend on the concrete type.
+func NewPgRepository(dbPool *pgxpool.Pool) *pgRepository {
+	return &pgRepository{dbPool: dbPool}
+}
+
+func (repository *pgRepository) CreateBranch(ctx context.Context, branch domain.Branch) (domain.Branch, error) {
+	const insertQuery = `
+INSERT INTO branches (id, code, name, created_at, updated_at)
+VALUES ($1,$2,$3,NOW(),NOW())
+RETURNING created_at, updated_at;
+`
+	row := repository.dbPool.QueryRow(ctx, insertQuery, branch.ID, branch.Code, branch.Name)
+	if err := row.Scan(&branch.CreatedAt, &branch.UpdatedAt); err != nil {
+		if isPgErrorCode(err, "23505") {
+			return domain.Branch{}, fmt.Errorf("%w: a branch with this code already exists", intErr.ErrConflict)
+		}
+		return domain.Branch{}, fmt.Errorf("insert branch: %w", err)
+	}
+	return branch, nil
+}
+
+// isPgErrorCode checks if the error is a PostgreSQL error with the given SQLSTATE code.
+func isPgErrorCode(err error, code string) bool {
+	var pgError *pgconn.PgError
+	return errors.As(err, &pgError) && pgError.Code == code
+}
+
+func (repository *pgRepository) GetBranch(ctx context.Context, branchID uuid.UUID) (domain.Branch, error) {
+	const selectQuery = `SELECT ` + branchColumns + ` FROM branches WHERE id=$1;`
+	branch, err := scanBranch(repository.dbPool.QueryRow(ctx, selectQuery, branchID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Branch{}, intErr.ErrNotFound
+		}
+		return domain.Branch{}, fmt.Errorf("get branch: %w", err)
+	}
+	return branch, nil
+}
+
+func (repository *pgRepository) UpdateBranch(ctx context.Context, branch domain.Branch) (domain.Branch, error) {
+	const updateQuery = `
+UPDATE branches SET code=$2, name=$3, updated_at=NOW()
+WHERE id=$1
+RETURNING ` + branchColumns + `;`
+	updated, err := scanBranch(repository.dbPool.QueryRow(ctx, updateQuery, branch.ID, branch.Code, branch.Name))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Branch{}, intErr.ErrNotFound
+		}
+		if isPgErrorCode(err, "23505") {
+			return domain.Branch{}, fmt.Errorf("%w: a branch with this code already exists", intErr.ErrConflict)
+		}
+		return domain.Branch{}, fmt.Errorf("update branch: %w", err)
+	}
+	return updated, nil
+}
+
+func (repository *pgRepository) DeleteBranch(ctx context.Context, branchID uuid.UUID) error {
+	const deleteQuery = `DELETE FROM branches WHERE id=$1;`
+	result, err := repository.dbPool.Exec(ctx, deleteQuery, branchID)
+	if err != nil {
+		if isPgErrorCode(err, "23503") {
+			return fmt.Errorf("%w: the branch still has holdings or transfers", intErr.ErrConflict)
+		}
+		return fmt.Errorf("delete branch: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return intErr.ErrNotFound
+	}
+	return nil
+}
+
+func (repository *pgRepository) ListBranches(ctx context.Context) ([]domain.Branch, error) {
+	const selectQuery = `SELECT ` + branchColumns + ` FROM branches ORDER BY lower(name), id;`
+	rows, err := repository.dbPool.Query(ctx, selectQuery)
+	if err != nil {
+		return nil, fmt.Errorf("list branches: %w", err)
+	}
+	defer rows.Close()
+
+	branches := []domain.Branch{}
+	for rows.Next() {
+		branch, err := scanBranch(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan branch row: %w", err)
+		}
+		branches = append(branches, branch)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return branches, nil
+}
+
+func (repository *pgRepository) Availability(ctx context.Context, bookID uuid.UUID) ([]domain.BranchAvailability, error) {
+	if err := lockBook(ctx, repository.dbPool, bookID, ""); err != nil {
+		return nil, err
+	}
+
+	const selectQuery = `
+SELECT branches.id, branches.code, branches.name,
+	COALESCE(holdings.copies_total, 0), COALESCE(holdings.copies_available, 0), COALESCE(incoming.copies, 0)
+FROM branches
+LEFT JOIN branch_holdings AS holdings ON holdings.branch_id = branches.id AND holdings.book_id = $1
+LEFT JOIN (
+	SELECT to_branch_id, SUM(copies) AS copies
+	FROM transfers WHERE book_id = $1 AND status = 'in_transit'
+	GROUP BY to_branch_id
+) AS incoming ON incoming.to_branch_id = branches.id
+ORDER BY lower(branches.name), branches.id;
+`
+	rows, err := repository.dbPool.Query(ctx, selectQuery, bookID)
+	if err != nil {
+		return nil, fmt.Errorf("book availability: %w", err)
+	}
+	defer rows.Close()
+
+	availability := []domain.BranchAvailability{}
+	for rows.Next() {
+		var branch domain.BranchAvailability
+		if err := rows.Scan(&branch.BranchID, &branch.Code, &branch.Name, &branch.CopiesTotal, &branch.CopiesAvailable, &branch.CopiesIncoming); err != nil {
+			return nil, fmt.Errorf("scan availability row: %w", err)
+		}
+		availability = append(availability, branch)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return availability, nil
+}
+
+func (repository *pgRepository) SetHolding(ctx context.Context, bookID, branchID uuid.UUID, copiesTotal int, copiesAvailable *int) (domain.Holding, error) {
+	tx, err := repository.dbPool.Begin(ctx)
+	if err != nil {
+		return domain.Holding{}, fmt.Errorf("begin set holding: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	const currentCopiesQuery = `
+SELECT copies_total, copies_available, EXISTS (SELECT 1 FROM branch_holdings WHERE book_id = books.id)
+FROM books WHERE id=$1 FOR UPDATE;
+`
+	var bookCopiesTotal, bookCopiesAvailable int
+	var branchManaged bool
+	if err := tx.QueryRow(ctx, currentCopiesQuery, bookID).Scan(&bookCopiesTotal, &bookCopiesAvailable, &branchManaged); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Holding{}, intErr.ErrNotFound
+		}
+		return domain.Holding{}, fmt.Errorf("get book copies: %w", err)
+	}
+
+	const upsertQuery = `
+INSERT INTO branch_holdings (book_id, branch_id, copies_total, copies_available, updated_at)
+VALUES ($1,$2,$3,COALESCE($4::int, $3),NOW())
+ON CONFLICT (book_id, branch_id) DO UPDATE SET
+	copies_total = EXCLUDED.copies_total,
+	copies_available = COALESCE($4::int, GREATEST(0, branch_holdings.copies_available + EXCLUDED.copies_total - branch_holdings.copies_total)),
+	updated_at = NOW()
+RETURNING book_id, branch_id, copies_total, copies_available, updated_at;
+`
+	var holding domain.Holding
+	err = tx.QueryRow(ctx, upsertQuery, bookID, branchID, copiesTotal, copiesAvailable).
+		Scan(&holding.BookID, &holding.BranchID, &holding.CopiesTotal, &holding.CopiesAvailable, &holding.UpdatedAt)
+	if err != nil {
+		if isPgErrorCode(err, "23503") {
+			return domain.Holding{}, intErr.ErrNotFound
+		}
+		if isPgErrorCode(err, "23514") {
+			return domain.Holding{}, fmt.Errorf("%w: copies_available cannot exceed copies_total", intErr.ErrBadRequest)
+		}
+		return domain.Holding{}, fmt.Errorf("set holding: %w", err)
+	}
+	// The first holding replaces the book's catalog-wide counts, so it must account for
+	// every copy and every loan; otherwise copies and loans would silently disappear.
+	bookCopiesOnLoan := bookCopiesTotal - bookCopiesAvailable
+	if !branchManaged && (holding.CopiesTotal < bookCopiesTotal || holding.CopiesTotal-holding.CopiesAvailable < bookCopiesOnLoan) {
+		return domain.Holding{}, fmt.Errorf("%w: the first holding must cover the book's %d copies, %d of them on loan", intErr.ErrConflict, bookCopiesTotal, bookCopiesOnLoan)
+	}
+	if _, err := tx.Exec(ctx, syncBookCopiesQuery, bookID); err != nil {
+		return domain.Holding{}, fmt.Errorf("sync book copies: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.Holding{}, fmt.Errorf("commit set holding: %w", err)
+	}
+	return holding, nil
+}
+
+func (repository *pgRepository) CreateTransfer(ctx context.Context, transfer domain.Transfer) (domain.Transfer, error) {
+	tx, err := repository.dbPool.Begin(ctx)
+	if err != nil {
+		return domain.Transfer{}, fmt.Errorf("begin create transfer: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := lockBook(ctx, tx, transfer.BookID, " FOR UPDATE"); err != nil {
+		if errors.Is(err, intErr.ErrNotFound) {
+			return domain.Transfer{}, fmt.Errorf("%w: unknown book", intErr.ErrBadRequest)
+		}
+		return domain.Transfer{}, err
+	}
+
+	insertQuery := `
+INSERT INTO transfers (id, book_id, from_branch_id, to_branch_id, copies, status, requested_at, updated_at)
+SELECT $1,$2,$3,$4,$5,'` + string(domain.TransferRequested) + `',NOW(),NOW()
+WHERE EXISTS (
+	SELECT 1 FROM branch_holdings WHERE book_id=$2 AND branch_id=$3 AND copies_available >= $5
+)
+RETURNING ` + transferColumns + `;`
+	created, err := scanTransfer(tx.QueryRow(ctx, insertQuery, transfer.ID, transfer.BookID, transfer.FromBranchID, transfer.ToBranchID, transfer.Copies))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Transfer{}, fmt.Errorf("%w: the source branch does not have %d copies available", intErr.ErrConflict, transfer.Copies)
+		}
+		if isPgErrorCode(err, "23503") {
+			return domain.Transfer{}, fmt.Errorf("%w: unknown destination branch", intErr.ErrBadRequest)
+		}
+		return domain.Transfer{}, fmt.Errorf("insert transfer: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.Transfer{}, fmt.Errorf("commit create transfer: %w", err)
+	}
+	return created, nil
+}
+
+func (repository *pgRepository) GetTransfer(ctx context.Context, transferID uuid.UUID) (domain.Transfer, error) {
+	const selectQuery = `SELECT ` + transferColumns + ` FROM transfers WHERE id=$1;`
+	transfer, err := scanTransfer(repository.dbPool.QueryRow(ctx, selectQuery, transferID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Transfer{}, intErr.ErrNotFound
+		}
+		return domain.Transfer{}, fmt.Errorf("get transfer: %w", err)
+	}
+	return transfer, nil
+}
+
+func (repository *pgRepository) ListTransfers(ctx context.Context, filter domain.TransferFilter) ([]domain.Transfer, error) {
+	var conditions []string
+	var queryArguments []any
+	if filter.BookID != nil {
+		queryArguments = append(queryArguments, *filter.BookID)
+		conditions = append(conditions, fmt.Sprintf("book_id = $%d", len(queryArguments)))
+	}
+	if filter.BranchID != nil {
+		queryArguments = append(queryArguments, *filter.BranchID)
+		conditions = append(conditions, fmt.Sprintf("(from_branch_id = $%d OR to_branch_id = $%d)", len(queryArguments), len(queryArguments)))
+	}
+	if filter.Status != nil {
+		queryArguments = append(queryArguments, string(*filter.Status))
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(queryArguments)))
+	}
+
+	query := `SELECT ` + transferColumns + ` FROM transfers`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY requested_at DESC, id"
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", filter.Limit)
+	}
+	if filter.Offset > 0 {
+		query += fmt.Sprintf(" OFFSET %d", filter.Offset)
+	}
+
+	rows, err := repository.dbPool.Query(ctx, query, queryArguments...)
+	if err != nil {
+		return nil, fmt.Errorf("list transfers: %w", err)
+	}
+	defer rows.Close()
+
+	transfers := []domain.Transfer{}
+	for rows.Next() {
+		transfer, err := scanTransfer(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan transfer row: %w", err)
+		}
+		transfers = append(transfers, transfer)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return transfers, nil
+}
+
+func (repository *pgRepository) AdvanceTransfer(ctx context.Context, transferID uuid.UUID, status domain.TransferStatus) (domain.Transfer, error) {
+	tx, err := repository.dbPool.Begin(ctx)
+	if err != nil {
+		return domain.Transfer{}, fmt.Errorf("begin advance transfer: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	const selectQuery = `SELECT ` + transferColumns + ` FROM transfers WHERE id=$1 FOR UPDATE;`
+	transfer, err := scanTransfer(tx.QueryRow(ctx, selectQuery, transferID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Transfer{}, intErr.ErrNotFound
+		}
+		return domain.Transfer{}, fmt.Errorf("get transfer: %w", err)
+	}
+	if !domain.CanTransition(transfer.Status, status) {
+		return domain.Transfer{}, fmt.Errorf("%w: a %s transfer cannot become %s", intErr.ErrConflict, transfer.Status, status)
+	}
+	if err := lockBook(ctx, tx, transfer.BookID, " FOR UPDATE"); err != nil {
+		return domain.Transfer{}, err
+	}
+
+	switch {
+	case status == domain.TransferInTransit:
+		const shipQuery = `
+UPDATE branch_holdings SET
+	copies_total = copies_total - $3,
+	copies_available = copies_available - $3,
+	updated_at = NOW()
+WHERE book_id=$1 AND branch_id=$2 AND copies_available >= $3;
+`
+		result, err := tx.Exec(ctx, shipQuery, transfer.BookID, transfer.FromBranchID, transfer.Copies)
+		if err != nil {
+			return domain.Transfer{}, fmt.Errorf("ship transfer: %w", err)
+		}
+		if result.RowsAffected() == 0 {
+			return domain.Transfer{}, fmt.Errorf("%w: the source branch no longer has %d copies available", intErr.ErrConflict, transfer.Copies)
+		}
+	case status == domain.TransferReceived:
+		if _, err := tx.Exec(ctx, addCopiesQuery, transfer.BookID, transfer.ToBranchID, transfer.Copies); err != nil {
+			return domain.Transfer{}, fmt.Errorf("receive transfer: %w", err)
+		}
+	case status == domain.TransferCancelled && transfer.Status == domain.TransferInTransit:
+		if _, err := tx.Exec(ctx, addCopiesQuery, transfer.BookID, transfer.FromBranchID, transfer.Copies); err != nil {
+			return domain.Transfer{}, fmt.Errorf("return transfer: %w", err)
+		}
+	}
+
+	updateQuery := `
+UPDATE transfers SET
+	status = $2,
+	shipped_at = CASE WHEN $2 = '` + string(domain.TransferInTransit) + `' THEN NOW() ELSE shipped_at END,
+	received_at = CASE WHEN $2 = '` + string(domain.TransferReceived) + `' THEN NOW() ELSE received_at END,
+	cancelled_at = CASE WHEN $2 = '` + string(domain.TransferCancelled) + `' THEN NOW() ELSE cancelled_at END,
+	updated_at = NOW()
+WHERE id=$1
+RETURNING ` + transferColumns + `;`
+	advanced, err := scanTransfer(tx.QueryRow(ctx, updateQuery, transferID, string(status)))
+	if err != nil {
+		return domain.Transfer{}, fmt.Errorf("update transfer: %w", err)
+	}
+	if _, err := tx.Exec(ctx, syncBookCopiesQuery, transfer.BookID); err != nil {
+		return domain.Transfer{}, fmt.Errorf("sync book copies: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.Transfer{}, fmt.Errorf("commit advance transfer: %w", err)
+	}
+	return advanced, nil
+}
+
+// querier is satisfied by both the pool and a transaction.
+type querier interface {
+	QueryRow(ctx context.Context, query string, args ...any) pgx.Row
+}
+
+// lockBook returns ErrNotFound unless the book exists. lock is appended to the query, so
+// " FOR UPDATE" serialises changes to the book's copies.
+func lockBook(ctx context.Context, db querier, bookID uuid.UUID, lock string) error {
+	var found uuid.UUID
+	if err := db.QueryRow(ctx, `SELECT id FROM books WHERE id=$1`+lock+`;`, bookID).Scan(&found); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return intErr.ErrNotFound
+		}
+		return fmt.Errorf("get book: %w", err)
+	}
+	return nil
+}
+
+// scanBranch scans a single row into a Branch entity.
+func scanBranch(row pgx.Row) (domain.Branch, error) {
+	var branch domain.Branch
+	err := row.Scan(&branch.ID, &branch.Code, &branch.Name, &branch.CreatedAt, &branch.UpdatedAt)
+	return branch, err
+}
+
+// scanTransfer scans a single row into a Transfer entity.
+func scanTransfer(row pgx.Row) (domain.Transfer, error) {
+	var transfer domain.Transfer
+	err := row.Scan(&transfer.ID, &transfer.BookID, &transfer.FromBranchID, &transfer.ToBranchID, &transfer.Copies, &transfer.Status,
+		&transfer.RequestedAt, &transfer.ShippedAt, &transfer.ReceivedAt, &transfer.CancelledAt, &transfer.UpdatedAt)
+	return transfer, err
+}
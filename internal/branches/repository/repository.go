@@ -0,0 +1,37 @@
+//go:generate mockgen -source=repository.go -destination=mocks/repository.go -package=mocks
+package repository
+
+import (
+	"context"
+
+	"github.com/bkiran6398/library/internal/branches/domain"
+	"github.com/google/uuid"
+)
+
+// Repository defines the interface for branch, holding and transfer data access operations.
+// Every write to holdings or transfers also recomputes the book's catalog-wide copy counts:
+// copies_total is the copies held at branches plus those in transit, and copies_available
+// the copies available at branches.
+// Consumers should depend on this interface, not on concrete implementations.
+type Repository interface {
+	CreateBranch(ctx context.Context, branch domain.Branch) (domain.Branch, error)
+	GetBranch(ctx context.Context, branchID uuid.UUID) (domain.Branch, error)
+	UpdateBranch(ctx context.Context, branch domain.Branch) (domain.Branch, error)
+	// DeleteBranch removes a branch. It fails with ErrConflict while it has holdings or transfers.
+	DeleteBranch(ctx context.Context, branchID uuid.UUID) error
+	ListBranches(ctx context.Context) ([]domain.Branch, error)
+	// Availability returns the copies of a book at every branch, including branches without any.
+	Availability(ctx context.Context, bookID uuid.UUID) ([]domain.BranchAvailability, error)
+	// SetHolding sets the copies of a book at a branch. A nil copiesAvailable keeps the copies
+	// on loan at the branch.
+	SetHolding(ctx context.Context, bookID, branchID uuid.UUID, copiesTotal int, copiesAvailable *int) (domain.Holding, error)
+	// CreateTransfer records a requested transfer. It fails with ErrConflict when the source
+	// branch does not have enough copies available.
+	CreateTransfer(ctx context.Context, transfer domain.Transfer) (domain.Transfer, error)
+	GetTransfer(ctx context.Context, transferID uuid.UUID) (domain.Transfer, error)
+	ListTransfers(ctx context.Context, filter domain.TransferFilter) ([]domain.Transfer, error)
+	// AdvanceTransfer moves a transfer to status and moves its copies accordingly, in one
+	// transaction. It fails with ErrConflict when the transition is not allowed or the source
+	// branch no longer has the copies to ship.
+	AdvanceTransfer(ctx context.Context, transferID uuid.UUID, status domain.TransferStatus) (domain.Transfer, error)
+}
@@ -0,0 +1,237 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=service.go -destination=mocks/service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/bkiran6398/library/internal/branches/domain"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// Availability mocks base method.
+func (m *MockService) Availability(ctx context.Context, bookID uuid.UUID) ([]domain.BranchAvailability, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Availability", ctx, bookID)
+	ret0, _ := ret[0].([]domain.BranchAvailability)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Availability indicates an expected call of Availability.
+func (mr *MockServiceMockRecorder) Availability(ctx, bookID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Availability", reflect.TypeOf((*MockService)(nil).Availability), ctx, bookID)
+}
+
+// CancelTransfer mocks base method.
+func (m *MockService) CancelTransfer(ctx context.Context, transferID uuid.UUID) (domain.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelTransfer", ctx, transferID)
+	ret0, _ := ret[0].(domain.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelTransfer indicates an expected call of CancelTransfer.
+func (mr *MockServiceMockRecorder) CancelTransfer(ctx, transferID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelTransfer", reflect.TypeOf((*MockService)(nil).CancelTransfer), ctx, transferID)
+}
+
+// CreateBranch mocks base method.
+func (m *MockService) CreateBranch(ctx context.Context, createRequest domain.CreateBranchRequest) (domain.Branch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBranch", ctx, createRequest)
+	ret0, _ := ret[0].(domain.Branch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBranch indicates an expected call of CreateBranch.
+func (mr *MockServiceMockRecorder) CreateBranch(ctx, createRequest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBranch", reflect.TypeOf((*MockService)(nil).CreateBranch), ctx, createRequest)
+}
+
+// CreateTransfer mocks base method.
+func (m *MockService) CreateTransfer(ctx context.Context, createRequest domain.CreateTransferRequest) (domain.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransfer", ctx, createRequest)
+	ret0, _ := ret[0].(domain.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTransfer indicates an expected call of CreateTransfer.
+func (mr *MockServiceMockRecorder) CreateTransfer(ctx, createRequest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransfer", reflect.TypeOf((*MockService)(nil).CreateTransfer), ctx, createRequest)
+}
+
+// DeleteBranch mocks base method.
+func (m *MockService) DeleteBranch(ctx context.Context, branchID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBranch", ctx, branchID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBranch indicates an expected call of DeleteBranch.
+func (mr *MockServiceMockRecorder) DeleteBranch(ctx, branchID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBranch", reflect.TypeOf((*MockService)(nil).DeleteBranch), ctx, branchID)
+}
+
+// GetBranch mocks base method.
+func (m *MockService) GetBranch(ctx context.Context, branchID uuid.UUID) (domain.Branch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBranch", ctx, branchID)
+	ret0, _ := ret[0].(domain.Branch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBranch indicates an expected call of GetBranch.
+func (mr *MockServiceMockRecorder) GetBranch(ctx, branchID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBranch", reflect.TypeOf((*MockService)(nil).GetBranch), ctx, branchID)
+}
+
+// GetTransfer mocks base method.
+func (m *MockService) GetTransfer(ctx context.Context, transferID uuid.UUID) (domain.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransfer", ctx, transferID)
+	ret0, _ := ret[0].(domain.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransfer indicates an expected call of GetTransfer.
+func (mr *MockServiceMockRecorder) GetTransfer(ctx, transferID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransfer", reflect.TypeOf((*MockService)(nil).GetTransfer), ctx, transferID)
+}
+
+// ListBranches mocks base method.
+func (m *MockService) ListBranches(ctx context.Context) ([]domain.Branch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBranches", ctx)
+	ret0, _ := ret[0].([]domain.Branch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBranches indicates an expected call of ListBranches.
+func (mr *MockServiceMockRecorder) ListBranches(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBranches", reflect.TypeOf((*MockService)(nil).ListBranches), ctx)
+}
+
+// ListTransfers mocks base method.
+func (m *MockService) ListTransfers(ctx context.Context, filter domain.TransferFilter) ([]domain.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransfers", ctx, filter)
+	ret0, _ := ret[0].([]domain.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransfers indicates an expected call of ListTransfers.
+func (mr *MockServiceMockRecorder) ListTransfers(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransfers", reflect.TypeOf((*MockService)(nil).ListTransfers), ctx, filter)
+}
+
+// ReceiveTransfer mocks base method.
+func (m *MockService) ReceiveTransfer(ctx context.Context, transferID uuid.UUID) (domain.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReceiveTransfer", ctx, transferID)
+	ret0, _ := ret[0].(domain.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReceiveTransfer indicates an expected call of ReceiveTransfer.
+func (mr *MockServiceMockRecorder) ReceiveTransfer(ctx, transferID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceiveTransfer", reflect.TypeOf((*MockService)(nil).ReceiveTransfer), ctx, transferID)
+}
+
+// SetHolding mocks base method.
+func (m *MockService) SetHolding(ctx context.Context, bookID, branchID uuid.UUID, setRequest domain.SetHoldingRequest) (domain.Holding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHolding", ctx, bookID, branchID, setRequest)
+	ret0, _ := ret[0].(domain.Holding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetHolding indicates an expected call of SetHolding.
+func (mr *MockServiceMockRecorder) SetHolding(ctx, bookID, branchID, setRequest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHolding", reflect.TypeOf((*MockService)(nil).SetHolding), ctx, bookID, branchID, setRequest)
+}
+
+// ShipTransfer mocks base method.
+func (m *MockService) ShipTransfer(ctx context.Context, transferID uuid.UUID) (domain.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShipTransfer", ctx, transferID)
+	ret0, _ := ret[0].(domain.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ShipTransfer indicates an expected call of ShipTransfer.
+func (mr *MockServiceMockRecorder) ShipTransfer(ctx, transferID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShipTransfer", reflect.TypeOf((*MockService)(nil).ShipTransfer), ctx, transferID)
+}
+
+// UpdateBranch mocks base method.
+func (m *MockService) UpdateBranch(ctx context.Context, branchID uuid.UUID, updateRequest domain.UpdateBranchRequest) (domain.Branch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBranch", ctx, branchID, updateRequest)
+	ret0, _ := ret[0].(domain.Branch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBranch indicates an expected call of UpdateBranch.
+func (mr *MockServiceMockRecorder) UpdateBranch(ctx, branchID, updateRequest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBranch", reflect.TypeOf((*MockService)(nil).UpdateBranch), ctx, branchID, updateRequest)
+}
@@ -0,0 +1,30 @@
+//go:generate mockgen -source=service.go -destination=mocks/service.go -package=mocks
+package service
+
+import (
+	"context"
+
+	"github.com/bkiran6398/library/internal/branches/domain"
+	"github.com/google/uuid"
+)
+
+// Service defines the interface for branches, per-branch holdings and inter-branch transfers.
+// Consumers should depend on this interface, not on concrete implementations.
+type Service interface {
+	CreateBranch(ctx context.Context, createRequest domain.CreateBranchRequest) (domain.Branch, error)
+	GetBranch(ctx context.Context, branchID uuid.UUID) (domain.Branch, error)
+	UpdateBranch(ctx context.Context, branchID uuid.UUID, updateRequest domain.UpdateBranchRequest) (domain.Branch, error)
+	DeleteBranch(ctx context.Context, branchID uuid.UUID) error
+	ListBranches(ctx context.Context) ([]domain.Branch, error)
+	Availability(ctx context.Context, bookID uuid.UUID) ([]domain.BranchAvailability, error)
+	SetHolding(ctx context.Context, bookID, branchID uuid.UUID, setRequest domain.SetHoldingRequest) (domain.Holding, error)
+	CreateTransfer(ctx context.Context, createRequest domain.CreateTransferRequest) (domain.Transfer, error)
+	GetTransfer(ctx context.Context, transferID uuid.UUID) (domain.Transfer, error)
+	ListTransfers(ctx context.Context, filter domain.TransferFilter) ([]domain.Transfer, error)
+	// ShipTransfer takes the copies off the source branch's shelf; they are in transit.
+	ShipTransfer(ctx context.Context, transferID uuid.UUID) (domain.Transfer, error)
+	// ReceiveTransfer makes the copies available at the destination branch.
+	ReceiveTransfer(ctx context.Context, transferID uuid.UUID) (domain.Transfer, error)
+	// CancelTransfer calls the transfer off; copies already shipped return to the source branch.
+	CancelTransfer(ctx context.Context, transferID uuid.UUID) (domain.Transfer, error)
+}
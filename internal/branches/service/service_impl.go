@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/bkiran6398/library/internal/branches/domain"
+	"github.com/bkiran6398/library/internal/branches/repository"
+	intErr "github.com/bkiran6398/library/internal/errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+const (
+	createTimeout   = 5 * time.Second
+	getTimeout      = 3 * time.Second
+	updateTimeout   = 5 * time.Second
+	deleteTimeout   = 5 * time.Second
+	listTimeout     = 10 * time.Second
+	transferTimeout = 5 * time.Second
+)
+
+// branchCodePattern matches the codes accepted by the branches table.
+var branchCodePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// service is the implementation of Service.
+type service struct {
+	repository repository.Repository
+	validator  *validator.Validate
+}
+
+// NewService creates a new Service implementation.
+// This constructor is the only place where consumers should depend on the concrete type.
+func NewService(repository repository.Repository) Service {
+	return &service{
+		repository: repository,
+		validator:  validator.New(),
+	}
+}
+
+func (serviceInstance *service) CreateBranch(ctx context.Context, createRequest domain.CreateBranchRequest) (domain.Branch, error) {
+	createRequest.Code = normalizeCode(createRequest.Code)
+	createRequest.Name = strings.TrimSpace(createRequest.Name)
+	if err := serviceInstance.validateBranch(createRequest, createRequest.Code); err != nil {
+		return domain.Branch{}, err
+	}
+
+	branch := domain.Branch{ID: uuid.New(), Code: createRequest.Code, Name: createRequest.Name}
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+	return serviceInstance.repository.CreateBranch(ctxWithTimeout, branch)
+}
+
+func (serviceInstance *service) GetBranch(ctx context.Context, branchID uuid.UUID) (domain.Branch, error) {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, getTimeout)
+	defer cancel()
+	return serviceInstance.repository.GetBranch(ctxWithTimeout, branchID)
+}
+
+func (serviceInstance *service) UpdateBranch(ctx context.Context, branchID uuid.UUID, updateRequest domain.UpdateBranchRequest) (domain.Branch, error) {
+	updateRequest.Code = normalizeCode(updateRequest.Code)
+	updateRequest.Name = strings.TrimSpace(updateRequest.Name)
+	if err := serviceInstance.validateBranch(updateRequest, updateRequest.Code); err != nil {
+		return domain.Branch{}, err
+	}
+
+	branch := domain.Branch{ID: branchID, Code: updateRequest.Code, Name: updateRequest.Name}
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+	return serviceInstance.repository.UpdateBranch(ctxWithTimeout, branch)
+}
+
+func (serviceInstance *service) DeleteBranch(ctx context.Context, branchID uuid.UUID) error {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+	return serviceInstance.repository.DeleteBranch(ctxWithTimeout, branchID)
+}
+
+func (serviceInstance *service) ListBranches(ctx context.Context) ([]domain.Branch, error) {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, listTimeout)
+	defer cancel()
+	return serviceInstance.repository.ListBranches(ctxWithTimeout)
+}
+
+func (serviceInstance *service) Availability(ctx context.Context, bookID uuid.UUID) ([]domain.BranchAvailability, error) {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, getTimeout)
+	defer cancel()
+	return serviceInstance.repository.Availability(ctxWithTimeout, bookID)
+}
+
+func (serviceInstance *service) SetHolding(ctx context.Context, bookID, branchID uuid.UUID, setRequest domain.SetHoldingRequest) (domain.Holding, error) {
+	if err := serviceInstance.validator.Struct(setRequest); err != nil {
+		return domain.Holding{}, fmt.Errorf("%w: %v", intErr.ErrBadRequest, err)
+	}
+
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+	return serviceInstance.repository.SetHolding(ctxWithTimeout, bookID, branchID, setRequest.CopiesTotal, setRequest.CopiesAvailable)
+}
+
+func (serviceInstance *service) CreateTransfer(ctx context.Context, createRequest domain.CreateTransferRequest) (domain.Transfer, error) {
+	if err := serviceInstance.validator.Struct(createRequest); err != nil {
+		return domain.Transfer{}, fmt.Errorf("%w: %v", intErr.ErrBadRequest, err)
+	}
+	if createRequest.FromBranchID == createRequest.ToBranchID {
+		return domain.Transfer{}, fmt.Errorf("%w: a transfer needs two different branches", intErr.ErrBadRequest)
+	}
+
+	transfer := domain.Transfer{
+		ID:           uuid.New(),
+		BookID:       createRequest.BookID,
+		FromBranchID: createRequest.FromBranchID,
+		ToBranchID:   createRequest.ToBranchID,
+		Copies:       createRequest.Copies,
+		Status:       domain.TransferRequested,
+	}
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+	return serviceInstance.repository.CreateTransfer(ctxWithTimeout, transfer)
+}
+
+func (serviceInstance *service) GetTransfer(ctx context.Context, transferID uuid.UUID) (domain.Transfer, error) {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, getTimeout)
+	defer cancel()
+	return serviceInstance.repository.GetTransfer(ctxWithTimeout, transferID)
+}
+
+func (serviceInstance *service) ListTransfers(ctx context.Context, filter domain.TransferFilter) ([]domain.Transfer, error) {
+	if filter.Status != nil {
+		switch *filter.Status {
+		case domain.TransferRequested, domain.TransferInTransit, domain.TransferReceived, domain.TransferCancelled:
+		default:
+			return nil, fmt.Errorf("%w: unknown transfer status %q", intErr.ErrBadRequest, *filter.Status)
+		}
+	}
+
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, listTimeout)
+	defer cancel()
+	return serviceInstance.repository.ListTransfers(ctxWithTimeout, filter)
+}
+
+func (serviceInstance *service) ShipTransfer(ctx context.Context, transferID uuid.UUID) (domain.Transfer, error) {
+	return serviceInstance.advanceTransfer(ctx, transferID, domain.TransferInTransit)
+}
+
+func (serviceInstance *service) ReceiveTransfer(ctx context.Context, transferID uuid.UUID) (domain.Transfer, error) {
+	return serviceInstance.advanceTransfer(ctx, transferID, domain.TransferReceived)
+}
+
+func (serviceInstance *service) CancelTransfer(ctx context.Context, transferID uuid.UUID) (domain.Transfer, error) {
+	return serviceInstance.advanceTransfer(ctx, transferID, domain.TransferCancelled)
+}
+
+func (serviceInstance *service) advanceTransfer(ctx context.Context, transferID uuid.UUID, status domain.TransferStatus) (domain.Transfer, error) {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, transferTimeout)
+	defer cancel()
+	return serviceInstance.repository.AdvanceTransfer(ctxWithTimeout, transferID, status)
+}
+
+// validateBranch validates a create or update request and its normalized code.
+func (serviceInstance *service) validateBranch(request any, code string) error {
+	if err := serviceInstance.validator.Struct(request); err != nil {
+		return fmt.Errorf("%w: %v", intErr.ErrBadRequest, err)
+	}
+	if !branchCodePattern.MatchString(code) {
+		return fmt.Errorf("%w: branch code may only contain lower-case letters, digits and dashes", intErr.ErrBadRequest)
+	}
+	return nil
+}
+
+// normalizeCode trims and lower-cases a branch code, so "Central" and "central" are the same.
+func normalizeCode(code string) string {
+	return strings.ToLower(strings.TrimSpace(code))
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bkiran6398/library/internal/branches/domain"
+	"github.com/bkiran6398/library/internal/branches/repository/mocks"
+	intErr "github.com/bkiran6398/library/internal/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateBranch_NormalizesCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	service := NewService(mockRepo)
+
+	mockRepo.EXPECT().
+		CreateBranch(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, branch domain.Branch) (domain.Branch, error) {
+			require.NotEqual(t, uuid.Nil, branch.ID)
+			require.Equal(t, "east-side", branch.Code)
+			require.Equal(t, "Eastside", branch.Name)
+			return branch, nil
+		}).
+		Times(1)
+
+	_, err := service.CreateBranch(context.Background(), domain.CreateBranchRequest{Code: " East-Side ", Name: " Eastside"})
+	require.NoError(t, err)
+
+	_, err = service.CreateBranch(context.Background(), domain.CreateBranchRequest{Code: "east side", Name: "Eastside"})
+	require.ErrorIs(t, err, intErr.ErrBadRequest)
+}
+
+func TestSetHolding_RejectsMoreAvailableThanTotal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	service := NewService(mockRepo)
+
+	bookID, branchID := uuid.New(), uuid.New()
+	available := 3
+	_, err := service.SetHolding(context.Background(), bookID, branchID, domain.SetHoldingRequest{CopiesTotal: 2, CopiesAvailable: &available})
+	require.ErrorIs(t, err, intErr.ErrBadRequest)
+
+	holding := domain.Holding{BookID: bookID, BranchID: branchID, CopiesTotal: 2, CopiesAvailable: 2}
+	mockRepo.EXPECT().SetHolding(gomock.Any(), bookID, branchID, 2, nil).Return(holding, nil).Times(1)
+	got, err := service.SetHolding(context.Background(), bookID, branchID, domain.SetHoldingRequest{CopiesTotal: 2})
+	require.NoError(t, err)
+	require.Equal(t, holding, got)
+}
+
+func TestCreateTransfer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	service := NewService(mockRepo)
+
+	bookID, central, eastside := uuid.New(), uuid.New(), uuid.New()
+	_, err := service.CreateTransfer(context.Background(), domain.CreateTransferRequest{BookID: bookID, FromBranchID: central, ToBranchID: central, Copies: 1})
+	require.ErrorIs(t, err, intErr.ErrBadRequest)
+
+	_, err = service.CreateTransfer(context.Background(), domain.CreateTransferRequest{BookID: bookID, FromBranchID: central, ToBranchID: eastside})
+	require.ErrorIs(t, err, intErr.ErrBadRequest)
+
+	mockRepo.EXPECT().
+		CreateTransfer(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, transfer domain.Transfer) (domain.Transfer, error) {
+			require.NotEqual(t, uuid.Nil, transfer.ID)
+			require.Equal(t, domain.TransferRequested, transfer.Status)
+			require.Equal(t, 2, transfer.Copies)
+			return transfer, nil
+		}).
+		Times(1)
+	_, err = service.CreateTransfer(context.Background(), domain.CreateTransferRequest{BookID: bookID, FromBranchID: central, ToBranchID: eastside, Copies: 2})
+	require.NoError(t, err)
+}
+
+func TestTransferLifecycle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	service := NewService(mockRepo)
+
+	transferID := uuid.New()
+	gomock.InOrder(
+		mockRepo.EXPECT().AdvanceTransfer(gomock.Any(), transferID, domain.TransferInTransit).Return(domain.Transfer{ID: transferID, Status: domain.TransferInTransit}, nil),
+		mockRepo.EXPECT().AdvanceTransfer(gomock.Any(), transferID, domain.TransferReceived).Return(domain.Transfer{ID: transferID, Status: domain.TransferReceived}, nil),
+		mockRepo.EXPECT().AdvanceTransfer(gomock.Any(), transferID, domain.TransferCancelled).Return(domain.Transfer{}, intErr.ErrConflict),
+	)
+
+	shipped, err := service.ShipTransfer(context.Background(), transferID)
+	require.NoError(t, err)
+	require.Equal(t, domain.TransferInTransit, shipped.Status)
+
+	received, err := service.ReceiveTransfer(context.Background(), transferID)
+	require.NoError(t, err)
+	require.Equal(t, domain.TransferReceived, received.Status)
+
+	_, err = service.CancelTransfer(context.Background(), transferID)
+	require.ErrorIs(t, err, intErr.ErrConflict)
+
+	require.True(t, domain.CanTransition(domain.TransferRequested, domain.TransferCancelled))
+	require.True(t, domain.CanTransition(domain.TransferInTransit, domain.TransferCancelled))
+	require.False(t, domain.CanTransition(domain.TransferRequested, domain.TransferReceived))
+	require.False(t, domain.CanTransition(domain.TransferReceived, domain.TransferCancelled))
+}
+
+func TestListTransfers_RejectsUnknownStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	service := NewService(mockRepo)
+
+	status := domain.TransferStatus("lost")
+	_, err := service.ListTransfers(context.Background(), domain.TransferFilter{Status: &status})
+	require.ErrorIs(t, err, intErr.ErrBadRequest)
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -19,17 +20,146 @@ type DBConfig struct {
 	SSLMode  string `mapstructure:"sslmode"`
 	MaxConns int32  `mapstructure:"max_conns"`
 	MinConns int32  `mapstructure:"min_conns"`
+	// SlowQueryThreshold logs queries taking at least this long; zero disables it.
+	SlowQueryThreshold time.Duration `mapstructure:"slow_query_threshold"`
+	// QueryLogSampleRate is the fraction of all queries logged at debug level.
+	QueryLogSampleRate float64 `mapstructure:"query_log_sample_rate"`
+	// MigrationsDir loads migrations from disk instead of the embedded ones; empty uses the embedded set.
+	MigrationsDir string `mapstructure:"migrations_dir"`
+	// MigrationMode is MigrationModeMigrate or MigrationModeWait.
+	MigrationMode string `mapstructure:"migration_mode"`
+	// SchemaWaitTimeout bounds how long MigrationModeWait waits for the schema.
+	SchemaWaitTimeout time.Duration `mapstructure:"schema_wait_timeout"`
 }
 
+const (
+	// MigrationModeMigrate applies pending migrations at startup under an advisory lock.
+	MigrationModeMigrate = "migrate"
+	// MigrationModeWait never migrates and waits until another process has migrated the schema.
+	MigrationModeWait = "wait"
+)
+
 type ServerConfig struct {
-	Port               int
-	CORSAllowedOrigins []string `mapstructure:"cors_allowed_origins"`
+	Port int
+	// ShutdownDrainDelay is how long /readyz reports failure before the server stops
+	// accepting connections, giving load balancers time to stop routing traffic.
+	ShutdownDrainDelay time.Duration `mapstructure:"shutdown_drain_delay"`
+	CORSAllowedOrigins []string      `mapstructure:"cors_allowed_origins"`
+	// ErrorFormat is the default error body format: "json" or "problem" (RFC 7807).
+	ErrorFormat        string `mapstructure:"error_format"`
+	ProblemTypeBaseURI string `mapstructure:"problem_type_base_uri"`
+}
+
+type AuthConfig struct {
+	// Enabled requires authentication on all /v1 routes.
+	Enabled bool
+	JWT     JWTConfig
+	APIKeys APIKeysConfig `mapstructure:"api_keys"`
+	// Roles maps role names to the permissions they grant (e.g. "books:read", "books:*").
+	Roles map[string][]string
+}
+
+type APIKeysConfig struct {
+	// Enabled accepts "Authorization: ApiKey <key>" credentials.
+	Enabled bool
+}
+
+type JWTConfig struct {
+	Issuer     string
+	Audience   string
+	HMACSecret string `mapstructure:"hmac_secret"`
+	JWKSFile   string `mapstructure:"jwks_file"`
+	JWKSURL    string `mapstructure:"jwks_url"`
+	RolesClaim string `mapstructure:"roles_claim"`
+}
+
+type RateLimitConfig struct {
+	Enabled bool
+	// Store is "memory" (per instance) or "postgres" (shared across instances).
+	Store             string
+	TrustForwardedFor bool `mapstructure:"trust_forwarded_for"`
+	Read              RateLimitRule
+	Write             RateLimitRule
+	// PerIP caps all requests from one client IP, counted before authentication.
+	PerIP RateLimitRule `mapstructure:"per_ip"`
+}
+
+// LongestRefillTime is how long the slowest configured bucket takes to fill up from empty.
+// Buckets untouched for less than this must not be discarded, or clients could burst early.
+func (rateLimitConfig RateLimitConfig) LongestRefillTime() time.Duration {
+	var longest time.Duration
+	for _, rule := range []RateLimitRule{rateLimitConfig.Read, rateLimitConfig.Write, rateLimitConfig.PerIP} {
+		if rule.RequestsPerSecond > 0 {
+			longest = max(longest, time.Duration(float64(rule.Burst)/rule.RequestsPerSecond*float64(time.Second)))
+		}
+	}
+	return longest
+}
+
+type RateLimitRule struct {
+	RequestsPerSecond float64 `mapstructure:"requests_per_second"`
+	Burst             int
+}
+
+type IdempotencyConfig struct {
+	Enabled bool
+	// Store is "memory" (per instance) or "postgres" (shared across instances).
+	Store string
+	// TTL is how long a key and its stored response are kept.
+	TTL time.Duration
+	// Lease is how long an unfinished request holds its key, so a request lost with its
+	// instance can be retried well before TTL.
+	Lease time.Duration
+	// MaxRequestBytes bounds requests carrying a key; MaxResponseBytes bounds stored responses.
+	MaxRequestBytes  int64 `mapstructure:"max_request_bytes"`
+	MaxResponseBytes int   `mapstructure:"max_response_bytes"`
+}
+
+type MetricsConfig struct {
+	Enabled bool
+	Path    string
+	// AdminPort is the separate listener serving metrics, keeping them off the public API
+	// port. Zero serves them on the API port instead.
+	AdminPort int `mapstructure:"admin_port"`
+}
+
+type TracingConfig struct {
+	Enabled bool
+	// Endpoint is the OTLP/HTTP collector address, e.g. "otel-collector:4318".
+	Endpoint    string
+	Insecure    bool
+	ServiceName string  `mapstructure:"service_name"`
+	SampleRatio float64 `mapstructure:"sample_ratio"`
+}
+
+type HealthConfig struct {
+	CheckTimeout            time.Duration `mapstructure:"check_timeout"`
+	PoolSaturationThreshold float64       `mapstructure:"pool_saturation_threshold"`
+}
+
+// MetadataConfig configures the Open Library-compatible provider used to look up books by ISBN.
+type MetadataConfig struct {
+	Enabled bool
+	BaseURL string `mapstructure:"base_url"`
+	Timeout time.Duration
+	// CacheTTL is how long answers, including "not found", are cached; CacheSize bounds the entries.
+	CacheTTL  time.Duration `mapstructure:"cache_ttl"`
+	CacheSize int           `mapstructure:"cache_size"`
+	// AutoEnrich fills in missing fields from the provider when a book is created.
+	AutoEnrich bool `mapstructure:"auto_enrich"`
 }
 
 type Config struct {
-	Log    LogConfig
-	DB     DBConfig
-	Server ServerConfig
+	Log         LogConfig
+	DB          DBConfig
+	Server      ServerConfig
+	Auth        AuthConfig
+	RateLimit   RateLimitConfig `mapstructure:"rate_limit"`
+	Idempotency IdempotencyConfig
+	Metrics     MetricsConfig
+	Tracing     TracingConfig
+	Health      HealthConfig
+	Metadata    MetadataConfig
 }
 
 // Load loads configuration from config/config.yaml, allowing environment variables to override values.
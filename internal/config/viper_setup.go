@@ -35,10 +35,71 @@ func setDefaultValues(viperInstance *viper.Viper) {
 	viperInstance.SetDefault("db.sslmode", "disable")
 	viperInstance.SetDefault("db.max_conns", 10)
 	viperInstance.SetDefault("db.min_conns", 1)
+	viperInstance.SetDefault("db.slow_query_threshold", "200ms")
+	viperInstance.SetDefault("db.query_log_sample_rate", 0.0)
+	viperInstance.SetDefault("db.migrations_dir", "")
+	viperInstance.SetDefault("db.migration_mode", "migrate")
+	viperInstance.SetDefault("db.schema_wait_timeout", "5m")
 
 	// Server defaults
 	viperInstance.SetDefault("server.port", 8080)
+	viperInstance.SetDefault("server.shutdown_drain_delay", "5s")
 	viperInstance.SetDefault("server.cors_allowed_origins", []string{"*"})
+	viperInstance.SetDefault("server.error_format", "json")
+	viperInstance.SetDefault("server.problem_type_base_uri", "")
+
+	// Auth defaults
+	viperInstance.SetDefault("auth.enabled", false)
+	viperInstance.SetDefault("auth.jwt.issuer", "")
+	viperInstance.SetDefault("auth.jwt.audience", "")
+	viperInstance.SetDefault("auth.jwt.hmac_secret", "")
+	viperInstance.SetDefault("auth.jwt.jwks_file", "")
+	viperInstance.SetDefault("auth.jwt.jwks_url", "")
+	viperInstance.SetDefault("auth.jwt.roles_claim", "roles")
+	viperInstance.SetDefault("auth.api_keys.enabled", true)
+
+	// Rate limit defaults
+	viperInstance.SetDefault("rate_limit.enabled", false)
+	viperInstance.SetDefault("rate_limit.store", "memory")
+	viperInstance.SetDefault("rate_limit.trust_forwarded_for", false)
+	viperInstance.SetDefault("rate_limit.read.requests_per_second", 20)
+	viperInstance.SetDefault("rate_limit.read.burst", 40)
+	viperInstance.SetDefault("rate_limit.write.requests_per_second", 5)
+	viperInstance.SetDefault("rate_limit.write.burst", 10)
+	viperInstance.SetDefault("rate_limit.per_ip.requests_per_second", 50)
+	viperInstance.SetDefault("rate_limit.per_ip.burst", 100)
+
+	// Idempotency defaults
+	viperInstance.SetDefault("idempotency.enabled", false)
+	viperInstance.SetDefault("idempotency.store", "postgres")
+	viperInstance.SetDefault("idempotency.ttl", "24h")
+	viperInstance.SetDefault("idempotency.lease", "1m")
+	viperInstance.SetDefault("idempotency.max_request_bytes", 1<<20)
+	viperInstance.SetDefault("idempotency.max_response_bytes", 1<<20)
+
+	// Metrics defaults
+	viperInstance.SetDefault("metrics.enabled", true)
+	viperInstance.SetDefault("metrics.path", "/metrics")
+	viperInstance.SetDefault("metrics.admin_port", 9090)
+
+	// Tracing defaults
+	viperInstance.SetDefault("tracing.enabled", false)
+	viperInstance.SetDefault("tracing.endpoint", "localhost:4318")
+	viperInstance.SetDefault("tracing.insecure", true)
+	viperInstance.SetDefault("tracing.service_name", "library-http")
+	viperInstance.SetDefault("tracing.sample_ratio", 1.0)
+
+	// Health defaults
+	viperInstance.SetDefault("health.check_timeout", "2s")
+	viperInstance.SetDefault("health.pool_saturation_threshold", 0.95)
+
+	// Metadata defaults
+	viperInstance.SetDefault("metadata.enabled", false)
+	viperInstance.SetDefault("metadata.base_url", "https://openlibrary.org")
+	viperInstance.SetDefault("metadata.timeout", "3s")
+	viperInstance.SetDefault("metadata.cache_ttl", "24h")
+	viperInstance.SetDefault("metadata.cache_size", 10000)
+	viperInstance.SetDefault("metadata.auto_enrich", false)
 }
 
 // setupEnvironmentOverrides configures Viper to read from environment variables.
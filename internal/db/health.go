@@ -0,0 +1,73 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pressly/goose/v3"
+)
+
+// PingCheck verifies that a connection can be acquired and the server responds.
+func PingCheck(dbPool *pgxpool.Pool) func(ctx context.Context) error {
+	return dbPool.Ping
+}
+
+// PoolSaturationCheck fails when the share of acquired connections reaches threshold (0-1].
+func PoolSaturationCheck(dbPool *pgxpool.Pool, threshold float64) func(ctx context.Context) error {
+	return func(context.Context) error {
+		stats := dbPool.Stat()
+		if stats.MaxConns() == 0 {
+			return nil
+		}
+		saturation := float64(stats.AcquiredConns()) / float64(stats.MaxConns())
+		if saturation >= threshold {
+			return fmt.Errorf("pool saturated: %d of %d connections in use", stats.AcquiredConns(), stats.MaxConns())
+		}
+		return nil
+	}
+}
+
+// MigrationVersionCheck fails when the database schema is older than the newest migration
+// known to this binary. A newer schema is expected while a rolling deploy is in progress,
+// since migrations are backward compatible, so it does not fail the check.
+func MigrationVersionCheck(dbPool *pgxpool.Pool) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		expectedVersion, err := LatestMigrationVersion()
+		if err != nil {
+			return err
+		}
+		currentVersion, err := CurrentSchemaVersion(ctx, dbPool)
+		if err != nil {
+			return err
+		}
+		if currentVersion < expectedVersion {
+			return fmt.Errorf("schema version %d is older than expected version %d", currentVersion, expectedVersion)
+		}
+		return nil
+	}
+}
+
+// LatestMigrationVersion returns the version of the newest embedded migration, or of the
+// newest one in the directory set with SetMigrationDir.
+func LatestMigrationVersion() (int64, error) {
+	collected, err := goose.CollectMigrations(useMigrationSource(), 0, goose.MaxVersion)
+	if err != nil {
+		return 0, fmt.Errorf("collect migrations: %w", err)
+	}
+	latest, err := collected.Last()
+	if err != nil {
+		return 0, fmt.Errorf("find latest migration: %w", err)
+	}
+	return latest.Version, nil
+}
+
+// CurrentSchemaVersion returns the highest migration version applied to the database.
+func CurrentSchemaVersion(ctx context.Context, dbPool *pgxpool.Pool) (int64, error) {
+	const versionQuery = `SELECT COALESCE(MAX(version_id), 0) FROM goose_db_version WHERE is_applied;`
+	var version int64
+	if err := dbPool.QueryRow(ctx, versionQuery).Scan(&version); err != nil {
+		return 0, fmt.Errorf("read schema version: %w", err)
+	}
+	return version, nil
+}
@@ -0,0 +1,206 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pressly/goose/v3"
+)
+
+const (
+	// migrationLockID is the Postgres advisory lock key held while migrations run, so that
+	// replicas starting together apply them one at a time.
+	migrationLockID    int64 = 4_170_921_837
+	lockPollInterval         = time.Second
+	schemaPollInterval       = 2 * time.Second
+)
+
+// ErrSchemaAhead reports a database schema newer than the binary's migrations.
+var ErrSchemaAhead = errors.New("database schema is newer than this binary")
+
+// Migrator runs the embedded goose migrations, or those in the directory set with
+// SetMigrationDir, against a database.
+type Migrator struct {
+	standardDB *sql.DB
+}
+
+// NewMigrator opens a database/sql connection for goose.
+func NewMigrator(host string, port int, user, password, databaseName, sslMode string) (*Migrator, error) {
+	return newMigrator(buildConnectionString(host, port, user, password, databaseName, sslMode))
+}
+
+func newMigrator(connectionString string) (*Migrator, error) {
+	if err := goose.SetDialect("postgres"); err != nil {
+		return nil, fmt.Errorf("set goose dialect: %w", err)
+	}
+
+	standardDB, err := sql.Open("postgres", connectionString)
+	if err != nil {
+		return nil, fmt.Errorf("open standard database connection: %w", err)
+	}
+	return &Migrator{standardDB: standardDB}, nil
+}
+
+// Close closes the underlying connection.
+func (migrator *Migrator) Close() error {
+	return migrator.standardDB.Close()
+}
+
+// Up applies all pending migrations.
+func (migrator *Migrator) Up(ctx context.Context) error {
+	return migrator.withLock(ctx, func() error {
+		if err := goose.UpContext(ctx, migrator.standardDB, useMigrationSource()); err != nil {
+			return fmt.Errorf("run migrations: %w", err)
+		}
+		return nil
+	})
+}
+
+// Down rolls back the most recently applied migration.
+func (migrator *Migrator) Down(ctx context.Context) error {
+	return migrator.withLock(ctx, func() error {
+		if err := goose.DownContext(ctx, migrator.standardDB, useMigrationSource()); err != nil {
+			return fmt.Errorf("roll back migration: %w", err)
+		}
+		return nil
+	})
+}
+
+// Redo rolls back the most recently applied migration and applies it again.
+func (migrator *Migrator) Redo(ctx context.Context) error {
+	return migrator.withLock(ctx, func() error {
+		if err := goose.RedoContext(ctx, migrator.standardDB, useMigrationSource()); err != nil {
+			return fmt.Errorf("redo migration: %w", err)
+		}
+		return nil
+	})
+}
+
+// To migrates up or down until version is the newest applied migration.
+func (migrator *Migrator) To(ctx context.Context, version int64) error {
+	return migrator.withLock(ctx, func() error {
+		currentVersion, err := migrator.Version(ctx)
+		if err != nil {
+			return err
+		}
+		if version >= currentVersion {
+			err = goose.UpToContext(ctx, migrator.standardDB, useMigrationSource(), version)
+		} else {
+			err = goose.DownToContext(ctx, migrator.standardDB, useMigrationSource(), version)
+		}
+		if err != nil {
+			return fmt.Errorf("migrate to version %d: %w", version, err)
+		}
+		return nil
+	})
+}
+
+// Status prints the applied state of every migration through goose's logger.
+func (migrator *Migrator) Status(ctx context.Context) error {
+	if err := goose.StatusContext(ctx, migrator.standardDB, useMigrationSource()); err != nil {
+		return fmt.Errorf("migration status: %w", err)
+	}
+	return nil
+}
+
+// CheckNotAhead fails when the database has a migration applied that is newer than any this
+// binary knows, which usually means an older build is running against a newer schema.
+func (migrator *Migrator) CheckNotAhead(ctx context.Context) error {
+	currentVersion, err := migrator.Version(ctx)
+	if err != nil {
+		return err
+	}
+	latestVersion, err := LatestMigrationVersion()
+	if err != nil {
+		return err
+	}
+	if currentVersion > latestVersion {
+		return fmt.Errorf("%w: database is at version %d but the newest known migration is %d", ErrSchemaAhead, currentVersion, latestVersion)
+	}
+	return nil
+}
+
+// Version returns the newest applied migration version.
+func (migrator *Migrator) Version(ctx context.Context) (int64, error) {
+	version, err := goose.GetDBVersionContext(ctx, migrator.standardDB)
+	if err != nil {
+		return 0, fmt.Errorf("read schema version: %w", err)
+	}
+	return version, nil
+}
+
+// withLock runs migrate while holding the migration advisory lock, waiting for other
+// migrators to finish first. The lock is session scoped, so it is taken on a dedicated
+// connection and released even if the process dies mid-migration.
+func (migrator *Migrator) withLock(ctx context.Context, migrate func() error) (err error) {
+	lockConnection, err := migrator.standardDB.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("acquire migration lock connection: %w", err)
+	}
+	defer lockConnection.Close()
+
+	for {
+		var acquired bool
+		if err := lockConnection.QueryRowContext(ctx, `SELECT pg_try_advisory_lock($1);`, migrationLockID).Scan(&acquired); err != nil {
+			return fmt.Errorf("acquire migration lock: %w", err)
+		}
+		if acquired {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("wait for migration lock: %w", ctx.Err())
+		case <-time.After(lockPollInterval):
+		}
+	}
+
+	defer func() {
+		_, unlockErr := lockConnection.ExecContext(context.WithoutCancel(ctx), `SELECT pg_advisory_unlock($1);`, migrationLockID)
+		if unlockErr != nil && err == nil {
+			err = fmt.Errorf("release migration lock: %w", unlockErr)
+		}
+	}()
+
+	return migrate()
+}
+
+// WaitForSchema blocks until the database schema reaches the newest migration known to this
+// binary, for replicas that leave migrating to a separate job. It fails immediately when the
+// schema is already newer, and when ctx ends.
+func WaitForSchema(ctx context.Context, dbPool *pgxpool.Pool) error {
+	expectedVersion, err := LatestMigrationVersion()
+	if err != nil {
+		return err
+	}
+
+	for {
+		currentVersion, err := CurrentSchemaVersion(ctx, dbPool)
+		if err != nil && !isUndefinedTable(err) {
+			return err
+		}
+		switch {
+		case currentVersion == expectedVersion:
+			return nil
+		case currentVersion > expectedVersion:
+			return fmt.Errorf("%w: database is at version %d but the newest known migration is %d", ErrSchemaAhead, currentVersion, expectedVersion)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("wait for schema version %d (currently %d): %w", expectedVersion, currentVersion, ctx.Err())
+		case <-time.After(schemaPollInterval):
+		}
+	}
+}
+
+// isUndefinedTable reports whether err is Postgres' undefined_table error, raised before the
+// first migration has created goose's version table.
+func isUndefinedTable(err error) bool {
+	var pgError *pgconn.PgError
+	return errors.As(err, &pgError) && pgError.Code == "42P01"
+}
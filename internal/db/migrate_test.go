@@ -0,0 +1,47 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatestMigrationVersion_Embedded(t *testing.T) {
+	entries, err := os.ReadDir("../../migrations")
+	require.NoError(t, err)
+	newest := ""
+	for _, entry := range entries {
+		if filepath.Ext(entry.Name()) == ".sql" && entry.Name() > newest {
+			newest = entry.Name()
+		}
+	}
+
+	version, err := LatestMigrationVersion()
+	require.NoError(t, err)
+	require.Equal(t, newest[:14], strconv.FormatInt(version, 10))
+}
+
+func TestLatestMigrationVersion_Override(t *testing.T) {
+	directory := t.TempDir()
+	migration := "-- +goose Up\nSELECT 1;\n\n-- +goose Down\nSELECT 1;\n"
+	require.NoError(t, os.WriteFile(filepath.Join(directory, "20990101000000_future.sql"), []byte(migration), 0o600))
+
+	SetMigrationDir(directory)
+	t.Cleanup(func() { SetMigrationDir("") })
+
+	version, err := LatestMigrationVersion()
+	require.NoError(t, err)
+	require.EqualValues(t, 20990101000000, version)
+}
+
+func TestIsUndefinedTable(t *testing.T) {
+	require.True(t, isUndefinedTable(fmt.Errorf("read schema version: %w", &pgconn.PgError{Code: "42P01"})))
+	require.False(t, isUndefinedTable(&pgconn.PgError{Code: "23505"}))
+	require.False(t, isUndefinedTable(errors.New("connection refused")))
+}
@@ -2,10 +2,12 @@ package db
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
 	"time"
 
+	"github.com/bkiran6398/library/migrations"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/multitracer"
 	"github.com/jackc/pgx/v5/pgxpool"
 	_ "github.com/lib/pq"
 	"github.com/pressly/goose/v3"
@@ -19,20 +21,85 @@ const (
 	maxRetryAttempts   = 10
 )
 
-var (
-	MigrationDir = "migrations"
-)
+// migrationDir, when set, is the directory on disk migrations are loaded from instead of
+// the ones embedded in the binary.
+var migrationDir = ""
+
+func init() {
+	goose.SetBaseFS(migrations.FS)
+}
+
+// SetMigrationDir loads migrations from dir on disk instead of the ones embedded in the
+// binary; it is meant for developing new migrations. goose keeps its file system in a
+// global, so this must be called once at startup, before anything reads migrations.
+func SetMigrationDir(dir string) {
+	migrationDir = dir
+	if dir != "" {
+		goose.SetBaseFS(nil)
+		return
+	}
+	goose.SetBaseFS(migrations.FS)
+}
+
+// useMigrationSource returns the directory to pass to goose: "." within the embedded
+// migrations, or the directory set with SetMigrationDir.
+func useMigrationSource() string {
+	if migrationDir != "" {
+		return migrationDir
+	}
+	return "."
+}
 
 // buildConnectionString constructs a PostgreSQL connection string (DSN).
 func buildConnectionString(host string, port int, user, password, databaseName, sslMode string) string {
 	return fmt.Sprintf("postgresql://%s:%s@%s:%d/%s?sslmode=%s", user, password, host, port, databaseName, sslMode)
 }
 
+// Option customizes the connection pool.
+type Option func(*pgxpool.Config)
+
+// WithQueryTracer attaches a pgx query tracer to every connection in the pool.
+// It may be given several times; the tracers run in the order they were added.
+func WithQueryTracer(tracer pgx.QueryTracer) Option {
+	return func(poolConfig *pgxpool.Config) {
+		poolConfig.ConnConfig.Tracer = combineTracers(poolConfig.ConnConfig.Tracer, tracer)
+	}
+}
+
+// combineTracers appends tracer to an existing tracer, if any.
+func combineTracers(existing, tracer pgx.QueryTracer) pgx.QueryTracer {
+	switch current := existing.(type) {
+	case nil:
+		return tracer
+	case *multitracer.Tracer:
+		return multitracer.New(append(current.QueryTracers, tracer)...)
+	default:
+		return multitracer.New(current, tracer)
+	}
+}
+
 // ConnectAndMigrate connects to Postgres using a pgxpool and runs embedded goose migrations.
-func ConnectAndMigrate(ctx context.Context, host string, port int, user, password, databaseName, sslMode string, maxConns, minConns int32) (*pgxpool.Pool, error) {
+// It refuses to start when the database schema is newer than the migrations this binary knows.
+func ConnectAndMigrate(ctx context.Context, host string, port int, user, password, databaseName, sslMode string, maxConns, minConns int32, options ...Option) (*pgxpool.Pool, error) {
+	databasePool, err := Connect(ctx, host, port, user, password, databaseName, sslMode, maxConns, minConns, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	connectionString := buildConnectionString(host, port, user, password, databaseName, sslMode)
+	if err := runMigrations(ctx, connectionString); err != nil {
+		databasePool.Close()
+		return nil, err
+	}
+
+	return databasePool, nil
+}
+
+// Connect connects to Postgres using a pgxpool and waits until it responds, without migrating.
+func Connect(ctx context.Context, host string, port int, user, password, databaseName, sslMode string, maxConns, minConns int32, options ...Option) (*pgxpool.Pool, error) {
 	connectionString := buildConnectionString(host, port, user, password, databaseName, sslMode)
 
-	poolConfig, err := createPoolConfig(connectionString, maxConns, minConns)
+	poolConfig, err := createPoolConfig(connectionString, maxConns, minConns, options...)
 	if err != nil {
 		return nil, err
 	}
@@ -47,22 +114,20 @@ func ConnectAndMigrate(ctx context.Context, host string, port int, user, passwor
 		return nil, err
 	}
 
-	if err := runMigrations(ctx, connectionString); err != nil {
-		databasePool.Close()
-		return nil, err
-	}
-
 	return databasePool, nil
 }
 
 // createPoolConfig creates a pgxpool configuration with connection limits.
-func createPoolConfig(connectionString string, maxConns, minConns int32) (*pgxpool.Config, error) {
+func createPoolConfig(connectionString string, maxConns, minConns int32, options ...Option) (*pgxpool.Config, error) {
 	poolConfig, err := pgxpool.ParseConfig(connectionString)
 	if err != nil {
 		return nil, fmt.Errorf("parse database config: %w", err)
 	}
 	poolConfig.MaxConns = maxConns
 	poolConfig.MinConns = minConns
+	for _, option := range options {
+		option(poolConfig)
+	}
 	return poolConfig, nil
 }
 
@@ -100,19 +165,14 @@ func waitForDatabaseReady(ctx context.Context, databasePool *pgxpool.Pool) error
 
 // runMigrations executes database migrations using goose.
 func runMigrations(ctx context.Context, connectionString string) error {
-	if err := goose.SetDialect("postgres"); err != nil {
-		return fmt.Errorf("set goose dialect: %w", err)
-	}
-
-	standardDB, err := sql.Open("postgres", connectionString)
+	migrator, err := newMigrator(connectionString)
 	if err != nil {
-		return fmt.Errorf("open standard database connection: %w", err)
+		return err
 	}
-	defer standardDB.Close()
+	defer migrator.Close()
 
-	if err := goose.UpContext(ctx, standardDB, MigrationDir); err != nil {
-		return fmt.Errorf("run migrations: %w", err)
+	if err := migrator.CheckNotAhead(ctx); err != nil {
+		return err
 	}
-
-	return nil
+	return migrator.Up(ctx)
 }
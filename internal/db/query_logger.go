@@ -0,0 +1,95 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog"
+)
+
+// QueryLoggerConfig configures slow and sampled query logging.
+type QueryLoggerConfig struct {
+	// SlowThreshold logs every query taking at least this long at warn level. Zero disables it.
+	SlowThreshold time.Duration
+	// SampleRate is the fraction (0-1) of all queries logged at debug level.
+	SampleRate float64
+	// RequestID extracts the request ID from a query's context, if any.
+	RequestID func(ctx context.Context) string
+}
+
+type queryStartKey struct{}
+
+type queryStart struct {
+	startedAt time.Time
+	sql       string
+	args      []any
+}
+
+// QueryLogger is a pgx.QueryTracer that logs slow queries and a sample of all queries.
+// Argument values are never logged, only their types, since they may contain personal data.
+type QueryLogger struct {
+	logger zerolog.Logger
+	config QueryLoggerConfig
+	sample func() float64
+}
+
+// NewQueryLogger creates a query logger.
+func NewQueryLogger(logger zerolog.Logger, config QueryLoggerConfig) *QueryLogger {
+	return &QueryLogger{logger: logger, config: config, sample: rand.Float64}
+}
+
+func (queryLogger *QueryLogger) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	return context.WithValue(ctx, queryStartKey{}, queryStart{startedAt: time.Now(), sql: data.SQL, args: data.Args})
+}
+
+func (queryLogger *QueryLogger) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	start, ok := ctx.Value(queryStartKey{}).(queryStart)
+	if !ok {
+		return
+	}
+	duration := time.Since(start.startedAt)
+
+	var event *zerolog.Event
+	switch {
+	case queryLogger.config.SlowThreshold > 0 && duration >= queryLogger.config.SlowThreshold:
+		event = queryLogger.logger.Warn().Str("reason", "slow_query")
+	case queryLogger.config.SampleRate > 0 && queryLogger.sample() < queryLogger.config.SampleRate:
+		event = queryLogger.logger.Debug().Str("reason", "sampled")
+	default:
+		return
+	}
+
+	event = event.Ctx(ctx).
+		Str("query", start.sql).
+		Strs("args", redactArgs(start.args)).
+		Dur("duration_ms", duration).
+		Int64("rows_affected", data.CommandTag.RowsAffected())
+	if queryLogger.config.RequestID != nil {
+		event = event.Str("request_id", queryLogger.config.RequestID(ctx))
+	}
+	if data.Err != nil {
+		event = event.Err(data.Err)
+	}
+	event.Msg("db_query")
+}
+
+// redactArgs describes query arguments by type so logs never contain their values.
+func redactArgs(args []any) []string {
+	redacted := make([]string, len(args))
+	for index, arg := range args {
+		switch value := arg.(type) {
+		case nil:
+			redacted[index] = "NULL"
+		case string:
+			redacted[index] = fmt.Sprintf("string(len=%d)", len(value))
+		case []byte:
+			redacted[index] = fmt.Sprintf("bytes(len=%d)", len(value))
+		default:
+			redacted[index] = fmt.Sprintf("%T", value)
+		}
+	}
+	return redacted
+}
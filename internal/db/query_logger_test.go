@@ -0,0 +1,53 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func runQuery(queryLogger *QueryLogger, ctx context.Context, args ...any) {
+	ctx = queryLogger.TraceQueryStart(ctx, nil, pgx.TraceQueryStartData{SQL: "SELECT 1", Args: args})
+	queryLogger.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{CommandTag: pgconn.NewCommandTag("UPDATE 2")})
+}
+
+func TestQueryLogger_SlowQuery(t *testing.T) {
+	var output bytes.Buffer
+	queryLogger := NewQueryLogger(zerolog.New(&output), QueryLoggerConfig{
+		SlowThreshold: time.Nanosecond,
+		RequestID:     func(context.Context) string { return "req-1" },
+	})
+
+	runQuery(queryLogger, context.Background(), "secret@example.com", 42, nil)
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(output.Bytes(), &entry))
+	require.Equal(t, "warn", entry["level"])
+	require.Equal(t, "slow_query", entry["reason"])
+	require.Equal(t, "SELECT 1", entry["query"])
+	require.Equal(t, "req-1", entry["request_id"])
+	require.EqualValues(t, 2, entry["rows_affected"])
+	require.Equal(t, []any{"string(len=18)", "int", "NULL"}, entry["args"])
+	require.NotContains(t, output.String(), "secret@example.com")
+}
+
+func TestQueryLogger_Sampling(t *testing.T) {
+	var output bytes.Buffer
+	queryLogger := NewQueryLogger(zerolog.New(&output), QueryLoggerConfig{SlowThreshold: time.Hour, SampleRate: 0.5})
+
+	queryLogger.sample = func() float64 { return 0.9 }
+	runQuery(queryLogger, context.Background())
+	require.Empty(t, output.String())
+
+	queryLogger.sample = func() float64 { return 0.1 }
+	runQuery(queryLogger, context.Background())
+	require.Contains(t, output.String(), `"reason":"sampled"`)
+	require.Contains(t, output.String(), `"level":"debug"`)
+}
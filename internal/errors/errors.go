@@ -1,10 +1,126 @@
 package errors
 
-import "errors"
+import (
+	"context"
+	"errors"
+)
+
+// Kind classifies an application error independently of any transport.
+type Kind string
+
+const (
+	KindInternal           Kind = "internal"
+	KindBadRequest         Kind = "bad_request"
+	KindNotFound           Kind = "not_found"
+	KindConflict           Kind = "conflict"
+	KindUnauthorized       Kind = "unauthorized"
+	KindForbidden          Kind = "forbidden"
+	KindPreconditionFailed Kind = "precondition_failed"
+	KindTooManyRequests    Kind = "too_many_requests"
+	KindUnavailable        Kind = "unavailable"
+	KindTimeout            Kind = "timeout"
+	KindCanceled           Kind = "canceled"
+)
 
 var (
-	ErrNotFound   = errors.New("not found")
-	ErrConflict   = errors.New("conflict")
-	ErrBadRequest = errors.New("bad request")
+	ErrNotFound           = New(KindNotFound, "not found")
+	ErrConflict           = New(KindConflict, "conflict")
+	ErrBadRequest         = New(KindBadRequest, "bad request")
+	ErrUnauthorized       = New(KindUnauthorized, "unauthorized")
+	ErrForbidden          = New(KindForbidden, "forbidden")
+	ErrPreconditionFailed = New(KindPreconditionFailed, "precondition failed")
+	ErrTooManyRequests    = New(KindTooManyRequests, "too many requests")
+	ErrUnavailable        = New(KindUnavailable, "unavailable")
+	ErrTimeout            = New(KindTimeout, "timeout")
+	ErrCanceled           = New(KindCanceled, "canceled")
 )
 
+// Error is a typed application error. Two errors match with errors.Is when they share
+// a Kind, so errors.Is(err, ErrNotFound) holds for any not-found error.
+type Error struct {
+	Kind Kind
+	// Code is a stable machine-readable identifier; it defaults to the kind's code.
+	Code string
+	// Message is safe to show to clients.
+	Message   string
+	Details   interface{}
+	Retryable bool
+	Cause     error
+}
+
+// New creates an error of the given kind with a client-safe message.
+func New(kind Kind, message string) *Error {
+	return &Error{Kind: kind, Message: message}
+}
+
+// Wrap creates an error of the given kind that keeps cause in the chain.
+func Wrap(cause error, kind Kind, message string) *Error {
+	return &Error{Kind: kind, Message: message, Cause: cause}
+}
+
+func (appError *Error) Error() string {
+	if appError.Cause == nil {
+		return appError.Message
+	}
+	if appError.Message == "" {
+		return appError.Cause.Error()
+	}
+	return appError.Message + ": " + appError.Cause.Error()
+}
+
+func (appError *Error) Unwrap() error {
+	return appError.Cause
+}
+
+// Is reports whether target is an *Error of the same kind.
+func (appError *Error) Is(target error) bool {
+	targetError, ok := target.(*Error)
+	return ok && targetError.Kind == appError.Kind
+}
+
+// WithCode returns a copy of the error with the given machine-readable code.
+func (appError *Error) WithCode(code string) *Error {
+	clone := *appError
+	clone.Code = code
+	return &clone
+}
+
+// WithDetails returns a copy of the error carrying structured details for clients.
+func (appError *Error) WithDetails(details interface{}) *Error {
+	clone := *appError
+	clone.Details = details
+	return &clone
+}
+
+// WithRetryable returns a copy of the error marked as safe (or not) to retry.
+func (appError *Error) WithRetryable(retryable bool) *Error {
+	clone := *appError
+	clone.Retryable = retryable
+	return &clone
+}
+
+// KindOf returns the kind of the first *Error in err's chain. Context deadline errors
+// are reported as KindTimeout and cancellations, such as a client that disconnected, as
+// KindCanceled; anything else unrecognised is KindInternal.
+func KindOf(err error) Kind {
+	var appError *Error
+	if errors.As(err, &appError) {
+		return appError.Kind
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return KindTimeout
+	}
+	if errors.Is(err, context.Canceled) {
+		return KindCanceled
+	}
+	return KindInternal
+}
+
+// As returns the first *Error in err's chain, if any.
+func As(err error) (*Error, bool) {
+	var appError *Error
+	if errors.As(err, &appError) {
+		return appError, true
+	}
+	return nil, false
+}
@@ -0,0 +1,80 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestError_IsMatchesByKind(t *testing.T) {
+	err := New(KindNotFound, "book not found").WithCode("book_not_found")
+
+	require.True(t, errors.Is(err, ErrNotFound))
+	require.False(t, errors.Is(err, ErrConflict))
+	require.True(t, errors.Is(fmt.Errorf("get book: %w", err), ErrNotFound))
+}
+
+func TestKindOf_DeadlineExceededIsTimeout(t *testing.T) {
+	err := fmt.Errorf("list books: %w", context.DeadlineExceeded)
+
+	require.Equal(t, KindTimeout, KindOf(err))
+	require.Equal(t, http.StatusGatewayTimeout, HTTPStatus(err))
+	require.Equal(t, GRPCCodeDeadlineExceeded, GRPCStatus(err))
+}
+
+func TestKindOf_CanceledIsNotInternal(t *testing.T) {
+	err := fmt.Errorf("list books: %w", context.Canceled)
+
+	require.Equal(t, KindCanceled, KindOf(err))
+	require.Equal(t, StatusClientClosedRequest, HTTPStatus(err))
+	require.Equal(t, GRPCCodeCanceled, GRPCStatus(err))
+}
+
+func TestKindOf_UnknownIsInternal(t *testing.T) {
+	require.Equal(t, KindInternal, KindOf(errors.New("boom")))
+	require.Equal(t, http.StatusInternalServerError, HTTPStatus(errors.New("boom")))
+}
+
+func TestResolve_HidesInternalMessages(t *testing.T) {
+	resolved := Resolve(Wrap(errors.New("dial tcp: connection refused"), KindUnavailable, "database unavailable"))
+
+	require.Equal(t, http.StatusServiceUnavailable, resolved.HTTPStatus)
+	require.Equal(t, "unavailable", resolved.Code)
+	require.Equal(t, "Service unavailable", resolved.Message)
+	require.True(t, resolved.Retryable)
+}
+
+func TestResolve_ExposesBadRequestMessage(t *testing.T) {
+	err := fmt.Errorf("%w: title is required", ErrBadRequest)
+
+	resolved := Resolve(err)
+
+	require.Equal(t, http.StatusBadRequest, resolved.HTTPStatus)
+	require.Equal(t, "bad_request", resolved.Code)
+	require.Equal(t, "bad request: title is required", resolved.Message)
+}
+
+func TestResolve_ExposedMessageOmitsCause(t *testing.T) {
+	err := fmt.Errorf("import row 3: %w", Wrap(errors.New("pq: invalid input syntax"), KindBadRequest, "invalid isbn"))
+
+	resolved := Resolve(err)
+
+	require.Equal(t, "import row 3: invalid isbn", resolved.Message)
+	require.Equal(t, "Bad request", Resolve(Wrap(errors.New("pq: invalid input syntax"), KindBadRequest, "")).Message)
+}
+
+func TestRegister_CustomKind(t *testing.T) {
+	const kindGone Kind = "gone"
+	Register(kindGone, Mapping{HTTPStatus: http.StatusGone, GRPCCode: GRPCCodeNotFound, Code: "gone", Message: "Resource gone"})
+
+	resolved := Resolve(New(kindGone, "book was withdrawn").WithDetails(map[string]string{"id": "42"}))
+
+	require.Equal(t, http.StatusGone, resolved.HTTPStatus)
+	require.Equal(t, "gone", resolved.Code)
+	require.Equal(t, "Resource gone", resolved.Message)
+	require.Equal(t, map[string]string{"id": "42"}, resolved.Details)
+}
@@ -0,0 +1,142 @@
+package errors
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// GRPCCode mirrors google.golang.org/grpc/codes.Code numerically so transports can
+// convert with codes.Code(value) without this package depending on gRPC.
+type GRPCCode uint32
+
+const (
+	GRPCCodeOK                 GRPCCode = 0
+	GRPCCodeCanceled           GRPCCode = 1
+	GRPCCodeUnknown            GRPCCode = 2
+	GRPCCodeInvalidArgument    GRPCCode = 3
+	GRPCCodeDeadlineExceeded   GRPCCode = 4
+	GRPCCodeNotFound           GRPCCode = 5
+	GRPCCodeAlreadyExists      GRPCCode = 6
+	GRPCCodePermissionDenied   GRPCCode = 7
+	GRPCCodeResourceExhausted  GRPCCode = 8
+	GRPCCodeFailedPrecondition GRPCCode = 9
+	GRPCCodeAborted            GRPCCode = 10
+	GRPCCodeInternal           GRPCCode = 13
+	GRPCCodeUnavailable        GRPCCode = 14
+	GRPCCodeUnauthenticated    GRPCCode = 16
+)
+
+// StatusClientClosedRequest is the non-standard status, popularised by nginx, for a request
+// the client abandoned before the response was ready.
+const StatusClientClosedRequest = 499
+
+// Mapping describes how a Kind is presented by transports.
+type Mapping struct {
+	HTTPStatus int
+	GRPCCode   GRPCCode
+	// Code is the default machine-readable code used when the error does not set one.
+	Code string
+	// Message is the default client-facing message.
+	Message string
+	// ExposeMessage makes transports show the error's own message instead of Message.
+	// Only enable it for kinds whose messages never contain internal details.
+	ExposeMessage bool
+	// Retryable is the default retry hint for errors of this kind.
+	Retryable bool
+}
+
+var (
+	registryMutex sync.RWMutex
+	registry      = map[Kind]Mapping{
+		KindInternal:           {HTTPStatus: http.StatusInternalServerError, GRPCCode: GRPCCodeInternal, Code: "internal_error", Message: "An internal error occurred"},
+		KindBadRequest:         {HTTPStatus: http.StatusBadRequest, GRPCCode: GRPCCodeInvalidArgument, Code: "bad_request", Message: "Bad request", ExposeMessage: true},
+		KindNotFound:           {HTTPStatus: http.StatusNotFound, GRPCCode: GRPCCodeNotFound, Code: "not_found", Message: "Resource not found"},
+		KindConflict:           {HTTPStatus: http.StatusConflict, GRPCCode: GRPCCodeAlreadyExists, Code: "conflict", Message: "Resource conflict"},
+		KindUnauthorized:       {HTTPStatus: http.StatusUnauthorized, GRPCCode: GRPCCodeUnauthenticated, Code: "unauthorized", Message: "Authentication required", ExposeMessage: true},
+		KindForbidden:          {HTTPStatus: http.StatusForbidden, GRPCCode: GRPCCodePermissionDenied, Code: "forbidden", Message: "Permission denied", ExposeMessage: true},
+		KindPreconditionFailed: {HTTPStatus: http.StatusPreconditionFailed, GRPCCode: GRPCCodeFailedPrecondition, Code: "precondition_failed", Message: "Precondition failed", ExposeMessage: true},
+		KindTooManyRequests:    {HTTPStatus: http.StatusTooManyRequests, GRPCCode: GRPCCodeResourceExhausted, Code: "too_many_requests", Message: "Too many requests", Retryable: true},
+		KindUnavailable:        {HTTPStatus: http.StatusServiceUnavailable, GRPCCode: GRPCCodeUnavailable, Code: "unavailable", Message: "Service unavailable", Retryable: true},
+		KindTimeout:            {HTTPStatus: http.StatusGatewayTimeout, GRPCCode: GRPCCodeDeadlineExceeded, Code: "timeout", Message: "The request timed out", Retryable: true},
+		KindCanceled:           {HTTPStatus: StatusClientClosedRequest, GRPCCode: GRPCCodeCanceled, Code: "canceled", Message: "The request was canceled"},
+	}
+)
+
+// Register adds or replaces the transport mapping for a kind. Modules that introduce
+// their own kinds should call it from an init function.
+func Register(kind Kind, mapping Mapping) {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+	registry[kind] = mapping
+}
+
+// Lookup returns the mapping for a kind, falling back to the internal error mapping.
+func Lookup(kind Kind) Mapping {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+	if mapping, ok := registry[kind]; ok {
+		return mapping
+	}
+	return registry[KindInternal]
+}
+
+// Resolved is the transport-neutral view of an error after applying its kind's mapping.
+type Resolved struct {
+	Kind       Kind
+	HTTPStatus int
+	GRPCCode   GRPCCode
+	Code       string
+	Message    string
+	Details    interface{}
+	Retryable  bool
+}
+
+// Resolve classifies err and applies the registered mapping for its kind.
+func Resolve(err error) Resolved {
+	kind := KindOf(err)
+	mapping := Lookup(kind)
+	resolved := Resolved{
+		Kind:       kind,
+		HTTPStatus: mapping.HTTPStatus,
+		GRPCCode:   mapping.GRPCCode,
+		Code:       mapping.Code,
+		Message:    mapping.Message,
+		Retryable:  mapping.Retryable,
+	}
+
+	if mapping.ExposeMessage {
+		resolved.Message = exposedMessage(err, mapping.Message)
+	}
+	if appError, ok := As(err); ok {
+		if appError.Code != "" {
+			resolved.Code = appError.Code
+		}
+		resolved.Details = appError.Details
+		resolved.Retryable = resolved.Retryable || appError.Retryable
+	}
+	return resolved
+}
+
+// exposedMessage returns err's message without the cause chain of its *Error, which may
+// carry internal details. Context wrapped around the *Error is kept.
+func exposedMessage(err error, fallback string) string {
+	appError, ok := As(err)
+	if !ok || appError.Cause == nil {
+		return err.Error()
+	}
+	if appError.Message == "" {
+		return fallback
+	}
+	return strings.Replace(err.Error(), appError.Error(), appError.Message, 1)
+}
+
+// HTTPStatus returns the HTTP status code registered for err's kind.
+func HTTPStatus(err error) int {
+	return Lookup(KindOf(err)).HTTPStatus
+}
+
+// GRPCStatus returns the gRPC status code registered for err's kind.
+func GRPCStatus(err error) GRPCCode {
+	return Lookup(KindOf(err)).GRPCCode
+}
@@ -0,0 +1,115 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	StatusPass = "pass"
+	StatusFail = "fail"
+)
+
+var errShuttingDown = errors.New("server is shutting down")
+
+// Check reports whether a dependency is healthy. It should honour ctx cancellation.
+type Check func(ctx context.Context) error
+
+// CheckResult is the outcome of a single check.
+type CheckResult struct {
+	Status    string  `json:"status"`
+	LatencyMS float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// Report is the aggregated readiness report.
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+// Healthy reports whether every check passed.
+func (report Report) Healthy() bool {
+	return report.Status == StatusPass
+}
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Checker runs registered readiness checks concurrently with a per-check timeout.
+// It reports not ready once MarkShuttingDown is called, so load balancers stop routing
+// traffic before the server stops accepting connections.
+type Checker struct {
+	timeout      time.Duration
+	mutex        sync.RWMutex
+	checks       []namedCheck
+	shuttingDown atomic.Bool
+}
+
+// NewChecker creates a checker whose checks each get at most timeout to complete.
+func NewChecker(timeout time.Duration) *Checker {
+	return &Checker{timeout: timeout}
+}
+
+// Register adds a named readiness check.
+func (checker *Checker) Register(name string, check Check) {
+	checker.mutex.Lock()
+	defer checker.mutex.Unlock()
+	checker.checks = append(checker.checks, namedCheck{name: name, check: check})
+}
+
+// MarkShuttingDown makes every subsequent report fail.
+func (checker *Checker) MarkShuttingDown() {
+	checker.shuttingDown.Store(true)
+}
+
+// Run executes all checks and aggregates their results.
+func (checker *Checker) Run(ctx context.Context) Report {
+	checker.mutex.RLock()
+	checks := append([]namedCheck(nil), checker.checks...)
+	checker.mutex.RUnlock()
+
+	results := make([]CheckResult, len(checks))
+	var waitGroup sync.WaitGroup
+	for index, registered := range checks {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			results[index] = checker.runCheck(ctx, registered.check)
+		}()
+	}
+	waitGroup.Wait()
+
+	report := Report{Status: StatusPass, Checks: make(map[string]CheckResult, len(checks)+1)}
+	for index, registered := range checks {
+		report.Checks[registered.name] = results[index]
+		if results[index].Status != StatusPass {
+			report.Status = StatusFail
+		}
+	}
+	if checker.shuttingDown.Load() {
+		report.Status = StatusFail
+		report.Checks["shutdown"] = CheckResult{Status: StatusFail, Error: errShuttingDown.Error()}
+	}
+	return report
+}
+
+// runCheck executes one check with the checker's timeout and measures its latency.
+func (checker *Checker) runCheck(ctx context.Context, check Check) CheckResult {
+	checkContext, cancel := context.WithTimeout(ctx, checker.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check(checkContext)
+	result := CheckResult{Status: StatusPass, LatencyMS: float64(time.Since(start).Microseconds()) / 1000}
+	if err != nil {
+		result.Status = StatusFail
+		result.Error = err.Error()
+	}
+	return result
+}
@@ -0,0 +1,60 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChecker_AllPass(t *testing.T) {
+	checker := NewChecker(time.Second)
+	checker.Register("database", func(context.Context) error { return nil })
+	checker.Register("cache", func(context.Context) error { return nil })
+
+	report := checker.Run(context.Background())
+
+	require.True(t, report.Healthy())
+	require.Len(t, report.Checks, 2)
+	require.Equal(t, StatusPass, report.Checks["database"].Status)
+}
+
+func TestChecker_FailingCheck(t *testing.T) {
+	checker := NewChecker(time.Second)
+	checker.Register("database", func(context.Context) error { return errors.New("connection refused") })
+	checker.Register("cache", func(context.Context) error { return nil })
+
+	report := checker.Run(context.Background())
+
+	require.False(t, report.Healthy())
+	require.Equal(t, StatusFail, report.Checks["database"].Status)
+	require.Equal(t, "connection refused", report.Checks["database"].Error)
+	require.Equal(t, StatusPass, report.Checks["cache"].Status)
+}
+
+func TestChecker_TimesOutSlowChecks(t *testing.T) {
+	checker := NewChecker(10 * time.Millisecond)
+	checker.Register("slow", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	report := checker.Run(context.Background())
+
+	require.False(t, report.Healthy())
+	require.Equal(t, context.DeadlineExceeded.Error(), report.Checks["slow"].Error)
+}
+
+func TestChecker_ShuttingDown(t *testing.T) {
+	checker := NewChecker(time.Second)
+	checker.Register("database", func(context.Context) error { return nil })
+
+	checker.MarkShuttingDown()
+	report := checker.Run(context.Background())
+
+	require.False(t, report.Healthy())
+	require.Equal(t, StatusFail, report.Checks["shutdown"].Status)
+	require.Equal(t, StatusPass, report.Checks["database"].Status)
+}
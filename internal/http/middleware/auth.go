@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/bkiran6398/library/internal/auth"
+	intErr "github.com/bkiran6398/library/internal/errors"
+	"github.com/bkiran6398/library/internal/http/response"
+	"github.com/rs/zerolog"
+)
+
+// Authenticator turns the credentials of an Authorization header into a principal.
+type Authenticator interface {
+	Authenticate(ctx context.Context, credentials string) (auth.Principal, error)
+}
+
+// Authentication requires every request to carry an Authorization header using one of the
+// given schemes (e.g. "Bearer"). The resolved principal is stored in the request context.
+// Missing or invalid credentials get a 401 in the configured error format.
+func Authentication(logger zerolog.Logger, authenticators map[string]Authenticator) func(http.Handler) http.Handler {
+	challenge := buildChallenge(authenticators)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			scheme, credentials, ok := parseAuthorizationHeader(r.Header.Get("Authorization"))
+			if !ok {
+				rejectUnauthenticated(w, challenge, intErr.New(intErr.KindUnauthorized, "missing credentials"))
+				return
+			}
+
+			authenticator, ok := lookupAuthenticator(authenticators, scheme)
+			if !ok {
+				rejectUnauthenticated(w, challenge, intErr.New(intErr.KindUnauthorized, "unsupported authorization scheme"))
+				return
+			}
+
+			principal, err := authenticator.Authenticate(r.Context(), credentials)
+			if err != nil {
+				logger.Debug().Ctx(r.Context()).Err(err).Str("request_id", GetRequestID(r.Context())).Str("scheme", scheme).Msg("authentication failed")
+				rejectUnauthenticated(w, challenge, intErr.New(intErr.KindUnauthorized, "invalid credentials"))
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(auth.WithPrincipal(r.Context(), principal)))
+		})
+	}
+}
+
+// parseAuthorizationHeader splits "Scheme credentials" into its parts.
+func parseAuthorizationHeader(header string) (scheme, credentials string, ok bool) {
+	scheme, credentials, ok = strings.Cut(strings.TrimSpace(header), " ")
+	credentials = strings.TrimSpace(credentials)
+	return scheme, credentials, ok && credentials != ""
+}
+
+// lookupAuthenticator finds the authenticator for a scheme; scheme names are case-insensitive.
+func lookupAuthenticator(authenticators map[string]Authenticator, scheme string) (Authenticator, bool) {
+	for name, authenticator := range authenticators {
+		if strings.EqualFold(name, scheme) {
+			return authenticator, true
+		}
+	}
+	return nil, false
+}
+
+// buildChallenge lists the accepted schemes for the WWW-Authenticate header.
+func buildChallenge(authenticators map[string]Authenticator) string {
+	schemes := make([]string, 0, len(authenticators))
+	for name := range authenticators {
+		schemes = append(schemes, name)
+	}
+	slices.Sort(schemes)
+	return strings.Join(schemes, ", ")
+}
+
+// rejectUnauthenticated writes a 401 response with a WWW-Authenticate challenge.
+func rejectUnauthenticated(w http.ResponseWriter, challenge string, err error) {
+	if challenge != "" {
+		w.Header().Set("WWW-Authenticate", challenge)
+	}
+	response.MapServiceErrorToHTTP(w, err)
+}
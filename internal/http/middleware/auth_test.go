@@ -0,0 +1,110 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bkiran6398/library/internal/auth"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+type stubAuthenticator struct {
+	credentials string
+	principal   auth.Principal
+}
+
+func (authenticator stubAuthenticator) Authenticate(_ context.Context, credentials string) (auth.Principal, error) {
+	if credentials != authenticator.credentials {
+		return auth.Principal{}, errors.New("bad credentials")
+	}
+	return authenticator.principal, nil
+}
+
+func newAuthenticatedHandler() http.Handler {
+	authenticators := map[string]Authenticator{
+		"Bearer": stubAuthenticator{credentials: "good-token", principal: auth.Principal{Subject: "user-1"}},
+	}
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal, ok := auth.PrincipalFromContext(r.Context())
+		if !ok {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte(principal.Subject))
+	})
+	return Authentication(zerolog.Nop(), authenticators)(next)
+}
+
+func TestAuthentication_ValidCredentials(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/v1/books", nil)
+	req.Header.Set("Authorization", "bearer good-token")
+	w := httptest.NewRecorder()
+
+	newAuthenticatedHandler().ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "user-1", w.Body.String())
+}
+
+func TestAuthentication_Rejected(t *testing.T) {
+	tests := []struct {
+		name          string
+		authorization string
+	}{
+		{name: "missing header", authorization: ""},
+		{name: "unsupported scheme", authorization: "Basic dXNlcjpwYXNz"},
+		{name: "invalid token", authorization: "Bearer bad-token"},
+		{name: "empty credentials", authorization: "Bearer "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v1/books", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+
+			newAuthenticatedHandler().ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusUnauthorized, w.Code)
+			require.Equal(t, "Bearer", w.Header().Get("WWW-Authenticate"))
+
+			var errorResponse map[string]map[string]interface{}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errorResponse))
+			require.Equal(t, "unauthorized", errorResponse["error"]["code"])
+		})
+	}
+}
+
+func TestRequirePermission(t *testing.T) {
+	policy := auth.NewPolicy(map[string][]string{"patron": {"books:read"}})
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	handler := RequirePermission(policy, auth.PermissionBooksWrite)(next)
+
+	patron := auth.Principal{Subject: "p", Type: auth.PrincipalTypeUser, Roles: []string{"patron"}}
+	req := httptest.NewRequest(http.MethodPost, "/v1/books", nil)
+	req = req.WithContext(auth.WithPrincipal(req.Context(), patron))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusForbidden, w.Code)
+	var errorResponse map[string]map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errorResponse))
+	require.Equal(t, "forbidden", errorResponse["error"]["code"])
+
+	w = httptest.NewRecorder()
+	RequirePermission(policy, auth.PermissionBooksRead)(next).ServeHTTP(w, req)
+	require.Equal(t, http.StatusNoContent, w.Code)
+
+	w = httptest.NewRecorder()
+	RequirePermission(nil, auth.PermissionBooksWrite)(next).ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/books", nil))
+	require.Equal(t, http.StatusNoContent, w.Code)
+}
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/bkiran6398/library/internal/auth"
+	intErr "github.com/bkiran6398/library/internal/errors"
+	"github.com/bkiran6398/library/internal/http/response"
+)
+
+// RequirePermission rejects requests whose principal lacks permission with a 403.
+// It must run after Authentication. A nil policy disables authorization checks, so routes
+// that must never be anonymous should not be registered without a policy.
+func RequirePermission(policy *auth.Policy, permission auth.Permission) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if policy == nil {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, ok := auth.PrincipalFromContext(r.Context())
+			if !ok {
+				response.MapServiceErrorToHTTP(w, intErr.New(intErr.KindUnauthorized, "missing credentials"))
+				return
+			}
+			if !policy.Allows(principal, permission) {
+				response.MapServiceErrorToHTTP(w, intErr.New(intErr.KindForbidden, "missing permission "+string(permission)).
+					WithDetails(map[string]string{"permission": string(permission)}))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/bkiran6398/library/internal/http/response"
+)
+
+// ErrorFormat negotiates the error response format for each request. Clients asking for
+// application/problem+json get RFC 7807 documents; everyone else gets defaultFormat.
+// It must run after RequestID so the request ID can be used as the problem instance.
+func ErrorFormat(defaultFormat response.ErrorFormat, options response.ProblemOptions) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			format := response.NegotiateErrorFormat(r, defaultFormat)
+			next.ServeHTTP(response.WithErrorFormat(w, format, options, GetRequestID(r.Context())), r)
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/bkiran6398/library/internal/http/response"
+	"github.com/bkiran6398/library/internal/idempotency"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// maxIdempotencyKeyLength bounds the Idempotency-Key header.
+	maxIdempotencyKeyLength = 255
+	// idempotencyStoreTimeout bounds the store calls made after the handler has run, which
+	// must not be cut short by a client that went away.
+	idempotencyStoreTimeout = 5 * time.Second
+)
+
+// IdempotencyConfig configures how long keys are remembered, how large the requests and
+// responses kept in memory may be, and how anonymous clients are told apart.
+type IdempotencyConfig struct {
+	TTL time.Duration
+	// Lease is how long an unfinished request holds its key before a retry may claim it.
+	Lease time.Duration
+	// MaxRequestBytes bounds the body of a request carrying a key, which is read into memory
+	// to fingerprint it; larger requests are rejected with 413.
+	MaxRequestBytes int64
+	// MaxResponseBytes bounds the response body that is stored. Larger responses are sent
+	// but not stored, and the key is released so a retry runs the request again.
+	MaxResponseBytes int
+	// ExcludedRoutes lists the path templates of routes that ignore Idempotency-Key, such as
+	// uploads that accept bodies larger than MaxRequestBytes.
+	ExcludedRoutes []string
+	// TrustForwardedFor uses the first X-Forwarded-For address as the client IP.
+	// Only enable it behind a proxy that overwrites the header.
+	TrustForwardedFor bool
+}
+
+// Idempotency makes POST requests carrying an Idempotency-Key header safe to retry. The first
+// request with a key runs and its response is stored; repeats with the same payload get the
+// stored status and body back, marked with Idempotent-Replayed, without running again. Keys
+// are scoped to the client as identified for rate limiting, the principal or else the client
+// IP, so it must run after Authentication. Responses with a 5xx status are not stored, so
+// such requests can be retried.
+// If the store fails the request runs without protection, like RateLimit does.
+func Idempotency(logger zerolog.Logger, store idempotency.Store, config IdempotencyConfig) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get("Idempotency-Key")
+			if r.Method != http.MethodPost || key == "" || slices.Contains(config.ExcludedRoutes, routeTemplate(r)) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if len(key) > maxIdempotencyKeyLength {
+				response.Error(w, http.StatusBadRequest, "bad_request", "Idempotency-Key must be at most 255 characters", nil)
+				return
+			}
+
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, config.MaxRequestBytes))
+			if err != nil {
+				var maxBytesError *http.MaxBytesError
+				if errors.As(err, &maxBytesError) {
+					response.Error(w, http.StatusRequestEntityTooLarge, "payload_too_large", "Request body is too large for an Idempotency-Key", nil)
+					return
+				}
+				response.Error(w, http.StatusBadRequest, "bad_request", "Invalid request body", nil)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			storeKey := clientKey(r, config.TrustForwardedFor) + ":" + key
+			fingerprint := requestFingerprint(r, body)
+			record, started, err := store.Begin(r.Context(), storeKey, fingerprint, config.Lease, config.TTL)
+			if err != nil {
+				logger.Warn().Ctx(r.Context()).Err(err).Str("request_id", GetRequestID(r.Context())).Msg("idempotency store unavailable")
+				next.ServeHTTP(w, r)
+				return
+			}
+			if !started {
+				replay(w, record, fingerprint)
+				return
+			}
+
+			completed := false
+			defer func() {
+				if completed {
+					return
+				}
+				storeContext, cancel := context.WithTimeout(context.WithoutCancel(r.Context()), idempotencyStoreTimeout)
+				defer cancel()
+				if err := store.Release(storeContext, storeKey, record.ClaimToken); err != nil {
+					logger.Warn().Ctx(r.Context()).Err(err).Str("request_id", GetRequestID(r.Context())).Msg("failed to release idempotency key")
+				}
+			}()
+
+			recorder := &recordingResponseWriter{ResponseWriter: w, status: http.StatusOK, maxBodyBytes: config.MaxResponseBytes}
+			next.ServeHTTP(recorder, r)
+			if recorder.status >= http.StatusInternalServerError || recorder.truncated {
+				return
+			}
+
+			stored := idempotency.Response{StatusCode: recorder.status, ContentType: recorder.Header().Get("Content-Type"), Body: recorder.body.Bytes()}
+			storeContext, cancel := context.WithTimeout(context.WithoutCancel(r.Context()), idempotencyStoreTimeout)
+			defer cancel()
+			if err := store.Complete(storeContext, storeKey, record.ClaimToken, stored); err != nil {
+				logger.Warn().Ctx(r.Context()).Err(err).Str("request_id", GetRequestID(r.Context())).Msg("failed to store idempotent response")
+				return
+			}
+			completed = true
+		})
+	}
+}
+
+// replay answers a repeated request from the record of the first one.
+func replay(w http.ResponseWriter, record idempotency.Record, fingerprint string) {
+	if record.Fingerprint != fingerprint {
+		response.MapServiceErrorToHTTP(w, idempotency.ErrKeyReused)
+		return
+	}
+	if record.Response == nil {
+		w.Header().Set("Retry-After", "1")
+		response.MapServiceErrorToHTTP(w, idempotency.ErrInProgress)
+		return
+	}
+
+	if record.Response.ContentType != "" {
+		w.Header().Set("Content-Type", record.Response.ContentType)
+	}
+	w.Header().Set("Idempotent-Replayed", "true")
+	w.WriteHeader(record.Response.StatusCode)
+	_, _ = w.Write(record.Response.Body)
+}
+
+// requestFingerprint identifies the payload of a request, so a key reused for a different
+// request is detected.
+func requestFingerprint(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	_, _ = io.WriteString(hash, r.Method+" "+r.URL.RequestURI()+"\n"+r.Header.Get("Content-Type")+"\n")
+	_, _ = hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// recordingResponseWriter copies the status and body of a response as it is written. It
+// stops copying the body once it would exceed maxBodyBytes and marks it truncated.
+type recordingResponseWriter struct {
+	http.ResponseWriter
+	status       int
+	wroteHeader  bool
+	body         bytes.Buffer
+	maxBodyBytes int
+	truncated    bool
+}
+
+func (w *recordingResponseWriter) WriteHeader(statusCode int) {
+	if !w.wroteHeader {
+		w.status = statusCode
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *recordingResponseWriter) Write(data []byte) (int, error) {
+	w.wroteHeader = true
+	if !w.truncated && w.body.Len()+len(data) > w.maxBodyBytes {
+		w.truncated = true
+		w.body = bytes.Buffer{}
+	}
+	if !w.truncated {
+		w.body.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController and response helpers.
+func (w *recordingResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,226 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bkiran6398/library/internal/auth"
+	"github.com/bkiran6398/library/internal/http/response"
+	"github.com/bkiran6398/library/internal/idempotency"
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+type failingIdempotencyStore struct{}
+
+func (failingIdempotencyStore) Begin(context.Context, string, string, time.Duration, time.Duration) (idempotency.Record, bool, error) {
+	return idempotency.Record{}, false, errors.New("store down")
+}
+
+func (failingIdempotencyStore) Complete(context.Context, string, string, idempotency.Response) error {
+	return errors.New("store down")
+}
+
+func (failingIdempotencyStore) Release(context.Context, string, string) error {
+	return errors.New("store down")
+}
+
+// deadlineRecordingStore records the deadline of the context passed to Complete.
+type deadlineRecordingStore struct {
+	*idempotency.MemoryStore
+	completeDeadline time.Time
+}
+
+func (store *deadlineRecordingStore) Complete(ctx context.Context, key, claimToken string, response idempotency.Response) error {
+	store.completeDeadline, _ = ctx.Deadline()
+	return store.MemoryStore.Complete(ctx, key, claimToken, response)
+}
+
+// newIdempotentHandler counts the requests that reach the handler, which answers 201 or,
+// for bodies containing "fail", 503.
+func newIdempotentHandler(store idempotency.Store, calls *int) http.Handler {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		body, _ := io.ReadAll(r.Body)
+		if strings.Contains(string(body), "fail") {
+			response.Error(w, http.StatusServiceUnavailable, "unavailable", "Service unavailable", nil)
+			return
+		}
+		response.JSON(w, http.StatusCreated, map[string]int{"call": *calls})
+	})
+	return Idempotency(zerolog.Nop(), store, IdempotencyConfig{TTL: time.Hour, Lease: time.Minute, MaxRequestBytes: 1 << 10, MaxResponseBytes: 1 << 10})(next)
+}
+
+func newIdempotentRequest(key, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/v1/books", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set("Idempotency-Key", key)
+	}
+	return req
+}
+
+func TestIdempotency_ReplaysResponse(t *testing.T) {
+	var calls int
+	handler := newIdempotentHandler(idempotency.NewMemoryStore(), &calls)
+
+	first := httptest.NewRecorder()
+	handler.ServeHTTP(first, newIdempotentRequest("key-1", `{"title":"Dune"}`))
+	require.Equal(t, http.StatusCreated, first.Code)
+
+	replayed := httptest.NewRecorder()
+	handler.ServeHTTP(replayed, newIdempotentRequest("key-1", `{"title":"Dune"}`))
+	require.Equal(t, http.StatusCreated, replayed.Code)
+	require.Equal(t, first.Body.String(), replayed.Body.String())
+	require.Equal(t, "application/json", replayed.Header().Get("Content-Type"))
+	require.Equal(t, "true", replayed.Header().Get("Idempotent-Replayed"))
+	require.Equal(t, 1, calls)
+
+	// Requests without a key are never deduplicated.
+	for range 2 {
+		handler.ServeHTTP(httptest.NewRecorder(), newIdempotentRequest("", `{"title":"Dune"}`))
+	}
+	require.Equal(t, 3, calls)
+}
+
+func TestIdempotency_RejectsKeyReusedWithDifferentPayload(t *testing.T) {
+	var calls int
+	handler := newIdempotentHandler(idempotency.NewMemoryStore(), &calls)
+
+	handler.ServeHTTP(httptest.NewRecorder(), newIdempotentRequest("key-1", `{"title":"Dune"}`))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, newIdempotentRequest("key-1", `{"title":"Emma"}`))
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	require.Contains(t, w.Body.String(), "idempotency_key_reused")
+	require.Equal(t, 1, calls)
+}
+
+func TestIdempotency_InProgressAndServerErrors(t *testing.T) {
+	store := idempotency.NewMemoryStore()
+	var calls int
+	handler := newIdempotentHandler(store, &calls)
+
+	_, _, err := store.Begin(context.Background(), "ip:192.0.2.1:busy", requestFingerprint(newIdempotentRequest("busy", "{}"), []byte("{}")), time.Minute, time.Hour)
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, newIdempotentRequest("busy", "{}"))
+	require.Equal(t, http.StatusConflict, w.Code)
+	require.Equal(t, "1", w.Header().Get("Retry-After"))
+	require.Equal(t, 0, calls)
+
+	// A 5xx response releases the key so the retry runs again.
+	for range 2 {
+		w = httptest.NewRecorder()
+		handler.ServeHTTP(w, newIdempotentRequest("flaky", `{"fail":true}`))
+		require.Equal(t, http.StatusServiceUnavailable, w.Code)
+	}
+	require.Equal(t, 2, calls)
+}
+
+func TestIdempotency_FailsOpenWhenStoreIsDown(t *testing.T) {
+	var calls int
+	handler := newIdempotentHandler(failingIdempotencyStore{}, &calls)
+
+	for range 2 {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newIdempotentRequest("key-1", "{}"))
+		require.Equal(t, http.StatusCreated, w.Code)
+	}
+	require.Equal(t, 2, calls)
+}
+
+func TestIdempotency_BoundsRequestAndStoredResponse(t *testing.T) {
+	var calls int
+	handler := newIdempotentHandler(idempotency.NewMemoryStore(), &calls)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, newIdempotentRequest("large-request", strings.Repeat("a", 2<<10)))
+	require.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	require.Contains(t, w.Body.String(), "payload_too_large")
+	require.Equal(t, 0, calls)
+
+	// A response over the limit is sent in full but not stored, so the retry runs again.
+	largeResponse := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		response.JSON(w, http.StatusCreated, map[string]string{"padding": strings.Repeat("b", 2<<10)})
+	})
+	handler = Idempotency(zerolog.Nop(), idempotency.NewMemoryStore(), IdempotencyConfig{TTL: time.Hour, Lease: time.Minute, MaxRequestBytes: 1 << 10, MaxResponseBytes: 1 << 10})(largeResponse)
+	for range 2 {
+		w = httptest.NewRecorder()
+		handler.ServeHTTP(w, newIdempotentRequest("large-response", "{}"))
+		require.Equal(t, http.StatusCreated, w.Code)
+		require.Greater(t, w.Body.Len(), 2<<10)
+		require.Empty(t, w.Header().Get("Idempotent-Replayed"))
+	}
+	require.Equal(t, 2, calls)
+}
+
+func TestIdempotency_ScopesKeysToClient(t *testing.T) {
+	var calls int
+	handler := newIdempotentHandler(idempotency.NewMemoryStore(), &calls)
+	requestAs := func(subject, remoteAddr string) *http.Request {
+		req := newIdempotentRequest("shared", `{"title":"Dune"}`)
+		req.RemoteAddr = remoteAddr
+		if subject != "" {
+			req = req.WithContext(auth.WithPrincipal(req.Context(), auth.Principal{Subject: subject, Type: auth.PrincipalTypeAPIKey}))
+		}
+		return req
+	}
+
+	// Each principal gets its own key space.
+	handler.ServeHTTP(httptest.NewRecorder(), requestAs("key-a", "192.0.2.1:1000"))
+	handler.ServeHTTP(httptest.NewRecorder(), requestAs("key-b", "192.0.2.1:1000"))
+	require.Equal(t, 2, calls)
+
+	// Anonymous keys are scoped to the client IP, so common keys do not collide.
+	handler.ServeHTTP(httptest.NewRecorder(), requestAs("", "192.0.2.1:1000"))
+	handler.ServeHTTP(httptest.NewRecorder(), requestAs("", "198.51.100.7:2000"))
+	require.Equal(t, 4, calls)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, requestAs("", "198.51.100.7:2001"))
+	require.Equal(t, "true", w.Header().Get("Idempotent-Replayed"))
+	require.Equal(t, 4, calls)
+}
+
+func TestIdempotency_StoreTimeoutStartsAfterHandler(t *testing.T) {
+	store := &deadlineRecordingStore{MemoryStore: idempotency.NewMemoryStore()}
+	var handlerDone time.Time
+	slow := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(10 * time.Millisecond)
+		response.JSON(w, http.StatusCreated, map[string]string{"status": "created"})
+		handlerDone = time.Now()
+	})
+	handler := Idempotency(zerolog.Nop(), store, IdempotencyConfig{TTL: time.Hour, Lease: time.Minute, MaxRequestBytes: 1 << 10, MaxResponseBytes: 1 << 10})(slow)
+
+	handler.ServeHTTP(httptest.NewRecorder(), newIdempotentRequest("slow", "{}"))
+	require.False(t, store.completeDeadline.Before(handlerDone.Add(idempotencyStoreTimeout)))
+}
+
+func TestIdempotency_IgnoresKeyOnExcludedRoutes(t *testing.T) {
+	var calls int
+	router := mux.NewRouter()
+	router.Use(Idempotency(zerolog.Nop(), idempotency.NewMemoryStore(), IdempotencyConfig{TTL: time.Hour, Lease: time.Minute, MaxRequestBytes: 1 << 10, MaxResponseBytes: 1 << 10, ExcludedRoutes: []string{"/v1/books/import"}}))
+	router.HandleFunc("/v1/books/import", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := io.ReadAll(r.Body)
+		response.JSON(w, http.StatusOK, map[string]int{"bytes": len(body)})
+	}).Methods(http.MethodPost)
+
+	for range 2 {
+		req := httptest.NewRequest(http.MethodPost, "/v1/books/import", strings.NewReader(strings.Repeat("a", 2<<10)))
+		req.Header.Set("Idempotency-Key", "import-1")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Empty(t, w.Header().Get("Idempotent-Replayed"))
+	}
+	require.Equal(t, 2, calls)
+}
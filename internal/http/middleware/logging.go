@@ -14,6 +14,7 @@ func Logging(logger zerolog.Logger) func(http.Handler) http.Handler {
 			rw := &responseWriter{ResponseWriter: w, status: 200}
 			next.ServeHTTP(rw, r)
 			logger.Info().
+				Ctx(r.Context()).
 				Str("method", r.Method).
 				Str("path", r.URL.Path).
 				Int("status", rw.status).
@@ -35,3 +36,7 @@ func (w *responseWriter) WriteHeader(statusCode int) {
 	w.ResponseWriter.WriteHeader(statusCode)
 }
 
+// Unwrap exposes the underlying writer to http.ResponseController and response helpers.
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/bkiran6398/library/internal/metrics"
+	"github.com/gorilla/mux"
+)
+
+// unmatchedRoute labels requests that did not match a registered route, keeping
+// label cardinality bounded regardless of the paths clients send.
+const unmatchedRoute = "unmatched"
+
+// Metrics records request counts, latencies and in-flight requests. Requests are
+// labelled by the mux route template (e.g. /v1/books/{id}), never the raw path.
+func Metrics(httpMetrics *metrics.HTTPMetrics) func(http.Handler) http.Handler {
+	return instrument(httpMetrics, routeTemplate)
+}
+
+// UnmatchedMetrics records requests that matched no route, such as 404 and 405 answers,
+// under the fixed "unmatched" route label. Mux does not run router middleware for them,
+// so it wraps the router's NotFoundHandler and MethodNotAllowedHandler instead.
+func UnmatchedMetrics(httpMetrics *metrics.HTTPMetrics) func(http.Handler) http.Handler {
+	return instrument(httpMetrics, func(*http.Request) string { return unmatchedRoute })
+}
+
+// instrument records the requests served by next, labelled by the route that route returns.
+func instrument(httpMetrics *metrics.HTTPMetrics, route func(*http.Request) string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			httpMetrics.RequestsInFlight.Inc()
+			defer httpMetrics.RequestsInFlight.Dec()
+
+			start := time.Now()
+			rw := &responseWriter{ResponseWriter: w, status: 200}
+			next.ServeHTTP(rw, r)
+
+			labels := []string{r.Method, route(r), strconv.Itoa(rw.status)}
+			httpMetrics.RequestsTotal.WithLabelValues(labels...).Inc()
+			httpMetrics.RequestDuration.WithLabelValues(labels...).Observe(time.Since(start).Seconds())
+		})
+	}
+}
+
+// routeTemplate returns the path template of the matched mux route.
+func routeTemplate(r *http.Request) string {
+	route := mux.CurrentRoute(r)
+	if route == nil {
+		return unmatchedRoute
+	}
+	template, err := route.GetPathTemplate()
+	if err != nil {
+		return unmatchedRoute
+	}
+	return template
+}
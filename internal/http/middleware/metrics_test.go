@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bkiran6398/library/internal/metrics"
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics_LabelsByRouteTemplate(t *testing.T) {
+	httpMetrics := metrics.NewHTTPMetrics(prometheus.NewRegistry())
+
+	router := mux.NewRouter()
+	router.Use(Metrics(httpMetrics))
+	apiRouter := router.PathPrefix("/v1").Subrouter()
+	apiRouter.HandleFunc("/books/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}).Methods(http.MethodGet)
+
+	for _, path := range []string{"/v1/books/1", "/v1/books/2"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	require.Equal(t, float64(2), testutil.ToFloat64(httpMetrics.RequestsTotal.WithLabelValues(http.MethodGet, "/v1/books/{id}", "404")))
+	require.Equal(t, 1, testutil.CollectAndCount(httpMetrics.RequestDuration))
+	require.Equal(t, float64(0), testutil.ToFloat64(httpMetrics.RequestsInFlight))
+}
+
+func TestUnmatchedMetrics_CountsNotFoundAndMethodNotAllowed(t *testing.T) {
+	httpMetrics := metrics.NewHTTPMetrics(prometheus.NewRegistry())
+
+	router := mux.NewRouter()
+	router.Use(Metrics(httpMetrics))
+	router.NotFoundHandler = UnmatchedMetrics(httpMetrics)(http.NotFoundHandler())
+	router.MethodNotAllowedHandler = UnmatchedMetrics(httpMetrics)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}))
+	apiRouter := router.PathPrefix("/v1").Subrouter()
+	apiRouter.HandleFunc("/books/{id}", func(w http.ResponseWriter, r *http.Request) {}).Methods(http.MethodGet)
+
+	for _, path := range []string{"/missing", "/v1/missing"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/v1/books/1", nil))
+	require.Equal(t, http.StatusMethodNotAllowed, w.Code)
+
+	require.Equal(t, float64(2), testutil.ToFloat64(httpMetrics.RequestsTotal.WithLabelValues(http.MethodGet, "unmatched", "404")))
+	require.Equal(t, float64(1), testutil.ToFloat64(httpMetrics.RequestsTotal.WithLabelValues(http.MethodDelete, "unmatched", "405")))
+}
@@ -0,0 +1,117 @@
+package middleware
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bkiran6398/library/internal/auth"
+	intErr "github.com/bkiran6398/library/internal/errors"
+	"github.com/bkiran6398/library/internal/http/response"
+	"github.com/bkiran6398/library/internal/ratelimit"
+	"github.com/rs/zerolog"
+)
+
+// RateLimitConfig holds separate token bucket limits for read and write requests, and
+// the per-IP limit applied before authentication.
+type RateLimitConfig struct {
+	Read  ratelimit.Limit
+	Write ratelimit.Limit
+	PerIP ratelimit.Limit
+	// TrustForwardedFor uses the first X-Forwarded-For address as the client IP.
+	// Only enable it behind a proxy that overwrites the header.
+	TrustForwardedFor bool
+}
+
+// RateLimit enforces per-client token buckets. Clients are identified by API key,
+// then by authenticated principal, then by IP, so it must run after Authentication.
+// If the store fails the request is let through, since an outage of the limiter
+// should not take the API down with it.
+func RateLimit(logger zerolog.Logger, store ratelimit.Store, config RateLimitConfig) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			group, limit := "write", config.Write
+			if isReadMethod(r.Method) {
+				group, limit = "read", config.Read
+			}
+
+			enforceRateLimit(logger, store, group+":"+clientKey(r, config.TrustForwardedFor), limit, w, r, next)
+		})
+	}
+}
+
+// RateLimitByIP enforces a per-IP token bucket shared by all requests. It runs before
+// Authentication so that requests rejected with 401, such as credential guessing, are
+// counted too.
+func RateLimitByIP(logger zerolog.Logger, store ratelimit.Store, config RateLimitConfig) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			enforceRateLimit(logger, store, "any:ip:"+clientIP(r, config.TrustForwardedFor), config.PerIP, w, r, next)
+		})
+	}
+}
+
+// enforceRateLimit takes a token from the bucket named key and either rejects the request
+// with a 429 or passes it on. Store errors let the request through.
+func enforceRateLimit(logger zerolog.Logger, store ratelimit.Store, key string, limit ratelimit.Limit, w http.ResponseWriter, r *http.Request, next http.Handler) {
+	result, err := store.Take(r.Context(), key, limit)
+	if err != nil {
+		logger.Warn().Ctx(r.Context()).Err(err).Str("request_id", GetRequestID(r.Context())).Msg("rate limiter unavailable")
+		next.ServeHTTP(w, r)
+		return
+	}
+
+	setRateLimitHeaders(w, result)
+	if !result.Allowed {
+		w.Header().Set("Retry-After", strconv.Itoa(ceilSeconds(result.RetryAfter)))
+		response.MapServiceErrorToHTTP(w, intErr.ErrTooManyRequests)
+		return
+	}
+	next.ServeHTTP(w, r)
+}
+
+// isReadMethod reports whether the method does not modify state.
+func isReadMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// clientKey identifies the caller for rate limiting purposes.
+func clientKey(r *http.Request, trustForwardedFor bool) string {
+	if principal, ok := auth.PrincipalFromContext(r.Context()); ok {
+		if principal.Type == auth.PrincipalTypeAPIKey {
+			return "api_key:" + principal.Subject
+		}
+		return "principal:" + principal.Subject
+	}
+	return "ip:" + clientIP(r, trustForwardedFor)
+}
+
+// clientIP returns the remote address of the request without its port.
+func clientIP(r *http.Request, trustForwardedFor bool) string {
+	if trustForwardedFor {
+		if forwardedFor := r.Header.Get("X-Forwarded-For"); forwardedFor != "" {
+			first, _, _ := strings.Cut(forwardedFor, ",")
+			return strings.TrimSpace(first)
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// setRateLimitHeaders writes the RateLimit-* headers from the IETF httpapi draft.
+func setRateLimitHeaders(w http.ResponseWriter, result ratelimit.Result) {
+	w.Header().Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+	w.Header().Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	w.Header().Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.ResetAfter)))
+}
+
+// ceilSeconds rounds a duration up to whole seconds.
+func ceilSeconds(duration time.Duration) int {
+	return int(math.Ceil(duration.Seconds()))
+}
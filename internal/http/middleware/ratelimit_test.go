@@ -0,0 +1,101 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bkiran6398/library/internal/auth"
+	"github.com/bkiran6398/library/internal/ratelimit"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+type failingStore struct{}
+
+func (failingStore) Take(context.Context, string, ratelimit.Limit) (ratelimit.Result, error) {
+	return ratelimit.Result{}, errors.New("store down")
+}
+
+func newRateLimitedHandler(store ratelimit.Store) http.Handler {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	config := RateLimitConfig{
+		Read:  ratelimit.Limit{Rate: 1, Burst: 2},
+		Write: ratelimit.Limit{Rate: 1, Burst: 1},
+	}
+	return RateLimit(zerolog.Nop(), store, config)(next)
+}
+
+func TestRateLimit_RejectsWhenExhausted(t *testing.T) {
+	handler := newRateLimitedHandler(ratelimit.NewMemoryStore())
+
+	for range 2 {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/books", nil))
+		require.Equal(t, http.StatusNoContent, w.Code)
+		require.Equal(t, "2", w.Header().Get("RateLimit-Limit"))
+	}
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/books", nil))
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+	require.Equal(t, "1", w.Header().Get("Retry-After"))
+	require.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+
+	// Writes use a separate bucket.
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/books", nil))
+	require.Equal(t, http.StatusNoContent, w.Code)
+	require.Equal(t, "1", w.Header().Get("RateLimit-Limit"))
+}
+
+func TestRateLimit_KeysByPrincipal(t *testing.T) {
+	handler := newRateLimitedHandler(ratelimit.NewMemoryStore())
+
+	for _, subject := range []string{"user-1", "user-2"} {
+		req := httptest.NewRequest(http.MethodPost, "/v1/books", nil)
+		req = req.WithContext(auth.WithPrincipal(req.Context(), auth.Principal{Subject: subject, Type: auth.PrincipalTypeUser}))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		require.Equal(t, http.StatusNoContent, w.Code)
+	}
+}
+
+func TestRateLimit_FailsOpen(t *testing.T) {
+	handler := newRateLimitedHandler(failingStore{})
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/books", nil))
+	require.Equal(t, http.StatusNoContent, w.Code)
+}
+
+func TestClientIP(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/v1/books", nil)
+	req.RemoteAddr = "10.0.0.1:4321"
+	req.Header.Set("X-Forwarded-For", "203.0.113.7, 10.0.0.1")
+
+	require.Equal(t, "10.0.0.1", clientIP(req, false))
+	require.Equal(t, "203.0.113.7", clientIP(req, true))
+}
+
+func TestRateLimitByIP_CountsUnauthenticatedRequests(t *testing.T) {
+	rejectAll := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	config := RateLimitConfig{PerIP: ratelimit.Limit{Rate: 1, Burst: 2}}
+	handler := RateLimitByIP(zerolog.Nop(), ratelimit.NewMemoryStore(), config)(rejectAll)
+
+	for range 2 {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/books", nil))
+		require.Equal(t, http.StatusUnauthorized, w.Code)
+	}
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/books", nil))
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+}
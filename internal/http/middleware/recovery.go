@@ -12,7 +12,12 @@ func Recovery(logger zerolog.Logger) func(http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			defer func() {
 				if rec := recover(); rec != nil {
-					logger.Error().Interface("panic", rec).Str("request_id", GetRequestID(r.Context())).Msg("panic recovered")
+					if rec == http.ErrAbortHandler {
+						// The handler deliberately dropped a partly written response.
+						logger.Warn().Ctx(r.Context()).Str("request_id", GetRequestID(r.Context())).Msg("response aborted")
+						panic(rec)
+					}
+					logger.Error().Ctx(r.Context()).Interface("panic", rec).Str("request_id", GetRequestID(r.Context())).Msg("panic recovered")
 					response.Error(w, http.StatusInternalServerError, "internal_error", "Internal server error", nil)
 				}
 			}()
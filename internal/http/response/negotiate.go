@@ -0,0 +1,88 @@
+package response
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// NegotiateContentType returns the offered media type the request's Accept header ranks
+// highest, honouring q-values and wildcards; earlier offers win ties. Without an Accept
+// header, or when no offer is acceptable, the first offer is returned.
+func NegotiateContentType(r *http.Request, offers ...string) string {
+	ranges := parseAccept(r.Header.Get("Accept"))
+	best, bestQuality := offers[0], 0.0
+	for _, offer := range offers {
+		if quality := acceptQuality(ranges, offer); quality > bestQuality {
+			best, bestQuality = offer, quality
+		}
+	}
+	return best
+}
+
+// NegotiateErrorFormat picks the error format for a request based on its Accept header.
+// Listing application/problem+json with a non-zero q-value selects problem documents and
+// q=0 refuses them; otherwise defaultFormat is used.
+func NegotiateErrorFormat(r *http.Request, defaultFormat ErrorFormat) ErrorFormat {
+	for _, accepted := range parseAccept(r.Header.Get("Accept")) {
+		if accepted.mediaType != ContentTypeProblemJSON {
+			continue
+		}
+		if accepted.quality > 0 {
+			return ErrorFormatProblem
+		}
+		return ErrorFormatJSON
+	}
+	return defaultFormat
+}
+
+// mediaRange is one entry of an Accept header.
+type mediaRange struct {
+	mediaType string
+	quality   float64
+}
+
+func parseAccept(header string) []mediaRange {
+	var ranges []mediaRange
+	for _, entry := range strings.Split(header, ",") {
+		parameters := strings.Split(entry, ";")
+		mediaType := strings.ToLower(strings.TrimSpace(parameters[0]))
+		if mediaType == "" {
+			continue
+		}
+		quality := 1.0
+		for _, parameter := range parameters[1:] {
+			name, value, _ := strings.Cut(strings.TrimSpace(parameter), "=")
+			if strings.EqualFold(name, "q") {
+				if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+					quality = parsed
+				}
+			}
+		}
+		ranges = append(ranges, mediaRange{mediaType: mediaType, quality: quality})
+	}
+	return ranges
+}
+
+// acceptQuality returns the quality of offer under the most specific matching range.
+func acceptQuality(ranges []mediaRange, offer string) float64 {
+	offerType, _, _ := strings.Cut(offer, "/")
+	quality, specificity := 0.0, -1
+	for _, accepted := range ranges {
+		var matchSpecificity int
+		switch {
+		case accepted.mediaType == strings.ToLower(offer):
+			matchSpecificity = 2
+		case accepted.mediaType == offerType+"/*":
+			matchSpecificity = 1
+		case accepted.mediaType == "*/*":
+			matchSpecificity = 0
+		default:
+			continue
+		}
+		if matchSpecificity > specificity {
+			quality, specificity = accepted.quality, matchSpecificity
+		}
+	}
+	return quality
+}
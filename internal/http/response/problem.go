@@ -0,0 +1,123 @@
+package response
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+const (
+	// ContentTypeProblemJSON is the media type defined by RFC 7807 for problem details.
+	ContentTypeProblemJSON = "application/problem+json"
+
+	defaultProblemType = "about:blank"
+)
+
+// ErrorFormat selects how error responses are rendered.
+type ErrorFormat string
+
+const (
+	// ErrorFormatJSON renders errors as {"error":{"code":...,"message":...}}.
+	ErrorFormatJSON ErrorFormat = "json"
+	// ErrorFormatProblem renders errors as RFC 7807 problem details documents.
+	ErrorFormatProblem ErrorFormat = "problem"
+)
+
+// ProblemOptions controls how RFC 7807 documents are built.
+type ProblemOptions struct {
+	// TypeBaseURI is prefixed to the error code to build the problem "type" member.
+	// When empty, "about:blank" is used as mandated by RFC 7807.
+	TypeBaseURI string
+}
+
+// Problem is an RFC 7807 problem details document.
+// Extensions are serialized as top-level members alongside the standard ones.
+type Problem struct {
+	Type       string
+	Title      string
+	Status     int
+	Detail     string
+	Instance   string
+	Extensions map[string]interface{}
+}
+
+// MarshalJSON flattens the extension members into the problem document.
+func (problem Problem) MarshalJSON() ([]byte, error) {
+	document := make(map[string]interface{}, len(problem.Extensions)+5)
+	for key, value := range problem.Extensions {
+		document[key] = value
+	}
+	document["type"] = problem.Type
+	document["title"] = problem.Title
+	document["status"] = problem.Status
+	if problem.Detail != "" {
+		document["detail"] = problem.Detail
+	}
+	if problem.Instance != "" {
+		document["instance"] = problem.Instance
+	}
+	return json.Marshal(document)
+}
+
+// newProblem builds a problem document from the arguments accepted by Error.
+func newProblem(options ProblemOptions, instance string, status int, code, message string, details interface{}) Problem {
+	problemType := defaultProblemType
+	if options.TypeBaseURI != "" {
+		problemType = strings.TrimSuffix(options.TypeBaseURI, "/") + "/" + code
+	}
+
+	extensions := map[string]interface{}{"code": code}
+	if details != nil {
+		extensions["details"] = details
+	}
+
+	return Problem{
+		Type:       problemType,
+		Title:      http.StatusText(status),
+		Status:     status,
+		Detail:     message,
+		Instance:   instance,
+		Extensions: extensions,
+	}
+}
+
+// problemWriter carries the negotiated error format for the current request.
+type problemWriter struct {
+	http.ResponseWriter
+	format   ErrorFormat
+	options  ProblemOptions
+	instance string
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController.
+func (writer *problemWriter) Unwrap() http.ResponseWriter {
+	return writer.ResponseWriter
+}
+
+// Flush forwards to the underlying writer when it supports streaming.
+func (writer *problemWriter) Flush() {
+	if flusher, ok := writer.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// WithErrorFormat returns a writer that makes Error render in the given format.
+// instance identifies the request (typically the request ID) in problem documents.
+func WithErrorFormat(w http.ResponseWriter, format ErrorFormat, options ProblemOptions, instance string) http.ResponseWriter {
+	return &problemWriter{ResponseWriter: w, format: format, options: options, instance: instance}
+}
+
+// errorFormatOf finds the negotiated error format by walking wrapped writers.
+func errorFormatOf(w http.ResponseWriter) (*problemWriter, bool) {
+	for w != nil {
+		if writer, ok := w.(*problemWriter); ok {
+			return writer, true
+		}
+		unwrapper, ok := w.(interface{ Unwrap() http.ResponseWriter })
+		if !ok {
+			return nil, false
+		}
+		w = unwrapper.Unwrap()
+	}
+	return nil, false
+}
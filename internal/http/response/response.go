@@ -12,14 +12,17 @@ type ErrorBody struct {
 }
 
 func JSON(w http.ResponseWriter, status int, v interface{}) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	if v != nil {
-		_ = json.NewEncoder(w).Encode(v)
-	}
+	writeJSON(w, "application/json", status, v)
 }
 
+// Error writes an error response. The body is {"error":{...}} unless the request
+// negotiated RFC 7807 problem details via WithErrorFormat.
 func Error(w http.ResponseWriter, status int, code, message string, details interface{}) {
+	if writer, ok := errorFormatOf(w); ok && writer.format == ErrorFormatProblem {
+		writeJSON(w, ContentTypeProblemJSON, status, newProblem(writer.options, writer.instance, status, code, message, details))
+		return
+	}
+
 	JSON(w, status, map[string]interface{}{
 		"error": ErrorBody{
 			Code:    code,
@@ -29,3 +32,11 @@ func Error(w http.ResponseWriter, status int, code, message string, details inte
 	})
 }
 
+// writeJSON encodes v as the response body with the given content type.
+func writeJSON(w http.ResponseWriter, contentType string, status int, v interface{}) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	if v != nil {
+		_ = json.NewEncoder(w).Encode(v)
+	}
+}
@@ -0,0 +1,99 @@
+package response
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestError_DefaultFormat(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	Error(w, http.StatusNotFound, "not_found", "Resource not found", nil)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+	require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+	var body map[string]map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.Equal(t, "not_found", body["error"]["code"])
+	require.Equal(t, "Resource not found", body["error"]["message"])
+}
+
+func TestError_ProblemFormat(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	w := WithErrorFormat(recorder, ErrorFormatProblem, ProblemOptions{TypeBaseURI: "https://errors.example.com/"}, "req-123")
+
+	Error(w, http.StatusBadRequest, "bad_request", "title is required", map[string]string{"field": "title"})
+
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+	require.Equal(t, ContentTypeProblemJSON, recorder.Header().Get("Content-Type"))
+
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+	require.Equal(t, "https://errors.example.com/bad_request", body["type"])
+	require.Equal(t, "Bad Request", body["title"])
+	require.Equal(t, float64(http.StatusBadRequest), body["status"])
+	require.Equal(t, "title is required", body["detail"])
+	require.Equal(t, "req-123", body["instance"])
+	require.Equal(t, "bad_request", body["code"])
+	require.Equal(t, map[string]interface{}{"field": "title"}, body["details"])
+}
+
+func TestError_ProblemFormatDefaultType(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	w := WithErrorFormat(recorder, ErrorFormatProblem, ProblemOptions{}, "")
+
+	Error(w, http.StatusConflict, "conflict", "Resource conflict", nil)
+
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+	require.Equal(t, "about:blank", body["type"])
+	require.NotContains(t, body, "instance")
+	require.NotContains(t, body, "details")
+}
+
+func TestNegotiateErrorFormat(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/v1/books", nil)
+	require.Equal(t, ErrorFormatJSON, NegotiateErrorFormat(req, ErrorFormatJSON))
+
+	req.Header.Set("Accept", "application/json, application/problem+json;q=0.9")
+	require.Equal(t, ErrorFormatProblem, NegotiateErrorFormat(req, ErrorFormatJSON))
+
+	req.Header.Set("Accept", "application/problem+json;q=0")
+	require.Equal(t, ErrorFormatJSON, NegotiateErrorFormat(req, ErrorFormatJSON))
+	require.Equal(t, ErrorFormatJSON, NegotiateErrorFormat(req, ErrorFormatProblem))
+
+	req.Header.Set("Accept", "*/*")
+	require.Equal(t, ErrorFormatProblem, NegotiateErrorFormat(req, ErrorFormatProblem))
+}
+
+func TestNegotiateContentType(t *testing.T) {
+	offers := []string{"application/json", "application/marc", "application/marcxml+xml"}
+	tests := []struct {
+		accept string
+		want   string
+	}{
+		{accept: "", want: "application/json"},
+		{accept: "*/*", want: "application/json"},
+		{accept: "application/marc", want: "application/marc"},
+		{accept: "text/html, application/marcxml+xml;q=0.9, */*;q=0.1", want: "application/marcxml+xml"},
+		{accept: "application/marc;q=0.5, application/json;q=0.8", want: "application/json"},
+		{accept: "application/*;q=0.2, application/marc", want: "application/marc"},
+		{accept: "application/json;q=0, application/*", want: "application/marc"},
+		{accept: "text/csv", want: "application/json"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.accept, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			if test.accept != "" {
+				r.Header.Set("Accept", test.accept)
+			}
+			require.Equal(t, test.want, NegotiateContentType(r, offers...))
+		})
+	}
+}
@@ -2,31 +2,129 @@ package router
 
 import (
 	"net/http"
+	"slices"
 
+	apikeyhttp "github.com/bkiran6398/library/internal/apikeys/http"
+	"github.com/bkiran6398/library/internal/auth"
+	authorhttp "github.com/bkiran6398/library/internal/authors/http"
 	bookhttp "github.com/bkiran6398/library/internal/books/http"
+	branchhttp "github.com/bkiran6398/library/internal/branches/http"
+	"github.com/bkiran6398/library/internal/health"
 	"github.com/bkiran6398/library/internal/http/middleware"
+	"github.com/bkiran6398/library/internal/http/response"
+	"github.com/bkiran6398/library/internal/idempotency"
+	"github.com/bkiran6398/library/internal/metrics"
+	"github.com/bkiran6398/library/internal/ratelimit"
+	subjecthttp "github.com/bkiran6398/library/internal/subjects/http"
 	"github.com/gorilla/handlers"
 	"github.com/gorilla/mux"
 	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type CORSConfig struct {
 	AllowedOrigins []string
 }
 
-func NewRouter(loggerInstance zerolog.Logger, corsConfig CORSConfig, bookHandler bookhttp.Handler) http.Handler {
+// ErrorConfig selects the default error response format.
+type ErrorConfig struct {
+	Format             response.ErrorFormat
+	ProblemTypeBaseURI string
+}
+
+// AuthConfig lists the Authorization schemes accepted on /v1 routes and the policy
+// enforced per route. Authentication is disabled when no authenticators are configured,
+// and authorization is disabled when Policy is nil. The admin routes are only registered
+// when both are set, so they answer 404 rather than serving anonymous callers.
+type AuthConfig struct {
+	Authenticators map[string]middleware.Authenticator
+	Policy         *auth.Policy
+}
+
+// RateLimitConfig enables per-client rate limiting on /v1 routes when Store is set.
+type RateLimitConfig struct {
+	Store  ratelimit.Store
+	Limits middleware.RateLimitConfig
+}
+
+// IdempotencyConfig enables Idempotency-Key handling of POST requests when Store is set.
+type IdempotencyConfig struct {
+	Store  idempotency.Store
+	Config middleware.IdempotencyConfig
+}
+
+// MetricsConfig enables request instrumentation when HTTP is set. When Handler is set
+// it is also served at Path; leave it nil to expose metrics on a separate admin listener.
+type MetricsConfig struct {
+	HTTP    *metrics.HTTPMetrics
+	Handler http.Handler
+	Path    string
+}
+
+// Handlers groups the module handlers whose routes are mounted under /v1.
+type Handlers struct {
+	Books    bookhttp.Handler
+	Authors  authorhttp.Handler
+	Subjects subjecthttp.Handler
+	Branches branchhttp.Handler
+	APIKeys  apikeyhttp.Handler
+}
+
+// TracingConfig enables a server span per request when Tracer is set.
+type TracingConfig struct {
+	Tracer     trace.Tracer
+	Propagator propagation.TextMapPropagator
+}
+
+func NewRouter(loggerInstance zerolog.Logger, corsConfig CORSConfig, errorConfig ErrorConfig, authConfig AuthConfig, rateLimitConfig RateLimitConfig, idempotencyConfig IdempotencyConfig, metricsConfig MetricsConfig, tracingConfig TracingConfig, readiness *health.Checker, handlers Handlers) http.Handler {
 	router := mux.NewRouter()
 
 	// Apply global middleware
 	router.Use(middleware.RequestID)
+	if tracingConfig.Tracer != nil {
+		router.Use(middleware.Tracing(tracingConfig.Tracer, tracingConfig.Propagator))
+	}
+	router.Use(middleware.ErrorFormat(errorConfig.Format, response.ProblemOptions{TypeBaseURI: errorConfig.ProblemTypeBaseURI}))
 	router.Use(middleware.Recovery(loggerInstance))
 	router.Use(middleware.Logging(loggerInstance))
+	if metricsConfig.HTTP != nil {
+		router.Use(middleware.Metrics(metricsConfig.HTTP))
+		unmatchedMetrics := middleware.UnmatchedMetrics(metricsConfig.HTTP)
+		router.NotFoundHandler = unmatchedMetrics(http.NotFoundHandler())
+		router.MethodNotAllowedHandler = unmatchedMetrics(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}))
+	}
 
 	// Register routes
-	registerHealthEndpoint(router)
+	registerHealthEndpoints(router, readiness)
+	if metricsConfig.Handler != nil {
+		registerMetricsEndpoint(router, metricsConfig.Path, metricsConfig.Handler)
+	}
 
 	apiRouter := router.PathPrefix("/v1").Subrouter()
-	registerBookRoutes(apiRouter, bookHandler)
+	if rateLimitConfig.Store != nil {
+		apiRouter.Use(middleware.RateLimitByIP(loggerInstance, rateLimitConfig.Store, rateLimitConfig.Limits))
+	}
+	if len(authConfig.Authenticators) > 0 {
+		apiRouter.Use(middleware.Authentication(loggerInstance, authConfig.Authenticators))
+	}
+	if rateLimitConfig.Store != nil {
+		apiRouter.Use(middleware.RateLimit(loggerInstance, rateLimitConfig.Store, rateLimitConfig.Limits))
+	}
+	if idempotencyConfig.Store != nil {
+		idempotencyOptions := idempotencyConfig.Config
+		idempotencyOptions.ExcludedRoutes = append(slices.Clone(idempotencyOptions.ExcludedRoutes), "/v1"+booksImportPath)
+		apiRouter.Use(middleware.Idempotency(loggerInstance, idempotencyConfig.Store, idempotencyOptions))
+	}
+	registerBookRoutes(apiRouter, authConfig.Policy, handlers.Books)
+	registerAuthorRoutes(apiRouter, authConfig.Policy, handlers.Authors, handlers.Books)
+	registerSubjectRoutes(apiRouter, authConfig.Policy, handlers.Subjects, handlers.Books)
+	registerBranchRoutes(apiRouter, authConfig.Policy, handlers.Branches, handlers.Books)
+	if len(authConfig.Authenticators) > 0 && authConfig.Policy != nil {
+		registerAPIKeyRoutes(apiRouter, authConfig.Policy, handlers.APIKeys)
+	}
 
 	// Apply CORS
 	corsHandler := configureCORS(corsConfig.AllowedOrigins)
@@ -38,6 +136,7 @@ func configureCORS(allowedOrigins []string) func(http.Handler) http.Handler {
 	return handlers.CORS(
 		handlers.AllowedOrigins(allowedOrigins),
 		handlers.AllowedMethods([]string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}),
-		handlers.AllowedHeaders([]string{"Content-Type", "Authorization", "X-Request-ID"}),
+		handlers.AllowedHeaders([]string{"Content-Type", "Authorization", "X-Request-ID", "Idempotency-Key", "traceparent", "tracestate"}),
+		handlers.ExposedHeaders([]string{"X-Request-ID", "Retry-After", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "Idempotent-Replayed"}),
 	)
 }
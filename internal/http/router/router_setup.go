@@ -3,23 +3,122 @@ package router
 import (
 	"net/http"
 
+	apikeyhttp "github.com/bkiran6398/library/internal/apikeys/http"
+	"github.com/bkiran6398/library/internal/auth"
+	authorhttp "github.com/bkiran6398/library/internal/authors/http"
 	bookhttp "github.com/bkiran6398/library/internal/books/http"
+	branchhttp "github.com/bkiran6398/library/internal/branches/http"
+	"github.com/bkiran6398/library/internal/health"
+	"github.com/bkiran6398/library/internal/http/middleware"
+	"github.com/bkiran6398/library/internal/http/response"
+	subjecthttp "github.com/bkiran6398/library/internal/subjects/http"
 	"github.com/gorilla/mux"
 )
 
-// registerHealthEndpoint registers the health check endpoint.
-func registerHealthEndpoint(router *mux.Router) {
-	router.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+// registerHealthEndpoints registers the liveness and readiness endpoints.
+// /healthz is kept as an alias of /livez for existing probes.
+func registerHealthEndpoints(router *mux.Router, readiness *health.Checker) {
+	liveness := func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		_, _ = w.Write([]byte("ok"))
+	}
+	router.HandleFunc("/livez", liveness).Methods(http.MethodGet)
+	router.HandleFunc("/healthz", liveness).Methods(http.MethodGet)
+
+	router.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		report := readiness.Run(r.Context())
+		status := http.StatusOK
+		if !report.Healthy() {
+			status = http.StatusServiceUnavailable
+		}
+		response.JSON(w, status, report)
 	}).Methods(http.MethodGet)
 }
 
+// booksImportPath is the route of book imports, whose uploads are too large for
+// Idempotency-Key handling.
+const booksImportPath = "/books/import"
+
 // registerBookRoutes registers all book-related API routes.
-func registerBookRoutes(apiRouter *mux.Router, bookHandler bookhttp.Handler) {
-	apiRouter.HandleFunc("/books", bookHandler.List).Methods(http.MethodGet)
-	apiRouter.HandleFunc("/books", bookHandler.Create).Methods(http.MethodPost)
-	apiRouter.HandleFunc("/books/{id}", bookHandler.Get).Methods(http.MethodGet)
-	apiRouter.HandleFunc("/books/{id}", bookHandler.Update).Methods(http.MethodPut)
-	apiRouter.HandleFunc("/books/{id}", bookHandler.Delete).Methods(http.MethodDelete)
+func registerBookRoutes(apiRouter *mux.Router, policy *auth.Policy, bookHandler bookhttp.Handler) {
+	apiRouter.Handle("/books", authorize(policy, auth.PermissionBooksRead, bookHandler.List)).Methods(http.MethodGet)
+	apiRouter.Handle("/books", authorize(policy, auth.PermissionBooksWrite, bookHandler.Create)).Methods(http.MethodPost)
+	apiRouter.Handle("/books/export", authorize(policy, auth.PermissionBooksRead, bookHandler.Export)).Methods(http.MethodGet)
+	apiRouter.Handle(booksImportPath, authorize(policy, auth.PermissionBooksWrite, bookHandler.Import)).Methods(http.MethodPost)
+	apiRouter.Handle("/books/lookup", authorize(policy, auth.PermissionBooksWrite, bookHandler.Lookup)).Methods(http.MethodPost)
+	apiRouter.Handle("/books/citations", authorize(policy, auth.PermissionBooksRead, bookHandler.Citations)).Methods(http.MethodGet)
+	apiRouter.Handle("/books/{id}", authorize(policy, auth.PermissionBooksRead, bookHandler.Get)).Methods(http.MethodGet)
+	apiRouter.Handle("/books/{id}", authorize(policy, auth.PermissionBooksWrite, bookHandler.Update)).Methods(http.MethodPut)
+	apiRouter.Handle("/books/{id}", authorize(policy, auth.PermissionBooksDelete, bookHandler.Delete)).Methods(http.MethodDelete)
+	apiRouter.Handle("/books/{id}/copies:adjust", authorize(policy, auth.PermissionBooksWrite, bookHandler.AdjustCopies)).Methods(http.MethodPost)
+	apiRouter.Handle("/books/{id}/citation", authorize(policy, auth.PermissionBooksRead, bookHandler.Citation)).Methods(http.MethodGet)
+	apiRouter.Handle("/works", authorize(policy, auth.PermissionBooksWrite, bookHandler.CreateWork)).Methods(http.MethodPost)
+	apiRouter.Handle("/works/{id}", authorize(policy, auth.PermissionBooksRead, bookHandler.GetWork)).Methods(http.MethodGet)
+	apiRouter.Handle("/works/{id}", authorize(policy, auth.PermissionBooksDelete, bookHandler.DeleteWork)).Methods(http.MethodDelete)
+}
+
+// registerAuthorRoutes registers the author routes and the contributor routes of books.
+// Books by author are served by the book handler, so they accept the same filters as /books.
+func registerAuthorRoutes(apiRouter *mux.Router, policy *auth.Policy, authorHandler authorhttp.Handler, bookHandler bookhttp.Handler) {
+	apiRouter.Handle("/authors", authorize(policy, auth.PermissionBooksRead, authorHandler.List)).Methods(http.MethodGet)
+	apiRouter.Handle("/authors", authorize(policy, auth.PermissionBooksWrite, authorHandler.Create)).Methods(http.MethodPost)
+	apiRouter.Handle("/authors/{id}", authorize(policy, auth.PermissionBooksRead, authorHandler.Get)).Methods(http.MethodGet)
+	apiRouter.Handle("/authors/{id}", authorize(policy, auth.PermissionBooksWrite, authorHandler.Update)).Methods(http.MethodPut)
+	apiRouter.Handle("/authors/{id}", authorize(policy, auth.PermissionBooksDelete, authorHandler.Delete)).Methods(http.MethodDelete)
+	apiRouter.Handle("/authors/{id}/books", authorize(policy, auth.PermissionBooksRead, bookHandler.ListByAuthor)).Methods(http.MethodGet)
+	apiRouter.Handle("/books/{id}/authors", authorize(policy, auth.PermissionBooksRead, authorHandler.Contributors)).Methods(http.MethodGet)
+	apiRouter.Handle("/books/{id}/authors", authorize(policy, auth.PermissionBooksWrite, authorHandler.SetContributors)).Methods(http.MethodPut)
+}
+
+// registerSubjectRoutes registers the subject taxonomy and tagging routes. Books by subject
+// are served by the book handler, so they accept the same filters as /books.
+func registerSubjectRoutes(apiRouter *mux.Router, policy *auth.Policy, subjectHandler subjecthttp.Handler, bookHandler bookhttp.Handler) {
+	apiRouter.Handle("/subjects", authorize(policy, auth.PermissionBooksRead, subjectHandler.List)).Methods(http.MethodGet)
+	apiRouter.Handle("/subjects", authorize(policy, auth.PermissionBooksWrite, subjectHandler.Create)).Methods(http.MethodPost)
+	apiRouter.Handle("/subjects/{id}", authorize(policy, auth.PermissionBooksRead, subjectHandler.Get)).Methods(http.MethodGet)
+	apiRouter.Handle("/subjects/{id}", authorize(policy, auth.PermissionBooksWrite, subjectHandler.Update)).Methods(http.MethodPut)
+	apiRouter.Handle("/subjects/{id}", authorize(policy, auth.PermissionBooksDelete, subjectHandler.Delete)).Methods(http.MethodDelete)
+	apiRouter.Handle("/subjects/{id}/books", authorize(policy, auth.PermissionBooksRead, bookHandler.ListBySubject)).Methods(http.MethodGet)
+	apiRouter.Handle("/tags", authorize(policy, auth.PermissionBooksRead, subjectHandler.ListTags)).Methods(http.MethodGet)
+	apiRouter.Handle("/books/{id}/subjects", authorize(policy, auth.PermissionBooksRead, subjectHandler.BookSubjects)).Methods(http.MethodGet)
+	apiRouter.Handle("/books/{id}/subjects", authorize(policy, auth.PermissionBooksWrite, subjectHandler.SetBookSubjects)).Methods(http.MethodPut)
+	apiRouter.Handle("/books/{id}/tags", authorize(policy, auth.PermissionBooksRead, subjectHandler.BookTags)).Methods(http.MethodGet)
+	apiRouter.Handle("/books/{id}/tags", authorize(policy, auth.PermissionBooksWrite, subjectHandler.SetBookTags)).Methods(http.MethodPut)
+}
+
+// registerBranchRoutes registers the branch, holding and transfer routes. Books available at
+// a branch are served by the book handler, so they accept the same filters as /books.
+func registerBranchRoutes(apiRouter *mux.Router, policy *auth.Policy, branchHandler branchhttp.Handler, bookHandler bookhttp.Handler) {
+	apiRouter.Handle("/branches", authorize(policy, auth.PermissionBooksRead, branchHandler.List)).Methods(http.MethodGet)
+	apiRouter.Handle("/branches", authorize(policy, auth.PermissionBooksWrite, branchHandler.Create)).Methods(http.MethodPost)
+	apiRouter.Handle("/branches/{id}", authorize(policy, auth.PermissionBooksRead, branchHandler.Get)).Methods(http.MethodGet)
+	apiRouter.Handle("/branches/{id}", authorize(policy, auth.PermissionBooksWrite, branchHandler.Update)).Methods(http.MethodPut)
+	apiRouter.Handle("/branches/{id}", authorize(policy, auth.PermissionBooksDelete, branchHandler.Delete)).Methods(http.MethodDelete)
+	apiRouter.Handle("/branches/{id}/books", authorize(policy, auth.PermissionBooksRead, bookHandler.ListAvailableAtBranch)).Methods(http.MethodGet)
+	apiRouter.Handle("/books/{id}/availability", authorize(policy, auth.PermissionBooksRead, branchHandler.Availability)).Methods(http.MethodGet)
+	apiRouter.Handle("/books/{id}/holdings/{branch_id}", authorize(policy, auth.PermissionBooksWrite, branchHandler.SetHolding)).Methods(http.MethodPut)
+	apiRouter.Handle("/transfers", authorize(policy, auth.PermissionBooksRead, branchHandler.ListTransfers)).Methods(http.MethodGet)
+	apiRouter.Handle("/transfers", authorize(policy, auth.PermissionBooksWrite, branchHandler.CreateTransfer)).Methods(http.MethodPost)
+	apiRouter.Handle("/transfers/{id}", authorize(policy, auth.PermissionBooksRead, branchHandler.GetTransfer)).Methods(http.MethodGet)
+	apiRouter.Handle("/transfers/{id}/ship", authorize(policy, auth.PermissionBooksWrite, branchHandler.ShipTransfer)).Methods(http.MethodPost)
+	apiRouter.Handle("/transfers/{id}/receive", authorize(policy, auth.PermissionBooksWrite, branchHandler.ReceiveTransfer)).Methods(http.MethodPost)
+	apiRouter.Handle("/transfers/{id}/cancel", authorize(policy, auth.PermissionBooksWrite, branchHandler.CancelTransfer)).Methods(http.MethodPost)
+}
+
+// registerAPIKeyRoutes registers the API key administration routes.
+func registerAPIKeyRoutes(apiRouter *mux.Router, policy *auth.Policy, apiKeyHandler apikeyhttp.Handler) {
+	apiRouter.Handle("/admin/api-keys", authorize(policy, auth.PermissionAPIKeysManage, apiKeyHandler.List)).Methods(http.MethodGet)
+	apiRouter.Handle("/admin/api-keys", authorize(policy, auth.PermissionAPIKeysManage, apiKeyHandler.Create)).Methods(http.MethodPost)
+	apiRouter.Handle("/admin/api-keys/{id}/rotate", authorize(policy, auth.PermissionAPIKeysManage, apiKeyHandler.Rotate)).Methods(http.MethodPost)
+	apiRouter.Handle("/admin/api-keys/{id}/revoke", authorize(policy, auth.PermissionAPIKeysManage, apiKeyHandler.Revoke)).Methods(http.MethodPost)
+}
+
+// authorize wraps a handler so it only runs for principals holding permission.
+func authorize(policy *auth.Policy, permission auth.Permission, handlerFunc http.HandlerFunc) http.Handler {
+	return middleware.RequirePermission(policy, permission)(handlerFunc)
+}
+
+// registerMetricsEndpoint registers the Prometheus scrape endpoint.
+func registerMetricsEndpoint(router *mux.Router, path string, metricsHandler http.Handler) {
+	router.Handle(path, metricsHandler).Methods(http.MethodGet)
 }
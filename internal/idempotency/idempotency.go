@@ -0,0 +1,62 @@
+package idempotency
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	intErr "github.com/bkiran6398/library/internal/errors"
+)
+
+// KindKeyReused classifies a request that reuses an idempotency key with a different payload.
+const KindKeyReused intErr.Kind = "idempotency_key_reused"
+
+var (
+	// ErrKeyReused is returned when a key is presented again with a different request.
+	ErrKeyReused = intErr.New(KindKeyReused, "the Idempotency-Key was already used with a different request")
+	// ErrInProgress is returned while the first request with a key has not completed.
+	ErrInProgress = intErr.New(intErr.KindConflict, "a request with this Idempotency-Key is still in progress").
+			WithCode("idempotency_key_in_progress").
+			WithRetryable(true)
+)
+
+func init() {
+	intErr.Register(KindKeyReused, intErr.Mapping{
+		HTTPStatus:    http.StatusUnprocessableEntity,
+		GRPCCode:      intErr.GRPCCodeFailedPrecondition,
+		Code:          string(KindKeyReused),
+		Message:       "Idempotency key reused",
+		ExposeMessage: true,
+	})
+}
+
+// Response is the stored outcome of the first request made with a key.
+type Response struct {
+	StatusCode  int
+	ContentType string
+	Body        []byte
+}
+
+// Record is the state of a key. Response is nil while the first request is in progress.
+// ClaimToken is set only on the record of a claim Begin just made.
+type Record struct {
+	Fingerprint string
+	Response    *Response
+	ClaimToken  string
+}
+
+// Store keeps idempotency keys until they expire. Implementations must be safe for
+// concurrent use.
+type Store interface {
+	// Begin claims key for a request identified by fingerprint and keeps it for ttl. An
+	// unfinished claim holds the key only for lease, so a request whose instance died can be
+	// retried without waiting for ttl. It returns started=true when the key was new, expired
+	// or its lease had run out; otherwise it returns the key's record without claiming it.
+	Begin(ctx context.Context, key, fingerprint string, lease, ttl time.Duration) (record Record, started bool, err error)
+	// Complete stores the response of the request that claimed key with claimToken. It does
+	// nothing if the key has since been claimed again.
+	Complete(ctx context.Context, key, claimToken string, response Response) error
+	// Release forgets a claimed key whose request did not complete, so it can be retried. It
+	// does nothing if the key has since been claimed again.
+	Release(ctx context.Context, key, claimToken string) error
+}
@@ -0,0 +1,80 @@
+package idempotency
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// sweepInterval is how often expired keys are evicted.
+const sweepInterval = time.Minute
+
+type entry struct {
+	record      Record
+	lockedUntil time.Time
+	expiresAt   time.Time
+}
+
+// MemoryStore keeps keys in process memory. Keys are only honoured by the instance that saw them.
+type MemoryStore struct {
+	mutex     sync.Mutex
+	entries   map[string]*entry
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewMemoryStore creates an empty in-memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{entries: make(map[string]*entry), now: time.Now}
+}
+
+func (store *MemoryStore) Begin(_ context.Context, key, fingerprint string, lease, ttl time.Duration) (Record, bool, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	now := store.now()
+	store.sweep(now)
+
+	if existing, ok := store.entries[key]; ok && now.Before(existing.expiresAt) &&
+		(existing.record.Response != nil || now.Before(existing.lockedUntil)) {
+		return existing.record, false, nil
+	}
+	record := Record{Fingerprint: fingerprint, ClaimToken: uuid.NewString()}
+	store.entries[key] = &entry{record: record, lockedUntil: now.Add(lease), expiresAt: now.Add(ttl)}
+	return record, true, nil
+}
+
+func (store *MemoryStore) Complete(_ context.Context, key, claimToken string, response Response) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if existing, ok := store.entries[key]; ok && existing.record.ClaimToken == claimToken {
+		existing.record.Response = &response
+	}
+	return nil
+}
+
+func (store *MemoryStore) Release(_ context.Context, key, claimToken string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if existing, ok := store.entries[key]; ok && existing.record.ClaimToken == claimToken && existing.record.Response == nil {
+		delete(store.entries, key)
+	}
+	return nil
+}
+
+// sweep evicts expired keys at most once per sweepInterval. Callers must hold the mutex.
+func (store *MemoryStore) sweep(now time.Time) {
+	if now.Sub(store.lastSweep) < sweepInterval {
+		return
+	}
+	for key, stored := range store.entries {
+		if !now.Before(stored.expiresAt) {
+			delete(store.entries, key)
+		}
+	}
+	store.lastSweep = now
+}
@@ -0,0 +1,112 @@
+package idempotency
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore_BeginCompleteAndExpire(t *testing.T) {
+	store := NewMemoryStore()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+
+	claimed, started, err := store.Begin(context.Background(), "client:key", "fingerprint", time.Minute, time.Hour)
+	require.NoError(t, err)
+	require.True(t, started)
+
+	inProgress, started, err := store.Begin(context.Background(), "client:key", "fingerprint", time.Minute, time.Hour)
+	require.NoError(t, err)
+	require.False(t, started)
+	require.Nil(t, inProgress.Response)
+
+	response := Response{StatusCode: 201, ContentType: "application/json", Body: []byte(`{"id":"1"}`)}
+	require.NoError(t, store.Complete(context.Background(), "client:key", claimed.ClaimToken, response))
+	completed, started, err := store.Begin(context.Background(), "client:key", "other", time.Minute, time.Hour)
+	require.NoError(t, err)
+	require.False(t, started)
+	require.Equal(t, "fingerprint", completed.Fingerprint)
+	require.Equal(t, &response, completed.Response)
+
+	now = now.Add(time.Hour)
+	_, started, err = store.Begin(context.Background(), "client:key", "other", time.Minute, time.Hour)
+	require.NoError(t, err)
+	require.True(t, started)
+}
+
+func TestMemoryStore_ReleaseKeepsCompletedKeys(t *testing.T) {
+	store := NewMemoryStore()
+
+	released, _, err := store.Begin(context.Background(), "released", "fingerprint", time.Minute, time.Hour)
+	require.NoError(t, err)
+	require.NoError(t, store.Release(context.Background(), "released", released.ClaimToken))
+	_, started, err := store.Begin(context.Background(), "released", "fingerprint", time.Minute, time.Hour)
+	require.NoError(t, err)
+	require.True(t, started)
+
+	completed, _, err := store.Begin(context.Background(), "completed", "fingerprint", time.Minute, time.Hour)
+	require.NoError(t, err)
+	require.NoError(t, store.Complete(context.Background(), "completed", completed.ClaimToken, Response{StatusCode: 200}))
+	require.NoError(t, store.Release(context.Background(), "completed", completed.ClaimToken))
+	_, started, err = store.Begin(context.Background(), "completed", "fingerprint", time.Minute, time.Hour)
+	require.NoError(t, err)
+	require.False(t, started)
+}
+
+func TestMemoryStore_ExpiredLeaseCanBeReclaimed(t *testing.T) {
+	store := NewMemoryStore()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+
+	_, started, err := store.Begin(context.Background(), "abandoned", "fingerprint", time.Minute, time.Hour)
+	require.NoError(t, err)
+	require.True(t, started)
+
+	now = now.Add(30 * time.Second)
+	_, started, err = store.Begin(context.Background(), "abandoned", "fingerprint", time.Minute, time.Hour)
+	require.NoError(t, err)
+	require.False(t, started)
+
+	// Once the lease runs out, an unfinished key can be claimed again long before its TTL.
+	now = now.Add(time.Minute)
+	reclaimed, started, err := store.Begin(context.Background(), "abandoned", "fingerprint", time.Minute, time.Hour)
+	require.NoError(t, err)
+	require.True(t, started)
+
+	// A completed key is kept for its TTL regardless of the lease.
+	require.NoError(t, store.Complete(context.Background(), "abandoned", reclaimed.ClaimToken, Response{StatusCode: 201}))
+	now = now.Add(10 * time.Minute)
+	completed, started, err := store.Begin(context.Background(), "abandoned", "fingerprint", time.Minute, time.Hour)
+	require.NoError(t, err)
+	require.False(t, started)
+	require.NotNil(t, completed.Response)
+}
+
+func TestMemoryStore_StaleClaimCannotCompleteOrRelease(t *testing.T) {
+	store := NewMemoryStore()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+
+	stale, _, err := store.Begin(context.Background(), "slow", "fingerprint", time.Minute, time.Hour)
+	require.NoError(t, err)
+	now = now.Add(2 * time.Minute)
+	current, started, err := store.Begin(context.Background(), "slow", "fingerprint", time.Minute, time.Hour)
+	require.NoError(t, err)
+	require.True(t, started)
+	require.NotEqual(t, stale.ClaimToken, current.ClaimToken)
+
+	// The request whose lease ran out neither stores its response nor frees the new claim.
+	require.NoError(t, store.Complete(context.Background(), "slow", stale.ClaimToken, Response{StatusCode: 201}))
+	require.NoError(t, store.Release(context.Background(), "slow", stale.ClaimToken))
+	inProgress, started, err := store.Begin(context.Background(), "slow", "fingerprint", time.Minute, time.Hour)
+	require.NoError(t, err)
+	require.False(t, started)
+	require.Nil(t, inProgress.Response)
+
+	require.NoError(t, store.Complete(context.Background(), "slow", current.ClaimToken, Response{StatusCode: 201}))
+	completed, _, err := store.Begin(context.Background(), "slow", "fingerprint", time.Minute, time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, 201, completed.Response.StatusCode)
+}
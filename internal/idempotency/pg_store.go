@@ -0,0 +1,96 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PgStore keeps keys in Postgres so they hold across several instances. Begin claims a key
+// with a single upsert, so two instances never both run the request.
+type PgStore struct {
+	dbPool *pgxpool.Pool
+}
+
+// NewPgStore creates a Postgres-backed store using the idempotency_keys table.
+func NewPgStore(dbPool *pgxpool.Pool) *PgStore {
+	return &PgStore{dbPool: dbPool}
+}
+
+func (store *PgStore) Begin(ctx context.Context, key, fingerprint string, lease, ttl time.Duration) (Record, bool, error) {
+	const claimQuery = `
+INSERT INTO idempotency_keys AS stored (key, fingerprint, claim_token, created_at, locked_until, expires_at)
+VALUES ($1, $2, $3, NOW(), NOW() + make_interval(secs => $4), NOW() + make_interval(secs => $5))
+ON CONFLICT (key) DO UPDATE SET
+    fingerprint = EXCLUDED.fingerprint,
+    claim_token = EXCLUDED.claim_token,
+    status_code = NULL,
+    content_type = NULL,
+    body = NULL,
+    created_at = EXCLUDED.created_at,
+    locked_until = EXCLUDED.locked_until,
+    expires_at = EXCLUDED.expires_at
+WHERE stored.expires_at <= NOW()
+   OR (stored.status_code IS NULL AND stored.locked_until <= NOW())
+RETURNING TRUE;
+`
+	claimToken := uuid.NewString()
+	var claimed bool
+	err := store.dbPool.QueryRow(ctx, claimQuery, key, fingerprint, claimToken, lease.Seconds(), ttl.Seconds()).Scan(&claimed)
+	if err == nil {
+		return Record{Fingerprint: fingerprint, ClaimToken: claimToken}, true, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return Record{}, false, fmt.Errorf("claim idempotency key: %w", err)
+	}
+
+	const selectQuery = `SELECT fingerprint, status_code, content_type, body FROM idempotency_keys WHERE key = $1;`
+	var record Record
+	var statusCode *int
+	var contentType *string
+	var body []byte
+	if err := store.dbPool.QueryRow(ctx, selectQuery, key).Scan(&record.Fingerprint, &statusCode, &contentType, &body); err != nil {
+		return Record{}, false, fmt.Errorf("get idempotency key: %w", err)
+	}
+	if statusCode != nil {
+		record.Response = &Response{StatusCode: *statusCode, Body: body}
+		if contentType != nil {
+			record.Response.ContentType = *contentType
+		}
+	}
+	return record, false, nil
+}
+
+func (store *PgStore) Complete(ctx context.Context, key, claimToken string, response Response) error {
+	const completeQuery = `
+UPDATE idempotency_keys SET status_code = $3, content_type = $4, body = $5, locked_until = NULL
+WHERE key = $1 AND claim_token = $2;
+`
+	if _, err := store.dbPool.Exec(ctx, completeQuery, key, claimToken, response.StatusCode, response.ContentType, response.Body); err != nil {
+		return fmt.Errorf("complete idempotency key: %w", err)
+	}
+	return nil
+}
+
+func (store *PgStore) Release(ctx context.Context, key, claimToken string) error {
+	const releaseQuery = `DELETE FROM idempotency_keys WHERE key = $1 AND claim_token = $2 AND status_code IS NULL;`
+	if _, err := store.dbPool.Exec(ctx, releaseQuery, key, claimToken); err != nil {
+		return fmt.Errorf("release idempotency key: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpired removes expired keys; Begin already ignores them, so this only reclaims space.
+func (store *PgStore) DeleteExpired(ctx context.Context) (int64, error) {
+	const deleteQuery = `DELETE FROM idempotency_keys WHERE expires_at <= NOW();`
+	result, err := store.dbPool.Exec(ctx, deleteQuery)
+	if err != nil {
+		return 0, fmt.Errorf("delete expired idempotency keys: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
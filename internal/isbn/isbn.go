@@ -0,0 +1,79 @@
+// Package isbn validates International Standard Book Numbers and converts them to a
+// canonical form.
+package isbn
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrInvalid is returned for values that are not a well-formed ISBN-10 or ISBN-13 with a
+// correct check digit.
+var ErrInvalid = errors.New("invalid ISBN")
+
+// Normalize returns the canonical form of an ISBN-10 or ISBN-13: thirteen digits without
+// hyphens or spaces. ISBN-10s are converted by prefixing 978 and recomputing the check digit.
+func Normalize(value string) (string, error) {
+	compact := strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(value))
+	switch {
+	case len(compact) == 10 && validISBN10(compact):
+		body := "978" + compact[:9]
+		return body + string(isbn13CheckDigit(body)), nil
+	case len(compact) == 13 && validISBN13(compact):
+		return compact, nil
+	default:
+		return "", ErrInvalid
+	}
+}
+
+// Valid reports whether value is an ISBN-10 or ISBN-13 with a correct check digit.
+func Valid(value string) bool {
+	_, err := Normalize(value)
+	return err == nil
+}
+
+// validISBN10 checks nine digits and a check digit (0-9 or X for ten) whose weighted sum,
+// with weights 10 down to 1, is divisible by 11.
+func validISBN10(compact string) bool {
+	sum := 0
+	for index := 0; index < 10; index++ {
+		character := compact[index]
+		var digit int
+		switch {
+		case character >= '0' && character <= '9':
+			digit = int(character - '0')
+		case character == 'X' && index == 9:
+			digit = 10
+		default:
+			return false
+		}
+		sum += (10 - index) * digit
+	}
+	return sum%11 == 0
+}
+
+// validISBN13 checks thirteen digits starting with a 978 or 979 prefix and a correct check digit.
+func validISBN13(compact string) bool {
+	if !strings.HasPrefix(compact, "978") && !strings.HasPrefix(compact, "979") {
+		return false
+	}
+	for index := 0; index < 13; index++ {
+		if compact[index] < '0' || compact[index] > '9' {
+			return false
+		}
+	}
+	return isbn13CheckDigit(compact[:12]) == compact[12]
+}
+
+// isbn13CheckDigit computes the check digit of the first twelve digits, weighted 1 and 3 alternately.
+func isbn13CheckDigit(body string) byte {
+	sum := 0
+	for index := 0; index < 12; index++ {
+		weight := 1
+		if index%2 == 1 {
+			weight = 3
+		}
+		sum += weight * int(body[index]-'0')
+	}
+	return byte('0' + (10-sum%10)%10)
+}
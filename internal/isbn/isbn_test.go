@@ -0,0 +1,39 @@
+package isbn
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalize(t *testing.T) {
+	cases := map[string]string{
+		"978-0-306-40615-7": "9780306406157",
+		"9780306406157":     "9780306406157",
+		"0-306-40615-2":     "9780306406157",
+		"0306406152":        "9780306406157",
+		"080442957X":        "9780804429573",
+		"0 8044 2957 x":     "9780804429573",
+		"979-10-90636-07-1": "9791090636071",
+	}
+	for value, expected := range cases {
+		normalized, err := Normalize(value)
+		require.NoError(t, err, value)
+		require.Equal(t, expected, normalized, value)
+	}
+}
+
+func TestNormalize_Invalid(t *testing.T) {
+	for _, value := range []string{
+		"", "abc", "ISBN-123",
+		"978-0-306-40615-8", // wrong check digit
+		"0-306-40615-3",     // wrong check digit
+		"X306406152",        // X outside the check digit
+		"1234567890123",     // no 978/979 prefix
+		"97803064061577",    // too long
+	} {
+		_, err := Normalize(value)
+		require.ErrorIs(t, err, ErrInvalid, value)
+		require.False(t, Valid(value), value)
+	}
+}
@@ -23,5 +23,5 @@ func New(level string) (zerolog.Logger, error) {
 	zerolog.SetGlobalLevel(lvl)
 	zerolog.TimeFieldFormat = logTimeStamp
 
-	return zerolog.New(os.Stdout).With().Timestamp().Logger(), nil
+	return zerolog.New(os.Stdout).Hook(traceHook{}).With().Timestamp().Logger(), nil
 }
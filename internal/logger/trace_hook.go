@@ -0,0 +1,22 @@
+package logger
+
+import (
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// traceHook adds trace_id and span_id to events logged with a context carrying a span,
+// e.g. logger.Info().Ctx(r.Context()).Msg(...), so logs can be joined with traces.
+type traceHook struct{}
+
+func (traceHook) Run(event *zerolog.Event, _ zerolog.Level, _ string) {
+	ctx := event.GetCtx()
+	if ctx == nil {
+		return
+	}
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return
+	}
+	event.Str("trace_id", spanContext.TraceID().String()).Str("span_id", spanContext.SpanID().String())
+}
@@ -0,0 +1,35 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTraceHook_AddsTraceIDs(t *testing.T) {
+	var output bytes.Buffer
+	logger := zerolog.New(&output).Hook(traceHook{})
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID})
+	ctx := trace.ContextWithSpanContext(context.Background(), spanContext)
+
+	logger.Info().Ctx(ctx).Msg("with span")
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(output.Bytes(), &entry))
+	require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", entry["trace_id"])
+	require.Equal(t, "00f067aa0ba902b7", entry["span_id"])
+
+	output.Reset()
+	logger.Info().Msg("without span")
+	entry = nil
+	require.NoError(t, json.Unmarshal(output.Bytes(), &entry))
+	require.NotContains(t, entry, "trace_id")
+}
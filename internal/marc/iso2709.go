@@ -0,0 +1,186 @@
+package marc
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+const (
+	leaderLength       = 24
+	directoryEntrySize = 12
+	subfieldDelimiter  = 0x1F
+	fieldTerminator    = 0x1E
+	recordTerminator   = 0x1D
+	maxRecordLength    = 99999
+	maxFieldLength     = 9999
+)
+
+// defaultLeader describes a new, complete, Unicode bibliographic record for a monograph.
+const defaultLeader = "00000nam a2200000 i 4500"
+
+// Writer writes binary MARC 21 records.
+type Writer struct {
+	destination io.Writer
+}
+
+// NewWriter returns a Writer writing to destination.
+func NewWriter(destination io.Writer) *Writer {
+	return &Writer{destination: destination}
+}
+
+// Write encodes record, computing its leader lengths and directory.
+func (writer *Writer) Write(record Record) error {
+	encoded, err := Marshal(record)
+	if err != nil {
+		return err
+	}
+	_, err = writer.destination.Write(encoded)
+	return err
+}
+
+// Marshal encodes record in ISO 2709 form.
+func Marshal(record Record) ([]byte, error) {
+	var directory, data bytes.Buffer
+	addField := func(tag string, content []byte) error {
+		if len(tag) != 3 {
+			return fmt.Errorf("%w: invalid tag %q", ErrMalformed, tag)
+		}
+		if len(content)+1 > maxFieldLength {
+			return fmt.Errorf("%w: field %s is %d bytes, more than the %d allowed", ErrMalformed, tag, len(content)+1, maxFieldLength)
+		}
+		fmt.Fprintf(&directory, "%s%04d%05d", tag, len(content)+1, data.Len())
+		data.Write(content)
+		data.WriteByte(fieldTerminator)
+		return nil
+	}
+
+	for _, field := range record.ControlFields {
+		if err := addField(field.Tag, []byte(field.Value)); err != nil {
+			return nil, err
+		}
+	}
+	for _, field := range record.DataFields {
+		content := []byte{indicator(field.Ind1), indicator(field.Ind2)}
+		for _, subfield := range field.Subfields {
+			content = append(content, subfieldDelimiter, subfield.Code)
+			content = append(content, subfield.Value...)
+		}
+		if err := addField(field.Tag, content); err != nil {
+			return nil, err
+		}
+	}
+	directory.WriteByte(fieldTerminator)
+
+	baseAddress := leaderLength + directory.Len()
+	recordLength := baseAddress + data.Len() + 1
+	if recordLength > maxRecordLength {
+		return nil, fmt.Errorf("%w: record is %d bytes, more than the %d allowed", ErrMalformed, recordLength, maxRecordLength)
+	}
+
+	leader := []byte(record.Leader)
+	if len(leader) != leaderLength {
+		leader = []byte(defaultLeader)
+	}
+	copy(leader[0:5], fmt.Sprintf("%05d", recordLength))
+	copy(leader[12:17], fmt.Sprintf("%05d", baseAddress))
+
+	encoded := make([]byte, 0, recordLength)
+	encoded = append(encoded, leader...)
+	encoded = append(encoded, directory.Bytes()...)
+	encoded = append(encoded, data.Bytes()...)
+	return append(encoded, recordTerminator), nil
+}
+
+// indicator maps an unset indicator to a blank.
+func indicator(value byte) byte {
+	if value == 0 {
+		return ' '
+	}
+	return value
+}
+
+// Reader reads binary MARC 21 records.
+type Reader struct {
+	source *bufio.Reader
+}
+
+// NewReader returns a Reader reading from source.
+func NewReader(source io.Reader) *Reader {
+	return &Reader{source: bufio.NewReader(source)}
+}
+
+// Read returns the next record, or io.EOF after the last one. An error wrapping
+// ErrMalformed covers only that record; the following Read continues with the next one.
+func (reader *Reader) Read() (Record, error) {
+	raw, err := reader.source.ReadBytes(recordTerminator)
+	if errors.Is(err, io.EOF) {
+		if len(bytes.TrimSpace(raw)) == 0 {
+			return Record{}, io.EOF
+		}
+		return Record{}, fmt.Errorf("%w: missing record terminator", ErrMalformed)
+	}
+	if err != nil {
+		return Record{}, err
+	}
+	return Unmarshal(bytes.TrimLeft(raw, "\r\n"))
+}
+
+// Unmarshal decodes one ISO 2709 record, including its terminator.
+func Unmarshal(raw []byte) (Record, error) {
+	if len(raw) < leaderLength+2 {
+		return Record{}, fmt.Errorf("%w: record is too short", ErrMalformed)
+	}
+	leader := raw[:leaderLength]
+	baseAddress, err := strconv.Atoi(string(leader[12:17]))
+	if err != nil || baseAddress <= leaderLength || baseAddress >= len(raw) {
+		return Record{}, fmt.Errorf("%w: invalid base address %q", ErrMalformed, leader[12:17])
+	}
+
+	directory := raw[leaderLength : baseAddress-1]
+	if len(directory)%directoryEntrySize != 0 || raw[baseAddress-1] != fieldTerminator {
+		return Record{}, fmt.Errorf("%w: invalid directory", ErrMalformed)
+	}
+	data := raw[baseAddress : len(raw)-1]
+
+	record := Record{Leader: string(leader)}
+	for offset := 0; offset < len(directory); offset += directoryEntrySize {
+		entry := directory[offset : offset+directoryEntrySize]
+		tag := string(entry[0:3])
+		length, lengthErr := strconv.Atoi(string(entry[3:7]))
+		start, startErr := strconv.Atoi(string(entry[7:12]))
+		if lengthErr != nil || startErr != nil || length < 1 || start < 0 || start+length > len(data) {
+			return Record{}, fmt.Errorf("%w: invalid directory entry for field %s", ErrMalformed, tag)
+		}
+		content := bytes.TrimSuffix(data[start:start+length], []byte{fieldTerminator})
+
+		if isControlTag(tag) {
+			record.ControlFields = append(record.ControlFields, ControlField{Tag: tag, Value: string(content)})
+			continue
+		}
+		field, err := parseDataField(tag, content)
+		if err != nil {
+			return Record{}, err
+		}
+		record.DataFields = append(record.DataFields, field)
+	}
+	return record, nil
+}
+
+// parseDataField splits a data field into indicators and subfields.
+func parseDataField(tag string, content []byte) (DataField, error) {
+	if len(content) < 2 {
+		return DataField{}, fmt.Errorf("%w: field %s has no indicators", ErrMalformed, tag)
+	}
+	field := DataField{Tag: tag, Ind1: content[0], Ind2: content[1]}
+	for _, part := range bytes.Split(content[2:], []byte{subfieldDelimiter}) {
+		if len(part) == 0 {
+			continue
+		}
+		field.Subfields = append(field.Subfields, Subfield{Code: part[0], Value: string(part[1:])})
+	}
+	return field, nil
+}
@@ -0,0 +1,125 @@
+package marc
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func sampleRecord() Record {
+	return Record{
+		Leader: "00000nam a2200000 i 4500",
+		ControlFields: []ControlField{
+			{Tag: "001", Value: "book-1"},
+		},
+		DataFields: []DataField{
+			{Tag: "020", Ind1: ' ', Ind2: ' ', Subfields: []Subfield{{Code: 'a', Value: "9780134190440"}}},
+			{Tag: "100", Ind1: '1', Ind2: ' ', Subfields: []Subfield{{Code: 'a', Value: "Donovan, Alan A. A."}}},
+			{Tag: "245", Ind1: '1', Ind2: '4', Subfields: []Subfield{{Code: 'a', Value: "The Go programming language /"}, {Code: 'c', Value: "Ålan & <Brian>"}}},
+		},
+	}
+}
+
+func TestMarshalUnmarshal_RoundTrip(t *testing.T) {
+	record := sampleRecord()
+
+	encoded, err := Marshal(record)
+	require.NoError(t, err)
+	require.Equal(t, byte(recordTerminator), encoded[len(encoded)-1])
+	recordLength, err := strconv.Atoi(string(encoded[0:5]))
+	require.NoError(t, err)
+	require.Equal(t, len(encoded), recordLength)
+
+	decoded, err := Unmarshal(encoded)
+	require.NoError(t, err)
+	require.Equal(t, string(encoded[:leaderLength]), decoded.Leader)
+	require.Equal(t, record.ControlFields, decoded.ControlFields)
+	require.Equal(t, record.DataFields, decoded.DataFields)
+}
+
+func TestMarshal_RejectsOversizedField(t *testing.T) {
+	record := sampleRecord()
+	record.DataFields = append(record.DataFields, DataField{Tag: "520", Subfields: []Subfield{{Code: 'a', Value: strings.Repeat("x", 10000)}}})
+
+	_, err := Marshal(record)
+	require.ErrorIs(t, err, ErrMalformed)
+}
+
+func TestUnmarshal_RejectsBaseAddressAtEnd(t *testing.T) {
+	// A 37-byte record whose base address points past its one directory entry and
+	// field terminator, leaving no data and no record terminator.
+	raw := []byte("00037nam a2200037 i 4500" + "001000100000")
+	raw = append(raw, fieldTerminator)
+	require.Len(t, raw, 37)
+
+	_, err := Unmarshal(raw)
+	require.ErrorIs(t, err, ErrMalformed)
+}
+
+func TestReader_SkipsMalformedRecords(t *testing.T) {
+	var stream bytes.Buffer
+	writer := NewWriter(&stream)
+	require.NoError(t, writer.Write(sampleRecord()))
+	stream.WriteString("garbage")
+	stream.WriteByte(recordTerminator)
+	require.NoError(t, writer.Write(sampleRecord()))
+
+	reader := NewReader(&stream)
+	_, err := reader.Read()
+	require.NoError(t, err)
+	_, err = reader.Read()
+	require.ErrorIs(t, err, ErrMalformed)
+	record, err := reader.Read()
+	require.NoError(t, err)
+	require.Equal(t, "book-1", record.ControlFields[0].Value)
+	_, err = reader.Read()
+	require.ErrorIs(t, err, io.EOF)
+}
+
+func TestMarshalXML_RoundTrip(t *testing.T) {
+	encoded, err := MarshalXML(sampleRecord())
+	require.NoError(t, err)
+	require.Contains(t, string(encoded), `<record xmlns="http://www.loc.gov/MARC21/slim">`)
+	require.Contains(t, string(encoded), `<datafield tag="245" ind1="1" ind2="4">`)
+
+	record, err := NewXMLReader(bytes.NewReader(encoded)).Read()
+	require.NoError(t, err)
+	require.Equal(t, sampleRecord(), record)
+}
+
+func TestXMLWriter_Collection(t *testing.T) {
+	var stream bytes.Buffer
+	writer := NewXMLWriter(&stream)
+	require.NoError(t, writer.Write(sampleRecord()))
+	require.NoError(t, writer.Write(sampleRecord()))
+	require.NoError(t, writer.Close())
+	require.True(t, strings.HasPrefix(stream.String(), `<?xml`))
+	require.Contains(t, stream.String(), `<collection xmlns="http://www.loc.gov/MARC21/slim">`)
+
+	reader := NewXMLReader(&stream)
+	for range 2 {
+		record, err := reader.Read()
+		require.NoError(t, err)
+		require.Equal(t, sampleRecord(), record)
+	}
+	_, err := reader.Read()
+	require.ErrorIs(t, err, io.EOF)
+}
+
+func TestXMLReader_PrefixedNamespace(t *testing.T) {
+	document := `<marc:collection xmlns:marc="http://www.loc.gov/MARC21/slim">
+  <marc:record>
+    <marc:leader>00000nam a2200000 i 4500</marc:leader>
+    <marc:datafield tag="020" ind1=" " ind2=" "><marc:subfield code="a">123</marc:subfield></marc:datafield>
+    <marc:datafield tag="245" ind1="1" ind2="0"><marc:subfield code="ab">bad</marc:subfield></marc:datafield>
+  </marc:record>
+</marc:collection>`
+
+	_, err := NewXMLReader(strings.NewReader(document)).Read()
+	require.True(t, errors.Is(err, ErrMalformed))
+}
@@ -0,0 +1,191 @@
+package marc
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Namespace is the MARCXML (MARC 21 slim) namespace.
+const Namespace = "http://www.loc.gov/MARC21/slim"
+
+type xmlRecord struct {
+	XMLName       xml.Name          `xml:"record"`
+	Leader        string            `xml:"leader"`
+	ControlFields []xmlControlField `xml:"controlfield"`
+	DataFields    []xmlDataField    `xml:"datafield"`
+}
+
+type xmlControlField struct {
+	Tag   string `xml:"tag,attr"`
+	Value string `xml:",chardata"`
+}
+
+type xmlDataField struct {
+	Tag       string        `xml:"tag,attr"`
+	Ind1      string        `xml:"ind1,attr"`
+	Ind2      string        `xml:"ind2,attr"`
+	Subfields []xmlSubfield `xml:"subfield"`
+}
+
+type xmlSubfield struct {
+	Code  string `xml:"code,attr"`
+	Value string `xml:",chardata"`
+}
+
+// MarshalXML encodes record as a standalone MARCXML <record> document.
+func MarshalXML(record Record) ([]byte, error) {
+	var encoded bytes.Buffer
+	encoded.WriteString(xml.Header)
+	encoder := xml.NewEncoder(&encoded)
+	recordStart := xml.StartElement{
+		Name: xml.Name{Local: "record"},
+		Attr: []xml.Attr{{Name: xml.Name{Local: "xmlns"}, Value: Namespace}},
+	}
+	if err := encoder.EncodeElement(toXMLRecord(record), recordStart); err != nil {
+		return nil, fmt.Errorf("encode marcxml: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("encode marcxml: %w", err)
+	}
+	return encoded.Bytes(), nil
+}
+
+// XMLWriter writes records into a MARCXML <collection>. Close must be called to end it.
+type XMLWriter struct {
+	destination io.Writer
+	encoder     *xml.Encoder
+	started     bool
+}
+
+// NewXMLWriter returns an XMLWriter writing to destination.
+func NewXMLWriter(destination io.Writer) *XMLWriter {
+	return &XMLWriter{destination: destination, encoder: xml.NewEncoder(destination)}
+}
+
+var collectionStart = xml.StartElement{
+	Name: xml.Name{Local: "collection"},
+	Attr: []xml.Attr{{Name: xml.Name{Local: "xmlns"}, Value: Namespace}},
+}
+
+func (writer *XMLWriter) start() error {
+	if writer.started {
+		return nil
+	}
+	writer.started = true
+	if _, err := io.WriteString(writer.destination, xml.Header); err != nil {
+		return err
+	}
+	return writer.encoder.EncodeToken(collectionStart)
+}
+
+// Write appends record to the collection.
+func (writer *XMLWriter) Write(record Record) error {
+	if err := writer.start(); err != nil {
+		return err
+	}
+	if err := writer.encoder.Encode(toXMLRecord(record)); err != nil {
+		return fmt.Errorf("encode marcxml: %w", err)
+	}
+	return nil
+}
+
+// Close ends the collection and flushes it.
+func (writer *XMLWriter) Close() error {
+	if err := writer.start(); err != nil {
+		return err
+	}
+	if err := writer.encoder.EncodeToken(collectionStart.End()); err != nil {
+		return err
+	}
+	if err := writer.encoder.Flush(); err != nil {
+		return err
+	}
+	_, err := io.WriteString(writer.destination, "\n")
+	return err
+}
+
+// toXMLRecord converts record to its MARCXML shape.
+func toXMLRecord(record Record) xmlRecord {
+	encoded := xmlRecord{Leader: record.Leader}
+	if len(encoded.Leader) != leaderLength {
+		encoded.Leader = defaultLeader
+	}
+	for _, field := range record.ControlFields {
+		encoded.ControlFields = append(encoded.ControlFields, xmlControlField{Tag: field.Tag, Value: field.Value})
+	}
+	for _, field := range record.DataFields {
+		dataField := xmlDataField{Tag: field.Tag, Ind1: string(indicator(field.Ind1)), Ind2: string(indicator(field.Ind2))}
+		for _, subfield := range field.Subfields {
+			dataField.Subfields = append(dataField.Subfields, xmlSubfield{Code: string(subfield.Code), Value: subfield.Value})
+		}
+		encoded.DataFields = append(encoded.DataFields, dataField)
+	}
+	return encoded
+}
+
+// XMLReader reads the <record> elements of a MARCXML document, whether it is a
+// <collection> or a single record.
+type XMLReader struct {
+	decoder *xml.Decoder
+}
+
+// NewXMLReader returns an XMLReader reading from source.
+func NewXMLReader(source io.Reader) *XMLReader {
+	return &XMLReader{decoder: xml.NewDecoder(source)}
+}
+
+// Read returns the next record, or io.EOF after the last one. Errors wrapping ErrMalformed
+// cover only that record; XML syntax errors end the stream.
+func (reader *XMLReader) Read() (Record, error) {
+	for {
+		token, err := reader.decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return Record{}, io.EOF
+		}
+		if err != nil {
+			return Record{}, fmt.Errorf("read marcxml: %w", err)
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "record" {
+			continue
+		}
+
+		var decoded xmlRecord
+		if err := reader.decoder.DecodeElement(&decoded, &start); err != nil {
+			return Record{}, fmt.Errorf("read marcxml: %w", err)
+		}
+		return fromXMLRecord(decoded)
+	}
+}
+
+func fromXMLRecord(decoded xmlRecord) (Record, error) {
+	record := Record{Leader: decoded.Leader}
+	for _, field := range decoded.ControlFields {
+		record.ControlFields = append(record.ControlFields, ControlField{Tag: field.Tag, Value: field.Value})
+	}
+	for _, field := range decoded.DataFields {
+		if len(field.Ind1) > 1 || len(field.Ind2) > 1 {
+			return Record{}, fmt.Errorf("%w: field %s has invalid indicators", ErrMalformed, field.Tag)
+		}
+		dataField := DataField{Tag: field.Tag, Ind1: firstByte(field.Ind1), Ind2: firstByte(field.Ind2)}
+		for _, subfield := range field.Subfields {
+			if len(subfield.Code) != 1 {
+				return Record{}, fmt.Errorf("%w: field %s has an invalid subfield code %q", ErrMalformed, field.Tag, subfield.Code)
+			}
+			dataField.Subfields = append(dataField.Subfields, Subfield{Code: subfield.Code[0], Value: subfield.Value})
+		}
+		record.DataFields = append(record.DataFields, dataField)
+	}
+	return record, nil
+}
+
+// firstByte returns the indicator in value, treating an empty attribute as blank.
+func firstByte(value string) byte {
+	if value == "" {
+		return ' '
+	}
+	return value[0]
+}
@@ -0,0 +1,73 @@
+// Package marc reads and writes MARC 21 bibliographic records in binary (ISO 2709) and
+// MARCXML form.
+package marc
+
+import "errors"
+
+// ErrMalformed reports a record that cannot be parsed. Readers can continue with the next
+// record after returning it.
+var ErrMalformed = errors.New("malformed marc record")
+
+// Record is a MARC 21 record.
+type Record struct {
+	// Leader is the 24 character leader. Writers recompute the length and base address.
+	Leader        string
+	ControlFields []ControlField
+	DataFields    []DataField
+}
+
+// ControlField is a 00X field holding unstructured data.
+type ControlField struct {
+	Tag   string
+	Value string
+}
+
+// DataField is a field with two indicators and coded subfields.
+type DataField struct {
+	Tag       string
+	Ind1      byte
+	Ind2      byte
+	Subfields []Subfield
+}
+
+// Subfield is a coded piece of a data field.
+type Subfield struct {
+	Code  byte
+	Value string
+}
+
+// ControlField returns the value of the first control field with tag.
+func (record Record) ControlField(tag string) (string, bool) {
+	for _, field := range record.ControlFields {
+		if field.Tag == tag {
+			return field.Value, true
+		}
+	}
+	return "", false
+}
+
+// DataFieldsByTag returns every data field with tag, in record order.
+func (record Record) DataFieldsByTag(tag string) []DataField {
+	var fields []DataField
+	for _, field := range record.DataFields {
+		if field.Tag == tag {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// Subfield returns the value of the first subfield with code.
+func (field DataField) Subfield(code byte) (string, bool) {
+	for _, subfield := range field.Subfields {
+		if subfield.Code == code {
+			return subfield.Value, true
+		}
+	}
+	return "", false
+}
+
+// isControlTag reports whether tag names a control field (001-009).
+func isControlTag(tag string) bool {
+	return len(tag) == 3 && tag[0] == '0' && tag[1] == '0'
+}
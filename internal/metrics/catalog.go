@@ -0,0 +1,95 @@
+package metrics
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/bkiran6398/library/internal/books/domain"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// catalogStatsTimeout bounds the statistics query.
+	catalogStatsTimeout = 2 * time.Second
+	// catalogStatsTTL is how long statistics are reused across scrapes, so frequent or
+	// concurrent scrapes do not each aggregate the whole catalog.
+	catalogStatsTTL = 30 * time.Second
+)
+
+// CatalogStatsSource provides the aggregate figures exported as business gauges.
+type CatalogStatsSource interface {
+	Stats(ctx context.Context) (domain.CatalogStats, error)
+}
+
+// catalogCollector exports catalog-wide gauges, queried at scrape time at most once per catalogStatsTTL.
+type catalogCollector struct {
+	source CatalogStatsSource
+	now    func() time.Time
+
+	mutex     sync.Mutex
+	stats     domain.CatalogStats
+	fetchedAt time.Time
+
+	books          *prometheus.Desc
+	copiesTotal    *prometheus.Desc
+	copiesOnLoan   *prometheus.Desc
+	scrapeFailures prometheus.Counter
+}
+
+// NewCatalogCollector creates a collector for book and copy counts.
+func NewCatalogCollector(source CatalogStatsSource) prometheus.Collector {
+	return &catalogCollector{
+		source:       source,
+		now:          time.Now,
+		books:        prometheus.NewDesc(prometheus.BuildFQName(namespace, "catalog", "books"), "Books in the catalog.", nil, nil),
+		copiesTotal:  prometheus.NewDesc(prometheus.BuildFQName(namespace, "catalog", "copies"), "Copies owned across all books.", nil, nil),
+		copiesOnLoan: prometheus.NewDesc(prometheus.BuildFQName(namespace, "catalog", "copies_on_loan"), "Copies currently not available.", nil, nil),
+		scrapeFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "catalog",
+			Name:      "scrape_failures_total",
+			Help:      "Failed catalog statistics queries.",
+		}),
+	}
+}
+
+func (collector *catalogCollector) Describe(descriptions chan<- *prometheus.Desc) {
+	descriptions <- collector.books
+	descriptions <- collector.copiesTotal
+	descriptions <- collector.copiesOnLoan
+	collector.scrapeFailures.Describe(descriptions)
+}
+
+func (collector *catalogCollector) Collect(metricsChannel chan<- prometheus.Metric) {
+	stats, err := collector.cachedStats()
+	if err != nil {
+		collector.scrapeFailures.Inc()
+	} else {
+		metricsChannel <- prometheus.MustNewConstMetric(collector.books, prometheus.GaugeValue, float64(stats.Books))
+		metricsChannel <- prometheus.MustNewConstMetric(collector.copiesTotal, prometheus.GaugeValue, float64(stats.CopiesTotal))
+		metricsChannel <- prometheus.MustNewConstMetric(collector.copiesOnLoan, prometheus.GaugeValue, float64(stats.CopiesOnLoan))
+	}
+	collector.scrapeFailures.Collect(metricsChannel)
+}
+
+// cachedStats returns the last statistics if they are younger than catalogStatsTTL and
+// queries the source otherwise. Concurrent scrapes wait for a single query.
+func (collector *catalogCollector) cachedStats() (domain.CatalogStats, error) {
+	collector.mutex.Lock()
+	defer collector.mutex.Unlock()
+
+	now := collector.now()
+	if !collector.fetchedAt.IsZero() && now.Sub(collector.fetchedAt) < catalogStatsTTL {
+		return collector.stats, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), catalogStatsTimeout)
+	defer cancel()
+	stats, err := collector.source.Stats(ctx)
+	if err != nil {
+		return domain.CatalogStats{}, err
+	}
+	collector.stats, collector.fetchedAt = stats, now
+	return stats, nil
+}
@@ -0,0 +1,71 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bkiran6398/library/internal/books/domain"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+type stubCatalogStats struct {
+	stats domain.CatalogStats
+	err   error
+}
+
+func (source stubCatalogStats) Stats(context.Context) (domain.CatalogStats, error) {
+	return source.stats, source.err
+}
+
+type countingCatalogStats struct {
+	calls int
+}
+
+func (source *countingCatalogStats) Stats(context.Context) (domain.CatalogStats, error) {
+	source.calls++
+	return domain.CatalogStats{Books: int64(source.calls)}, nil
+}
+
+func TestCatalogCollector(t *testing.T) {
+	collector := NewCatalogCollector(stubCatalogStats{stats: domain.CatalogStats{Books: 3, CopiesTotal: 10, CopiesOnLoan: 4}})
+
+	expected := `
+# HELP library_catalog_books Books in the catalog.
+# TYPE library_catalog_books gauge
+library_catalog_books 3
+# HELP library_catalog_copies_on_loan Copies currently not available.
+# TYPE library_catalog_copies_on_loan gauge
+library_catalog_copies_on_loan 4
+`
+	require.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(expected), "library_catalog_books", "library_catalog_copies_on_loan"))
+}
+
+func TestCatalogCollector_CountsFailures(t *testing.T) {
+	collector := NewCatalogCollector(stubCatalogStats{err: errors.New("db down")})
+
+	expected := `
+# HELP library_catalog_scrape_failures_total Failed catalog statistics queries.
+# TYPE library_catalog_scrape_failures_total counter
+library_catalog_scrape_failures_total 1
+`
+	require.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(expected)))
+}
+
+func TestCatalogCollector_CachesStats(t *testing.T) {
+	source := &countingCatalogStats{}
+	collector := NewCatalogCollector(source).(*catalogCollector)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	collector.now = func() time.Time { return now }
+
+	testutil.CollectAndCount(collector)
+	testutil.CollectAndCount(collector)
+	require.Equal(t, 1, source.calls)
+
+	now = now.Add(catalogStatsTTL)
+	testutil.CollectAndCount(collector)
+	require.Equal(t, 2, source.calls)
+}
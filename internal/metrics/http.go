@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const namespace = "library"
+
+// HTTPMetrics holds the request instruments recorded by the HTTP middleware.
+type HTTPMetrics struct {
+	RequestsTotal    *prometheus.CounterVec
+	RequestDuration  *prometheus.HistogramVec
+	RequestsInFlight prometheus.Gauge
+}
+
+// NewHTTPMetrics creates the HTTP instruments and registers them with registerer.
+// Requests are labelled by method, mux route template and status code.
+func NewHTTPMetrics(registerer prometheus.Registerer) *HTTPMetrics {
+	httpMetrics := &HTTPMetrics{
+		RequestsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "Total HTTP requests by method, route template and status code.",
+		}, []string{"method", "route", "code"}),
+		RequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "HTTP request latency by method, route template and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "code"}),
+		RequestsInFlight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_in_flight",
+			Help:      "HTTP requests currently being served.",
+		}),
+	}
+	registerer.MustRegister(httpMetrics.RequestsTotal, httpMetrics.RequestDuration, httpMetrics.RequestsInFlight)
+	return httpMetrics
+}
@@ -0,0 +1,61 @@
+package metrics
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// poolCollector exports pgxpool statistics at scrape time.
+type poolCollector struct {
+	dbPool *pgxpool.Pool
+
+	acquiredConns   *prometheus.Desc
+	idleConns       *prometheus.Desc
+	totalConns      *prometheus.Desc
+	maxConns        *prometheus.Desc
+	waitCount       *prometheus.Desc
+	waitDuration    *prometheus.Desc
+	acquireCount    *prometheus.Desc
+	canceledAcquire *prometheus.Desc
+}
+
+// NewPoolCollector creates a collector for the connection pool's statistics.
+func NewPoolCollector(dbPool *pgxpool.Pool) prometheus.Collector {
+	describe := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "db_pool", name), help, nil, nil)
+	}
+	return &poolCollector{
+		dbPool:          dbPool,
+		acquiredConns:   describe("acquired_connections", "Connections currently checked out of the pool."),
+		idleConns:       describe("idle_connections", "Idle connections in the pool."),
+		totalConns:      describe("total_connections", "Total connections in the pool."),
+		maxConns:        describe("max_connections", "Maximum size of the pool."),
+		waitCount:       describe("wait_count_total", "Acquires that had to wait for a connection."),
+		waitDuration:    describe("wait_duration_seconds_total", "Total time spent acquiring connections."),
+		acquireCount:    describe("acquire_count_total", "Successful connection acquires."),
+		canceledAcquire: describe("canceled_acquire_count_total", "Acquires canceled by their context."),
+	}
+}
+
+func (collector *poolCollector) Describe(descriptions chan<- *prometheus.Desc) {
+	descriptions <- collector.acquiredConns
+	descriptions <- collector.idleConns
+	descriptions <- collector.totalConns
+	descriptions <- collector.maxConns
+	descriptions <- collector.waitCount
+	descriptions <- collector.waitDuration
+	descriptions <- collector.acquireCount
+	descriptions <- collector.canceledAcquire
+}
+
+func (collector *poolCollector) Collect(metricsChannel chan<- prometheus.Metric) {
+	stats := collector.dbPool.Stat()
+	metricsChannel <- prometheus.MustNewConstMetric(collector.acquiredConns, prometheus.GaugeValue, float64(stats.AcquiredConns()))
+	metricsChannel <- prometheus.MustNewConstMetric(collector.idleConns, prometheus.GaugeValue, float64(stats.IdleConns()))
+	metricsChannel <- prometheus.MustNewConstMetric(collector.totalConns, prometheus.GaugeValue, float64(stats.TotalConns()))
+	metricsChannel <- prometheus.MustNewConstMetric(collector.maxConns, prometheus.GaugeValue, float64(stats.MaxConns()))
+	metricsChannel <- prometheus.MustNewConstMetric(collector.waitCount, prometheus.CounterValue, float64(stats.EmptyAcquireCount()))
+	metricsChannel <- prometheus.MustNewConstMetric(collector.waitDuration, prometheus.CounterValue, stats.AcquireDuration().Seconds())
+	metricsChannel <- prometheus.MustNewConstMetric(collector.acquireCount, prometheus.CounterValue, float64(stats.AcquireCount()))
+	metricsChannel <- prometheus.MustNewConstMetric(collector.canceledAcquire, prometheus.CounterValue, float64(stats.CanceledAcquireCount()))
+}
@@ -0,0 +1,38 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Registry bundles the application's Prometheus registry and its HTTP instruments.
+type Registry struct {
+	registry *prometheus.Registry
+	HTTP     *HTTPMetrics
+}
+
+// NewRegistry creates a registry with runtime, process, connection pool and catalog collectors.
+func NewRegistry(dbPool *pgxpool.Pool, catalogStats CatalogStatsSource) *Registry {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		NewPoolCollector(dbPool),
+		NewCatalogCollector(catalogStats),
+	)
+	return &Registry{registry: registry, HTTP: NewHTTPMetrics(registry)}
+}
+
+// Register adds further collectors, e.g. from other modules.
+func (registry *Registry) Register(collectors ...prometheus.Collector) {
+	registry.registry.MustRegister(collectors...)
+}
+
+// Handler serves the registry in the Prometheus exposition format.
+func (registry *Registry) Handler() http.Handler {
+	return promhttp.HandlerFor(registry.registry, promhttp.HandlerOpts{})
+}
@@ -0,0 +1,70 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// idleBucketTTL is how long an untouched bucket is kept before it is evicted.
+const idleBucketTTL = 10 * time.Minute
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+	limit     Limit
+}
+
+// MemoryStore keeps buckets in process memory. Limits are enforced per instance.
+type MemoryStore struct {
+	mutex     sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewMemoryStore creates an empty in-memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: make(map[string]*bucket), now: time.Now}
+}
+
+func (store *MemoryStore) Take(_ context.Context, key string, limit Limit) (Result, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	now := store.now()
+	store.sweep(now)
+
+	current, ok := store.buckets[key]
+	if !ok {
+		current = &bucket{tokens: float64(limit.Burst), updatedAt: now}
+		store.buckets[key] = current
+	}
+
+	elapsed := now.Sub(current.updatedAt).Seconds()
+	current.tokens = min(float64(limit.Burst), current.tokens+elapsed*limit.Rate)
+	current.updatedAt = now
+	current.limit = limit
+
+	allowed := current.tokens >= 1
+	if allowed {
+		current.tokens--
+	}
+	return newResult(allowed, current.tokens, limit), nil
+}
+
+// sweep evicts idle buckets at most once per idleBucketTTL. Buckets that have not refilled
+// yet are kept, since a missing bucket is recreated full. Callers must hold the mutex.
+func (store *MemoryStore) sweep(now time.Time) {
+	if now.Sub(store.lastSweep) < idleBucketTTL {
+		return
+	}
+	for key, idleBucket := range store.buckets {
+		idleFor := now.Sub(idleBucket.updatedAt)
+		refilled := idleBucket.tokens+idleFor.Seconds()*idleBucket.limit.Rate >= float64(idleBucket.limit.Burst)
+		if idleFor > idleBucketTTL && refilled {
+			delete(store.buckets, key)
+		}
+	}
+	store.lastSweep = now
+}
@@ -0,0 +1,76 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore_TakeAndRefill(t *testing.T) {
+	store := NewMemoryStore()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+	limit := Limit{Rate: 1, Burst: 2}
+
+	first, err := store.Take(context.Background(), "client", limit)
+	require.NoError(t, err)
+	require.True(t, first.Allowed)
+	require.Equal(t, 1, first.Remaining)
+	require.Equal(t, 2, first.Limit)
+
+	second, err := store.Take(context.Background(), "client", limit)
+	require.NoError(t, err)
+	require.True(t, second.Allowed)
+	require.Equal(t, 0, second.Remaining)
+	require.Equal(t, 2*time.Second, second.ResetAfter)
+
+	third, err := store.Take(context.Background(), "client", limit)
+	require.NoError(t, err)
+	require.False(t, third.Allowed)
+	require.Equal(t, time.Second, third.RetryAfter)
+
+	other, err := store.Take(context.Background(), "other-client", limit)
+	require.NoError(t, err)
+	require.True(t, other.Allowed)
+
+	now = now.Add(1500 * time.Millisecond)
+	refilled, err := store.Take(context.Background(), "client", limit)
+	require.NoError(t, err)
+	require.True(t, refilled.Allowed)
+	require.Equal(t, 0, refilled.Remaining)
+}
+
+func TestMemoryStore_EvictsIdleBuckets(t *testing.T) {
+	store := NewMemoryStore()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+
+	_, err := store.Take(context.Background(), "client", Limit{Rate: 1, Burst: 1})
+	require.NoError(t, err)
+	require.Len(t, store.buckets, 1)
+
+	now = now.Add(2 * idleBucketTTL)
+	_, err = store.Take(context.Background(), "other-client", Limit{Rate: 1, Burst: 1})
+	require.NoError(t, err)
+	require.Len(t, store.buckets, 1)
+	require.Contains(t, store.buckets, "other-client")
+}
+
+func TestMemoryStore_KeepsBucketsStillRefilling(t *testing.T) {
+	store := NewMemoryStore()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+
+	slowLimit := Limit{Rate: 1.0 / 3600, Burst: 2}
+	for range 2 {
+		_, err := store.Take(context.Background(), "client", slowLimit)
+		require.NoError(t, err)
+	}
+
+	now = now.Add(2 * idleBucketTTL)
+	result, err := store.Take(context.Background(), "client", slowLimit)
+	require.NoError(t, err)
+	require.False(t, result.Allowed)
+}
@@ -0,0 +1,54 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PgStore keeps buckets in Postgres so limits hold across several instances.
+// Each Take is a single atomic upsert, so concurrent instances never double-spend a token.
+type PgStore struct {
+	dbPool *pgxpool.Pool
+}
+
+// NewPgStore creates a Postgres-backed store using the rate_limit_buckets table.
+func NewPgStore(dbPool *pgxpool.Pool) *PgStore {
+	return &PgStore{dbPool: dbPool}
+}
+
+func (store *PgStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	const takeQuery = `
+INSERT INTO rate_limit_buckets AS bucket (key, tokens, allowed, updated_at)
+VALUES ($1, $2::float8 - 1, TRUE, NOW())
+ON CONFLICT (key) DO UPDATE SET
+    tokens = CASE
+        WHEN LEAST($2::float8, bucket.tokens + EXTRACT(EPOCH FROM NOW() - bucket.updated_at) * $3::float8) >= 1
+        THEN LEAST($2::float8, bucket.tokens + EXTRACT(EPOCH FROM NOW() - bucket.updated_at) * $3::float8) - 1
+        ELSE LEAST($2::float8, bucket.tokens + EXTRACT(EPOCH FROM NOW() - bucket.updated_at) * $3::float8)
+    END,
+    allowed = LEAST($2::float8, bucket.tokens + EXTRACT(EPOCH FROM NOW() - bucket.updated_at) * $3::float8) >= 1,
+    updated_at = NOW()
+RETURNING tokens, allowed;
+`
+	var tokens float64
+	var allowed bool
+	if err := store.dbPool.QueryRow(ctx, takeQuery, key, float64(limit.Burst), limit.Rate).Scan(&tokens, &allowed); err != nil {
+		return Result{}, fmt.Errorf("take rate limit token: %w", err)
+	}
+	return newResult(allowed, tokens, limit), nil
+}
+
+// DeleteIdle removes buckets untouched for longer than idleFor. A missing bucket is
+// recreated full, so idleFor must be at least the longest Burst/Rate refill time in use;
+// a shorter window lets clients of a deleted, partly drained bucket burst again early.
+func (store *PgStore) DeleteIdle(ctx context.Context, idleFor time.Duration) (int64, error) {
+	const deleteQuery = `DELETE FROM rate_limit_buckets WHERE updated_at < NOW() - make_interval(secs => $1);`
+	result, err := store.dbPool.Exec(ctx, deleteQuery, idleFor.Seconds())
+	if err != nil {
+		return 0, fmt.Errorf("delete idle rate limit buckets: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,52 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Limit configures a token bucket: Burst tokens at most, refilled at Rate tokens per second.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Result is the outcome of taking a token from a bucket.
+type Result struct {
+	Allowed bool
+	Limit   int
+	// Remaining is the number of whole tokens left after this request.
+	Remaining int
+	// RetryAfter is how long to wait before a token is available; zero when allowed.
+	RetryAfter time.Duration
+	// ResetAfter is how long until the bucket is full again.
+	ResetAfter time.Duration
+}
+
+// Store takes tokens from named buckets. Implementations must be safe for concurrent use.
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// newResult derives the client-facing result from the token count left in a bucket.
+func newResult(allowed bool, tokens float64, limit Limit) Result {
+	result := Result{
+		Allowed:    allowed,
+		Limit:      limit.Burst,
+		Remaining:  max(int(math.Floor(tokens)), 0),
+		ResetAfter: refillDuration(float64(limit.Burst)-tokens, limit.Rate),
+	}
+	if !allowed {
+		result.RetryAfter = refillDuration(1-tokens, limit.Rate)
+	}
+	return result
+}
+
+// refillDuration is the time needed to refill the given number of tokens.
+func refillDuration(tokens, rate float64) time.Duration {
+	if tokens <= 0 || rate <= 0 {
+		return 0
+	}
+	return time.Duration(tokens / rate * float64(time.Second))
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Subject is a node of the subject taxonomy, such as a genre. Subjects without a parent are
+// top-level; names are unique among siblings regardless of case.
+type Subject struct {
+	ID        uuid.UUID  `json:"id"`
+	Name      string     `json:"name"`
+	ParentID  *uuid.UUID `json:"parent_id,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// CreateSubjectRequest represents the request payload for creating a new subject.
+type CreateSubjectRequest struct {
+	Name     string     `json:"name" validate:"required,min=1,max=200"`
+	ParentID *uuid.UUID `json:"parent_id,omitempty"`
+}
+
+// UpdateSubjectRequest renames a subject or moves it under another parent; a nil ParentID
+// makes it top-level.
+type UpdateSubjectRequest struct {
+	Name     string     `json:"name" validate:"required,min=1,max=200"`
+	ParentID *uuid.UUID `json:"parent_id,omitempty"`
+}
+
+// SetBookSubjectsRequest replaces the subjects of a book.
+type SetBookSubjectsRequest struct {
+	SubjectIDs []uuid.UUID `json:"subject_ids" validate:"max=50,dive,required"`
+}
+
+// SetBookTagsRequest replaces the tags of a book.
+type SetBookTagsRequest struct {
+	Tags []string `json:"tags" validate:"max=50,dive,required,max=50"`
+}
+
+// TagCount is a tag with the number of books carrying it.
+type TagCount struct {
+	Tag   string `json:"tag"`
+	Books int    `json:"books"`
+}
@@ -0,0 +1,187 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/bkiran6398/library/internal/http/response"
+	"github.com/bkiran6398/library/internal/subjects/domain"
+	"github.com/bkiran6398/library/internal/subjects/service"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// Handler handles HTTP requests for subjects and book tags.
+type Handler struct {
+	service service.Service
+}
+
+// NewHandler creates a new Handler instance.
+func NewHandler(service service.Service) Handler {
+	return Handler{service: service}
+}
+
+func (handler Handler) List(w http.ResponseWriter, r *http.Request) {
+	subjects, err := handler.service.List(r.Context())
+	if err != nil {
+		response.MapServiceErrorToHTTP(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, subjects)
+}
+
+func (handler Handler) Create(w http.ResponseWriter, r *http.Request) {
+	var createRequest domain.CreateSubjectRequest
+	if err := json.NewDecoder(r.Body).Decode(&createRequest); err != nil {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Invalid JSON body", nil)
+		return
+	}
+
+	subject, err := handler.service.Create(r.Context(), createRequest)
+	if err != nil {
+		response.MapServiceErrorToHTTP(w, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, subject)
+}
+
+func (handler Handler) Get(w http.ResponseWriter, r *http.Request) {
+	subjectID, err := parseIDFromPath(r)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Invalid subject ID", nil)
+		return
+	}
+
+	subject, err := handler.service.Get(r.Context(), subjectID)
+	if err != nil {
+		response.MapServiceErrorToHTTP(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, subject)
+}
+
+func (handler Handler) Update(w http.ResponseWriter, r *http.Request) {
+	subjectID, err := parseIDFromPath(r)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Invalid subject ID", nil)
+		return
+	}
+
+	var updateRequest domain.UpdateSubjectRequest
+	if err := json.NewDecoder(r.Body).Decode(&updateRequest); err != nil {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Invalid JSON body", nil)
+		return
+	}
+
+	subject, err := handler.service.Update(r.Context(), subjectID, updateRequest)
+	if err != nil {
+		response.MapServiceErrorToHTTP(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, subject)
+}
+
+func (handler Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	subjectID, err := parseIDFromPath(r)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Invalid subject ID", nil)
+		return
+	}
+
+	if err := handler.service.Delete(r.Context(), subjectID); err != nil {
+		response.MapServiceErrorToHTTP(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// BookSubjects serves GET /books/{id}/subjects.
+func (handler Handler) BookSubjects(w http.ResponseWriter, r *http.Request) {
+	bookID, err := parseIDFromPath(r)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Invalid book ID", nil)
+		return
+	}
+
+	subjects, err := handler.service.BookSubjects(r.Context(), bookID)
+	if err != nil {
+		response.MapServiceErrorToHTTP(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, subjects)
+}
+
+// SetBookSubjects serves PUT /books/{id}/subjects.
+func (handler Handler) SetBookSubjects(w http.ResponseWriter, r *http.Request) {
+	bookID, err := parseIDFromPath(r)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Invalid book ID", nil)
+		return
+	}
+
+	var setRequest domain.SetBookSubjectsRequest
+	if err := json.NewDecoder(r.Body).Decode(&setRequest); err != nil {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Invalid JSON body", nil)
+		return
+	}
+
+	subjects, err := handler.service.SetBookSubjects(r.Context(), bookID, setRequest)
+	if err != nil {
+		response.MapServiceErrorToHTTP(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, subjects)
+}
+
+// BookTags serves GET /books/{id}/tags.
+func (handler Handler) BookTags(w http.ResponseWriter, r *http.Request) {
+	bookID, err := parseIDFromPath(r)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Invalid book ID", nil)
+		return
+	}
+
+	tags, err := handler.service.BookTags(r.Context(), bookID)
+	if err != nil {
+		response.MapServiceErrorToHTTP(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, tags)
+}
+
+// SetBookTags serves PUT /books/{id}/tags.
+func (handler Handler) SetBookTags(w http.ResponseWriter, r *http.Request) {
+	bookID, err := parseIDFromPath(r)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Invalid book ID", nil)
+		return
+	}
+
+	var setRequest domain.SetBookTagsRequest
+	if err := json.NewDecoder(r.Body).Decode(&setRequest); err != nil {
+		response.Error(w, http.StatusBadRequest, "bad_request", "Invalid JSON body", nil)
+		return
+	}
+
+	tags, err := handler.service.SetBookTags(r.Context(), bookID, setRequest)
+	if err != nil {
+		response.MapServiceErrorToHTTP(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, tags)
+}
+
+// ListTags serves GET /tags.
+func (handler Handler) ListTags(w http.ResponseWriter, r *http.Request) {
+	tags, err := handler.service.ListTags(r.Context())
+	if err != nil {
+		response.MapServiceErrorToHTTP(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, tags)
+}
+
+// parseIDFromPath extracts and parses the subject or book ID from the request path.
+func parseIDFromPath(r *http.Request) (uuid.UUID, error) {
+	return uuid.Parse(mux.Vars(r)["id"])
+}
@@ -0,0 +1,192 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=repository.go -destination=mocks/repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/bkiran6398/library/internal/subjects/domain"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// BookSubjects mocks base method.
+func (m *MockRepository) BookSubjects(ctx context.Context, bookID uuid.UUID) ([]domain.Subject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BookSubjects", ctx, bookID)
+	ret0, _ := ret[0].([]domain.Subject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BookSubjects indicates an expected call of BookSubjects.
+func (mr *MockRepositoryMockRecorder) BookSubjects(ctx, bookID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BookSubjects", reflect.TypeOf((*MockRepository)(nil).BookSubjects), ctx, bookID)
+}
+
+// BookTags mocks base method.
+func (m *MockRepository) BookTags(ctx context.Context, bookID uuid.UUID) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BookTags", ctx, bookID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BookTags indicates an expected call of BookTags.
+func (mr *MockRepositoryMockRecorder) BookTags(ctx, bookID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BookTags", reflect.TypeOf((*MockRepository)(nil).BookTags), ctx, bookID)
+}
+
+// Create mocks base method.
+func (m *MockRepository) Create(ctx context.Context, subject domain.Subject) (domain.Subject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, subject)
+	ret0, _ := ret[0].(domain.Subject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRepositoryMockRecorder) Create(ctx, subject any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), ctx, subject)
+}
+
+// Delete mocks base method.
+func (m *MockRepository) Delete(ctx context.Context, subjectID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, subjectID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRepositoryMockRecorder) Delete(ctx, subjectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), ctx, subjectID)
+}
+
+// Get mocks base method.
+func (m *MockRepository) Get(ctx context.Context, subjectID uuid.UUID) (domain.Subject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, subjectID)
+	ret0, _ := ret[0].(domain.Subject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRepositoryMockRecorder) Get(ctx, subjectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepository)(nil).Get), ctx, subjectID)
+}
+
+// List mocks base method.
+func (m *MockRepository) List(ctx context.Context) ([]domain.Subject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]domain.Subject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockRepositoryMockRecorder) List(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRepository)(nil).List), ctx)
+}
+
+// ListTags mocks base method.
+func (m *MockRepository) ListTags(ctx context.Context) ([]domain.TagCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTags", ctx)
+	ret0, _ := ret[0].([]domain.TagCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTags indicates an expected call of ListTags.
+func (mr *MockRepositoryMockRecorder) ListTags(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTags", reflect.TypeOf((*MockRepository)(nil).ListTags), ctx)
+}
+
+// SetBookSubjects mocks base method.
+func (m *MockRepository) SetBookSubjects(ctx context.Context, bookID uuid.UUID, subjectIDs []uuid.UUID) ([]domain.Subject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBookSubjects", ctx, bookID, subjectIDs)
+	ret0, _ := ret[0].([]domain.Subject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetBookSubjects indicates an expected call of SetBookSubjects.
+func (mr *MockRepositoryMockRecorder) SetBookSubjects(ctx, bookID, subjectIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBookSubjects", reflect.TypeOf((*MockRepository)(nil).SetBookSubjects), ctx, bookID, subjectIDs)
+}
+
+// SetBookTags mocks base method.
+func (m *MockRepository) SetBookTags(ctx context.Context, bookID uuid.UUID, tags []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBookTags", ctx, bookID, tags)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetBookTags indicates an expected call of SetBookTags.
+func (mr *MockRepositoryMockRecorder) SetBookTags(ctx, bookID, tags any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBookTags", reflect.TypeOf((*MockRepository)(nil).SetBookTags), ctx, bookID, tags)
+}
+
+// Update mocks base method.
+func (m *MockRepository) Update(ctx context.Context, subject domain.Subject) (domain.Subject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, subject)
+	ret0, _ := ret[0].(domain.Subject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockRepositoryMockRecorder) Update(ctx, subject any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepository)(nil).Update), ctx, subject)
+}
@@ -0,0 +1,319 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	intErr "github.com/bkiran6398/library/internal/errors"
+	"github.com/bkiran6398/library/internal/subjects/domain"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const subjectColumns = `subjects.id, subjects.name, subjects.parent_id, subjects.created_at, subjects.updated_at`
+
+// pgRepository is the PostgreSQL implementation of Repository.
+type pgRepository struct {
+	dbPool *pgxpool.Pool
+}
+
+// NewPgRepository creates a new PostgreSQL-based Repository implementation.
+// This constructor is the only place where consumers should depend on the concrete type.
+func NewPgRepository(dbPool *pgxpool.Pool) *pgRepository {
+	return &pgRepository{dbPool: dbPool}
+}
+
+func (repository *pgRepository) Create(ctx context.Context, subject domain.Subject) (domain.Subject, error) {
+	const insertQuery = `
+INSERT INTO subjects (id, name, parent_id, created_at, updated_at)
+VALUES ($1,$2,$3,NOW(),NOW())
+RETURNING created_at, updated_at;
+`
+	row := repository.dbPool.QueryRow(ctx, insertQuery, subject.ID, subject.Name, subject.ParentID)
+	if err := row.Scan(&subject.CreatedAt, &subject.UpdatedAt); err != nil {
+		return domain.Subject{}, mapWriteError("insert subject", err)
+	}
+	return subject, nil
+}
+
+// mapWriteError converts constraint violations on subjects to service errors.
+func mapWriteError(operation string, err error) error {
+	var pgError *pgconn.PgError
+	if !errors.As(err, &pgError) {
+		return fmt.Errorf("%s: %w", operation, err)
+	}
+	switch pgError.Code {
+	case "23505":
+		return fmt.Errorf("%w: a subject with this name already exists under the same parent", intErr.ErrConflict)
+	case "23503":
+		return fmt.Errorf("%w: unknown parent subject", intErr.ErrBadRequest)
+	case "23514":
+		return fmt.Errorf("%w: a subject cannot be its own parent", intErr.ErrBadRequest)
+	default:
+		return fmt.Errorf("%s: %w", operation, err)
+	}
+}
+
+// isPgErrorCode checks if the error is a PostgreSQL error with the given SQLSTATE code.
+func isPgErrorCode(err error, code string) bool {
+	var pgError *pgconn.PgError
+	return errors.As(err, &pgError) && pgError.Code == code
+}
+
+func (repository *pgRepository) Get(ctx context.Context, subjectID uuid.UUID) (domain.Subject, error) {
+	const selectQuery = `SELECT ` + subjectColumns + ` FROM subjects WHERE id=$1;`
+	subject, err := scanSubject(repository.dbPool.QueryRow(ctx, selectQuery, subjectID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Subject{}, intErr.ErrNotFound
+		}
+		return domain.Subject{}, fmt.Errorf("get subject: %w", err)
+	}
+	return subject, nil
+}
+
+func (repository *pgRepository) Update(ctx context.Context, subject domain.Subject) (domain.Subject, error) {
+	tx, err := repository.dbPool.Begin(ctx)
+	if err != nil {
+		return domain.Subject{}, fmt.Errorf("begin update subject: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if subject.ParentID != nil {
+		// Moves are serialised so two concurrent moves cannot close a cycle between them.
+		if _, err := tx.Exec(ctx, `LOCK TABLE subjects IN SHARE ROW EXCLUSIVE MODE;`); err != nil {
+			return domain.Subject{}, fmt.Errorf("lock subjects: %w", err)
+		}
+		const cycleQuery = `
+WITH RECURSIVE descendants AS (
+	SELECT id FROM subjects WHERE id=$1
+	UNION ALL
+	SELECT subjects.id FROM subjects JOIN descendants ON subjects.parent_id = descendants.id
+)
+SELECT EXISTS (SELECT 1 FROM descendants WHERE id=$2);
+`
+		var createsCycle bool
+		if err := tx.QueryRow(ctx, cycleQuery, subject.ID, *subject.ParentID).Scan(&createsCycle); err != nil {
+			return domain.Subject{}, fmt.Errorf("check subject cycle: %w", err)
+		}
+		if createsCycle {
+			return domain.Subject{}, fmt.Errorf("%w: a subject cannot be moved under itself or its descendants", intErr.ErrBadRequest)
+		}
+	}
+
+	const updateQuery = `
+UPDATE subjects SET name=$2, parent_id=$3, updated_at=NOW()
+WHERE id=$1
+RETURNING ` + subjectColumns + `;`
+	updated, err := scanSubject(tx.QueryRow(ctx, updateQuery, subject.ID, subject.Name, subject.ParentID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Subject{}, intErr.ErrNotFound
+		}
+		return domain.Subject{}, mapWriteError("update subject", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return domain.Subject{}, fmt.Errorf("commit update subject: %w", err)
+	}
+	return updated, nil
+}
+
+func (repository *pgRepository) Delete(ctx context.Context, subjectID uuid.UUID) error {
+	const deleteQuery = `DELETE FROM subjects WHERE id=$1;`
+	result, err := repository.dbPool.Exec(ctx, deleteQuery, subjectID)
+	if err != nil {
+		if isPgErrorCode(err, "23503") {
+			return fmt.Errorf("%w: the subject still has child subjects or books", intErr.ErrConflict)
+		}
+		return fmt.Errorf("delete subject: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return intErr.ErrNotFound
+	}
+	return nil
+}
+
+func (repository *pgRepository) List(ctx context.Context) ([]domain.Subject, error) {
+	const selectQuery = `SELECT ` + subjectColumns + ` FROM subjects ORDER BY lower(name), id;`
+	return querySubjects(ctx, repository.dbPool, "list subjects", selectQuery)
+}
+
+func (repository *pgRepository) BookSubjects(ctx context.Context, bookID uuid.UUID) ([]domain.Subject, error) {
+	if err := requireBook(ctx, repository.dbPool, bookID, ""); err != nil {
+		return nil, err
+	}
+	return selectBookSubjects(ctx, repository.dbPool, bookID)
+}
+
+func (repository *pgRepository) SetBookSubjects(ctx context.Context, bookID uuid.UUID, subjectIDs []uuid.UUID) ([]domain.Subject, error) {
+	tx, err := repository.dbPool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin set book subjects: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := requireBook(ctx, tx, bookID, " FOR UPDATE"); err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM book_subjects WHERE book_id=$1;`, bookID); err != nil {
+		return nil, fmt.Errorf("clear book subjects: %w", err)
+	}
+	const insertQuery = `
+INSERT INTO book_subjects (book_id, subject_id)
+SELECT $1, subject_id FROM unnest($2::uuid[]) AS subject_id
+ON CONFLICT DO NOTHING;
+`
+	if _, err := tx.Exec(ctx, insertQuery, bookID, subjectIDs); err != nil {
+		if isPgErrorCode(err, "23503") {
+			return nil, fmt.Errorf("%w: unknown subject", intErr.ErrBadRequest)
+		}
+		return nil, fmt.Errorf("insert book subjects: %w", err)
+	}
+
+	subjects, err := selectBookSubjects(ctx, tx, bookID)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit set book subjects: %w", err)
+	}
+	return subjects, nil
+}
+
+func (repository *pgRepository) BookTags(ctx context.Context, bookID uuid.UUID) ([]string, error) {
+	if err := requireBook(ctx, repository.dbPool, bookID, ""); err != nil {
+		return nil, err
+	}
+	return selectBookTags(ctx, repository.dbPool, bookID)
+}
+
+func (repository *pgRepository) SetBookTags(ctx context.Context, bookID uuid.UUID, tags []string) ([]string, error) {
+	tx, err := repository.dbPool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin set book tags: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := requireBook(ctx, tx, bookID, " FOR UPDATE"); err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM book_tags WHERE book_id=$1;`, bookID); err != nil {
+		return nil, fmt.Errorf("clear book tags: %w", err)
+	}
+	const insertQuery = `
+INSERT INTO book_tags (book_id, tag)
+SELECT $1, tag FROM unnest($2::text[]) AS tag
+ON CONFLICT DO NOTHING;
+`
+	if _, err := tx.Exec(ctx, insertQuery, bookID, tags); err != nil {
+		return nil, fmt.Errorf("insert book tags: %w", err)
+	}
+
+	stored, err := selectBookTags(ctx, tx, bookID)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit set book tags: %w", err)
+	}
+	return stored, nil
+}
+
+func (repository *pgRepository) ListTags(ctx context.Context) ([]domain.TagCount, error) {
+	const selectQuery = `SELECT tag, COUNT(*) FROM book_tags GROUP BY tag ORDER BY COUNT(*) DESC, tag;`
+	rows, err := repository.dbPool.Query(ctx, selectQuery)
+	if err != nil {
+		return nil, fmt.Errorf("list tags: %w", err)
+	}
+	defer rows.Close()
+
+	tags := []domain.TagCount{}
+	for rows.Next() {
+		var tagCount domain.TagCount
+		if err := rows.Scan(&tagCount.Tag, &tagCount.Books); err != nil {
+			return nil, fmt.Errorf("scan tag row: %w", err)
+		}
+		tags = append(tags, tagCount)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return tags, nil
+}
+
+// querier is satisfied by both the pool and a transaction.
+type querier interface {
+	Query(ctx context.Context, query string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, query string, args ...any) pgx.Row
+}
+
+// requireBook returns ErrNotFound unless the book exists. lock is appended to the query.
+func requireBook(ctx context.Context, db querier, bookID uuid.UUID, lock string) error {
+	var found uuid.UUID
+	if err := db.QueryRow(ctx, `SELECT id FROM books WHERE id=$1`+lock+`;`, bookID).Scan(&found); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return intErr.ErrNotFound
+		}
+		return fmt.Errorf("get book: %w", err)
+	}
+	return nil
+}
+
+// selectBookSubjects reads the subjects of a book ordered by name.
+func selectBookSubjects(ctx context.Context, db querier, bookID uuid.UUID) ([]domain.Subject, error) {
+	const selectQuery = `
+SELECT ` + subjectColumns + `
+FROM book_subjects JOIN subjects ON subjects.id = book_subjects.subject_id
+WHERE book_subjects.book_id=$1
+ORDER BY lower(subjects.name), subjects.id;
+`
+	return querySubjects(ctx, db, "list book subjects", selectQuery, bookID)
+}
+
+// selectBookTags reads the tags of a book in alphabetical order.
+func selectBookTags(ctx context.Context, db querier, bookID uuid.UUID) ([]string, error) {
+	rows, err := db.Query(ctx, `SELECT tag FROM book_tags WHERE book_id=$1 ORDER BY tag;`, bookID)
+	if err != nil {
+		return nil, fmt.Errorf("list book tags: %w", err)
+	}
+	tags, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("list book tags: %w", err)
+	}
+	if tags == nil {
+		tags = []string{}
+	}
+	return tags, nil
+}
+
+// querySubjects runs a query selecting subjectColumns and scans every row.
+func querySubjects(ctx context.Context, db querier, operation, query string, args ...any) ([]domain.Subject, error) {
+	rows, err := db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", operation, err)
+	}
+	defer rows.Close()
+
+	subjects := []domain.Subject{}
+	for rows.Next() {
+		subject, err := scanSubject(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan subject row: %w", err)
+		}
+		subjects = append(subjects, subject)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return subjects, nil
+}
+
+// scanSubject scans a single row into a Subject entity.
+func scanSubject(row pgx.Row) (domain.Subject, error) {
+	var subject domain.Subject
+	err := row.Scan(&subject.ID, &subject.Name, &subject.ParentID, &subject.CreatedAt, &subject.UpdatedAt)
+	return subject, err
+}
@@ -0,0 +1,29 @@
+//go:generate mockgen -source=repository.go -destination=mocks/repository.go -package=mocks
+package repository
+
+import (
+	"context"
+
+	"github.com/bkiran6398/library/internal/subjects/domain"
+	"github.com/google/uuid"
+)
+
+// Repository defines the interface for subject and tag data access operations.
+// Consumers should depend on this interface, not on concrete implementations.
+type Repository interface {
+	Create(ctx context.Context, subject domain.Subject) (domain.Subject, error)
+	Get(ctx context.Context, subjectID uuid.UUID) (domain.Subject, error)
+	// Update renames or moves a subject. Moving a subject under itself or one of its
+	// descendants fails with ErrBadRequest.
+	Update(ctx context.Context, subject domain.Subject) (domain.Subject, error)
+	// Delete removes a subject. It fails with ErrConflict while it has children or books.
+	Delete(ctx context.Context, subjectID uuid.UUID) error
+	// List returns the whole taxonomy ordered by name.
+	List(ctx context.Context) ([]domain.Subject, error)
+	BookSubjects(ctx context.Context, bookID uuid.UUID) ([]domain.Subject, error)
+	SetBookSubjects(ctx context.Context, bookID uuid.UUID, subjectIDs []uuid.UUID) ([]domain.Subject, error)
+	BookTags(ctx context.Context, bookID uuid.UUID) ([]string, error)
+	SetBookTags(ctx context.Context, bookID uuid.UUID, tags []string) ([]string, error)
+	// ListTags returns the tags in use with their book counts, most used first.
+	ListTags(ctx context.Context) ([]domain.TagCount, error)
+}
@@ -0,0 +1,192 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=service.go -destination=mocks/service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/bkiran6398/library/internal/subjects/domain"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// BookSubjects mocks base method.
+func (m *MockService) BookSubjects(ctx context.Context, bookID uuid.UUID) ([]domain.Subject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BookSubjects", ctx, bookID)
+	ret0, _ := ret[0].([]domain.Subject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BookSubjects indicates an expected call of BookSubjects.
+func (mr *MockServiceMockRecorder) BookSubjects(ctx, bookID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BookSubjects", reflect.TypeOf((*MockService)(nil).BookSubjects), ctx, bookID)
+}
+
+// BookTags mocks base method.
+func (m *MockService) BookTags(ctx context.Context, bookID uuid.UUID) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BookTags", ctx, bookID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BookTags indicates an expected call of BookTags.
+func (mr *MockServiceMockRecorder) BookTags(ctx, bookID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BookTags", reflect.TypeOf((*MockService)(nil).BookTags), ctx, bookID)
+}
+
+// Create mocks base method.
+func (m *MockService) Create(ctx context.Context, createRequest domain.CreateSubjectRequest) (domain.Subject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, createRequest)
+	ret0, _ := ret[0].(domain.Subject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockServiceMockRecorder) Create(ctx, createRequest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockService)(nil).Create), ctx, createRequest)
+}
+
+// Delete mocks base method.
+func (m *MockService) Delete(ctx context.Context, subjectID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, subjectID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockServiceMockRecorder) Delete(ctx, subjectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockService)(nil).Delete), ctx, subjectID)
+}
+
+// Get mocks base method.
+func (m *MockService) Get(ctx context.Context, subjectID uuid.UUID) (domain.Subject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, subjectID)
+	ret0, _ := ret[0].(domain.Subject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockServiceMockRecorder) Get(ctx, subjectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockService)(nil).Get), ctx, subjectID)
+}
+
+// List mocks base method.
+func (m *MockService) List(ctx context.Context) ([]domain.Subject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]domain.Subject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockServiceMockRecorder) List(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockService)(nil).List), ctx)
+}
+
+// ListTags mocks base method.
+func (m *MockService) ListTags(ctx context.Context) ([]domain.TagCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTags", ctx)
+	ret0, _ := ret[0].([]domain.TagCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTags indicates an expected call of ListTags.
+func (mr *MockServiceMockRecorder) ListTags(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTags", reflect.TypeOf((*MockService)(nil).ListTags), ctx)
+}
+
+// SetBookSubjects mocks base method.
+func (m *MockService) SetBookSubjects(ctx context.Context, bookID uuid.UUID, setRequest domain.SetBookSubjectsRequest) ([]domain.Subject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBookSubjects", ctx, bookID, setRequest)
+	ret0, _ := ret[0].([]domain.Subject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetBookSubjects indicates an expected call of SetBookSubjects.
+func (mr *MockServiceMockRecorder) SetBookSubjects(ctx, bookID, setRequest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBookSubjects", reflect.TypeOf((*MockService)(nil).SetBookSubjects), ctx, bookID, setRequest)
+}
+
+// SetBookTags mocks base method.
+func (m *MockService) SetBookTags(ctx context.Context, bookID uuid.UUID, setRequest domain.SetBookTagsRequest) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBookTags", ctx, bookID, setRequest)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetBookTags indicates an expected call of SetBookTags.
+func (mr *MockServiceMockRecorder) SetBookTags(ctx, bookID, setRequest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBookTags", reflect.TypeOf((*MockService)(nil).SetBookTags), ctx, bookID, setRequest)
+}
+
+// Update mocks base method.
+func (m *MockService) Update(ctx context.Context, subjectID uuid.UUID, updateRequest domain.UpdateSubjectRequest) (domain.Subject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, subjectID, updateRequest)
+	ret0, _ := ret[0].(domain.Subject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockServiceMockRecorder) Update(ctx, subjectID, updateRequest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockService)(nil).Update), ctx, subjectID, updateRequest)
+}
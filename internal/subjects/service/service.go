@@ -0,0 +1,24 @@
+//go:generate mockgen -source=service.go -destination=mocks/service.go -package=mocks
+package service
+
+import (
+	"context"
+
+	"github.com/bkiran6398/library/internal/subjects/domain"
+	"github.com/google/uuid"
+)
+
+// Service defines the interface for the subject taxonomy and book tagging.
+// Consumers should depend on this interface, not on concrete implementations.
+type Service interface {
+	Create(ctx context.Context, createRequest domain.CreateSubjectRequest) (domain.Subject, error)
+	Get(ctx context.Context, subjectID uuid.UUID) (domain.Subject, error)
+	Update(ctx context.Context, subjectID uuid.UUID, updateRequest domain.UpdateSubjectRequest) (domain.Subject, error)
+	Delete(ctx context.Context, subjectID uuid.UUID) error
+	List(ctx context.Context) ([]domain.Subject, error)
+	BookSubjects(ctx context.Context, bookID uuid.UUID) ([]domain.Subject, error)
+	SetBookSubjects(ctx context.Context, bookID uuid.UUID, setRequest domain.SetBookSubjectsRequest) ([]domain.Subject, error)
+	BookTags(ctx context.Context, bookID uuid.UUID) ([]string, error)
+	SetBookTags(ctx context.Context, bookID uuid.UUID, setRequest domain.SetBookTagsRequest) ([]string, error)
+	ListTags(ctx context.Context) ([]domain.TagCount, error)
+}
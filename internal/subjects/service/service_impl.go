@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	intErr "github.com/bkiran6398/library/internal/errors"
+	"github.com/bkiran6398/library/internal/subjects/domain"
+	"github.com/bkiran6398/library/internal/subjects/repository"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+const (
+	createTimeout = 5 * time.Second
+	getTimeout    = 3 * time.Second
+	updateTimeout = 5 * time.Second
+	deleteTimeout = 5 * time.Second
+	listTimeout   = 10 * time.Second
+	setTimeout    = 5 * time.Second
+)
+
+// service is the implementation of Service.
+type service struct {
+	repository repository.Repository
+	validator  *validator.Validate
+}
+
+// NewService creates a new Service implementation.
+// This constructor is the only place where consumers should depend on the concrete type.
+func NewService(repository repository.Repository) Service {
+	return &service{
+		repository: repository,
+		validator:  validator.New(),
+	}
+}
+
+func (serviceInstance *service) Create(ctx context.Context, createRequest domain.CreateSubjectRequest) (domain.Subject, error) {
+	createRequest.Name = normalizeName(createRequest.Name)
+	if err := serviceInstance.validator.Struct(createRequest); err != nil {
+		return domain.Subject{}, fmt.Errorf("%w: %v", intErr.ErrBadRequest, err)
+	}
+
+	subject := domain.Subject{ID: uuid.New(), Name: createRequest.Name, ParentID: createRequest.ParentID}
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+	return serviceInstance.repository.Create(ctxWithTimeout, subject)
+}
+
+func (serviceInstance *service) Get(ctx context.Context, subjectID uuid.UUID) (domain.Subject, error) {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, getTimeout)
+	defer cancel()
+	return serviceInstance.repository.Get(ctxWithTimeout, subjectID)
+}
+
+func (serviceInstance *service) Update(ctx context.Context, subjectID uuid.UUID, updateRequest domain.UpdateSubjectRequest) (domain.Subject, error) {
+	updateRequest.Name = normalizeName(updateRequest.Name)
+	if err := serviceInstance.validator.Struct(updateRequest); err != nil {
+		return domain.Subject{}, fmt.Errorf("%w: %v", intErr.ErrBadRequest, err)
+	}
+	if updateRequest.ParentID != nil && *updateRequest.ParentID == subjectID {
+		return domain.Subject{}, fmt.Errorf("%w: a subject cannot be its own parent", intErr.ErrBadRequest)
+	}
+
+	subject := domain.Subject{ID: subjectID, Name: updateRequest.Name, ParentID: updateRequest.ParentID}
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+	return serviceInstance.repository.Update(ctxWithTimeout, subject)
+}
+
+func (serviceInstance *service) Delete(ctx context.Context, subjectID uuid.UUID) error {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+	return serviceInstance.repository.Delete(ctxWithTimeout, subjectID)
+}
+
+func (serviceInstance *service) List(ctx context.Context) ([]domain.Subject, error) {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, listTimeout)
+	defer cancel()
+	return serviceInstance.repository.List(ctxWithTimeout)
+}
+
+func (serviceInstance *service) BookSubjects(ctx context.Context, bookID uuid.UUID) ([]domain.Subject, error) {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, getTimeout)
+	defer cancel()
+	return serviceInstance.repository.BookSubjects(ctxWithTimeout, bookID)
+}
+
+func (serviceInstance *service) SetBookSubjects(ctx context.Context, bookID uuid.UUID, setRequest domain.SetBookSubjectsRequest) ([]domain.Subject, error) {
+	if err := serviceInstance.validator.Struct(setRequest); err != nil {
+		return nil, fmt.Errorf("%w: %v", intErr.ErrBadRequest, err)
+	}
+	subjectIDs := setRequest.SubjectIDs
+	if subjectIDs == nil {
+		subjectIDs = []uuid.UUID{}
+	}
+
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, setTimeout)
+	defer cancel()
+	return serviceInstance.repository.SetBookSubjects(ctxWithTimeout, bookID, subjectIDs)
+}
+
+func (serviceInstance *service) BookTags(ctx context.Context, bookID uuid.UUID) ([]string, error) {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, getTimeout)
+	defer cancel()
+	return serviceInstance.repository.BookTags(ctxWithTimeout, bookID)
+}
+
+func (serviceInstance *service) SetBookTags(ctx context.Context, bookID uuid.UUID, setRequest domain.SetBookTagsRequest) ([]string, error) {
+	tags := make([]string, 0, len(setRequest.Tags))
+	seen := make(map[string]bool, len(setRequest.Tags))
+	for _, tag := range setRequest.Tags {
+		tag = normalizeTag(tag)
+		if !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	setRequest.Tags = tags
+	if err := serviceInstance.validator.Struct(setRequest); err != nil {
+		return nil, fmt.Errorf("%w: %v", intErr.ErrBadRequest, err)
+	}
+
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, setTimeout)
+	defer cancel()
+	return serviceInstance.repository.SetBookTags(ctxWithTimeout, bookID, tags)
+}
+
+func (serviceInstance *service) ListTags(ctx context.Context) ([]domain.TagCount, error) {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, listTimeout)
+	defer cancel()
+	return serviceInstance.repository.ListTags(ctxWithTimeout)
+}
+
+// normalizeTag lower-cases a tag and collapses its whitespace, so "Sci  Fi" and "sci fi"
+// are the same tag.
+func normalizeTag(tag string) string {
+	return strings.ToLower(normalizeName(tag))
+}
+
+// normalizeName trims the name and collapses runs of whitespace.
+func normalizeName(name string) string {
+	return strings.Join(strings.Fields(name), " ")
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	intErr "github.com/bkiran6398/library/internal/errors"
+	"github.com/bkiran6398/library/internal/subjects/domain"
+	"github.com/bkiran6398/library/internal/subjects/repository/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreate_ChildSubject(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	service := NewService(mockRepo)
+
+	parentID := uuid.New()
+	mockRepo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, subject domain.Subject) (domain.Subject, error) {
+			require.NotEqual(t, uuid.Nil, subject.ID)
+			require.Equal(t, "Science Fiction", subject.Name)
+			require.Equal(t, parentID, *subject.ParentID)
+			return subject, nil
+		}).
+		Times(1)
+
+	_, err := service.Create(context.Background(), domain.CreateSubjectRequest{Name: " Science  Fiction", ParentID: &parentID})
+	require.NoError(t, err)
+
+	_, err = service.Create(context.Background(), domain.CreateSubjectRequest{Name: "  "})
+	require.ErrorIs(t, err, intErr.ErrBadRequest)
+}
+
+func TestUpdate_RejectsOwnParent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	service := NewService(mockRepo)
+
+	subjectID := uuid.New()
+	_, err := service.Update(context.Background(), subjectID, domain.UpdateSubjectRequest{Name: "Fantasy", ParentID: &subjectID})
+	require.ErrorIs(t, err, intErr.ErrBadRequest)
+
+	moved := domain.Subject{ID: subjectID, Name: "Fantasy"}
+	mockRepo.EXPECT().Update(gomock.Any(), moved).Return(moved, nil).Times(1)
+	updated, err := service.Update(context.Background(), subjectID, domain.UpdateSubjectRequest{Name: "Fantasy"})
+	require.NoError(t, err)
+	require.Equal(t, moved, updated)
+}
+
+func TestSetBookTags_NormalizesAndDeduplicates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	service := NewService(mockRepo)
+
+	bookID := uuid.New()
+	mockRepo.EXPECT().
+		SetBookTags(gomock.Any(), bookID, []string{"space opera", "classics"}).
+		Return([]string{"classics", "space opera"}, nil).
+		Times(1)
+
+	tags, err := service.SetBookTags(context.Background(), bookID, domain.SetBookTagsRequest{Tags: []string{"Space  Opera", "classics", "space opera"}})
+	require.NoError(t, err)
+	require.Equal(t, []string{"classics", "space opera"}, tags)
+}
+
+func TestSetBookTags_RejectsEmptyTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	service := NewService(mockRepo)
+
+	_, err := service.SetBookTags(context.Background(), uuid.New(), domain.SetBookTagsRequest{Tags: []string{"   "}})
+	require.ErrorIs(t, err, intErr.ErrBadRequest)
+}
+
+func TestSetBookSubjects_ClearsWithEmptyList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	service := NewService(mockRepo)
+
+	bookID := uuid.New()
+	mockRepo.EXPECT().SetBookSubjects(gomock.Any(), bookID, []uuid.UUID{}).Return([]domain.Subject{}, nil).Times(1)
+
+	subjects, err := service.SetBookSubjects(context.Background(), bookID, domain.SetBookSubjectsRequest{})
+	require.NoError(t, err)
+	require.Empty(t, subjects)
+}
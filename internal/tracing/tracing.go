@@ -0,0 +1,103 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// InstrumentationName identifies spans created by this application's instrumentation.
+const InstrumentationName = "github.com/bkiran6398/library"
+
+// Config configures span export.
+type Config struct {
+	Enabled bool
+	// Endpoint is the OTLP/HTTP collector address, e.g. "otel-collector:4318".
+	Endpoint    string
+	Insecure    bool
+	ServiceName string
+	// SampleRatio is the fraction of new traces recorded; parent decisions are honoured.
+	SampleRatio float64
+}
+
+// Provider bundles the tracer provider and propagator used by the instrumentation.
+type Provider struct {
+	TracerProvider trace.TracerProvider
+	Propagator     propagation.TextMapPropagator
+	shutdown       func(context.Context) error
+}
+
+// Tracer returns the application tracer.
+func (provider *Provider) Tracer() trace.Tracer {
+	return provider.TracerProvider.Tracer(InstrumentationName)
+}
+
+// Shutdown flushes pending spans and stops the exporter.
+func (provider *Provider) Shutdown(ctx context.Context) error {
+	return provider.shutdown(ctx)
+}
+
+// NewProvider creates a provider that exports over OTLP/HTTP. When tracing is disabled
+// it returns a no-op provider so instrumentation can stay in place unconditionally.
+// The provider and W3C traceparent propagator are also installed as OTel globals.
+func NewProvider(ctx context.Context, config Config) (*Provider, error) {
+	if !config.Enabled {
+		return &Provider{
+			TracerProvider: noop.NewTracerProvider(),
+			Propagator:     newPropagator(),
+			shutdown:       func(context.Context) error { return nil },
+		}, nil
+	}
+
+	exporterOptions := []otlptracehttp.Option{otlptracehttp.WithEndpoint(config.Endpoint)}
+	if config.Insecure {
+		exporterOptions = append(exporterOptions, otlptracehttp.WithInsecure())
+	}
+	exporter, err := otlptracehttp.New(ctx, exporterOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("create otlp exporter: %w", err)
+	}
+
+	return NewProviderWithExporter(config, sdktrace.WithBatcher(exporter))
+}
+
+// NewProviderWithExporter creates a provider using the given span processor option, e.g.
+// sdktrace.WithSyncer(tracetest.NewInMemoryExporter()) in tests.
+func NewProviderWithExporter(config Config, processor sdktrace.TracerProviderOption) (*Provider, error) {
+	serviceResource, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(config.ServiceName),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("create trace resource: %w", err)
+	}
+
+	tracerProvider := sdktrace.NewTracerProvider(
+		processor,
+		sdktrace.WithResource(serviceResource),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SampleRatio))),
+	)
+	propagator := newPropagator()
+
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetTextMapPropagator(propagator)
+
+	return &Provider{
+		TracerProvider: tracerProvider,
+		Propagator:     propagator,
+		shutdown:       tracerProvider.Shutdown,
+	}, nil
+}
+
+// newPropagator propagates W3C trace context and baggage.
+func newPropagator() propagation.TextMapPropagator {
+	return propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+}
@@ -0,0 +1,57 @@
+-- +goose Up
+-- Canonicalize stored ISBNs to the hyphen-free ISBN-13 form the service now writes. Values
+-- that are not valid ISBNs, or whose canonical form another book already has or would get,
+-- are left untouched so the UNIQUE constraint holds.
+
+-- +goose StatementBegin
+CREATE FUNCTION normalize_isbn(value TEXT) RETURNS TEXT
+LANGUAGE plpgsql IMMUTABLE AS $$
+DECLARE
+    digits TEXT := upper(regexp_replace(value, '[\s-]', '', 'g'));
+    body TEXT;
+    total INT := 0;
+BEGIN
+    IF digits ~ '^[0-9]{9}[0-9X]$' THEN
+        FOR idx IN 1..10 LOOP
+            total := total + (11 - idx) *
+                CASE WHEN substr(digits, idx, 1) = 'X' THEN 10 ELSE substr(digits, idx, 1)::INT END;
+        END LOOP;
+        IF total % 11 <> 0 THEN
+            RETURN NULL;
+        END IF;
+        body := '978' || left(digits, 9);
+    ELSIF digits ~ '^97[89][0-9]{10}$' THEN
+        body := left(digits, 12);
+    ELSE
+        RETURN NULL;
+    END IF;
+
+    total := 0;
+    FOR idx IN 1..12 LOOP
+        total := total + CASE WHEN idx % 2 = 1 THEN 1 ELSE 3 END * substr(body, idx, 1)::INT;
+    END LOOP;
+    body := body || ((10 - total % 10) % 10)::TEXT;
+    IF length(digits) = 13 AND body <> digits THEN
+        RETURN NULL;
+    END IF;
+    RETURN body;
+END;
+$$;
+-- +goose StatementEnd
+
+UPDATE books
+SET isbn = normalized.isbn, updated_at = NOW()
+FROM (
+    SELECT id, normalize_isbn(isbn) AS isbn, COUNT(*) OVER (PARTITION BY normalize_isbn(isbn)) AS sharing
+    FROM books
+) normalized
+WHERE books.id = normalized.id
+  AND normalized.isbn IS NOT NULL
+  AND normalized.isbn <> books.isbn
+  AND normalized.sharing = 1;
+
+DROP FUNCTION normalize_isbn(TEXT);
+
+-- +goose Down
+-- The original formatting is not kept, so there is nothing to undo.
+SELECT 1;
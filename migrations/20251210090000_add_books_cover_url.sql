@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE books ADD COLUMN IF NOT EXISTS cover_url TEXT;
+
+-- +goose Down
+ALTER TABLE books DROP COLUMN IF EXISTS cover_url;
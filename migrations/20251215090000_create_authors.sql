@@ -0,0 +1,37 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS authors (
+    id UUID PRIMARY KEY,
+    name TEXT NOT NULL CHECK (btrim(name) <> ''),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_authors_name_lower ON authors (lower(name));
+
+CREATE TABLE IF NOT EXISTS book_authors (
+    book_id UUID NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+    author_id UUID NOT NULL REFERENCES authors (id) ON DELETE RESTRICT,
+    role TEXT NOT NULL CHECK (role IN ('author', 'editor', 'translator', 'illustrator')),
+    ordinal INT NOT NULL CHECK (ordinal > 0),
+    PRIMARY KEY (book_id, author_id, role),
+    UNIQUE (book_id, ordinal)
+);
+
+CREATE INDEX IF NOT EXISTS idx_book_authors_author_id ON book_authors (author_id);
+
+-- Backfill one author per distinct free-text author (ignoring case and repeated whitespace)
+-- and credit each book to it.
+INSERT INTO authors (id, name, created_at, updated_at)
+SELECT gen_random_uuid(), min(btrim(regexp_replace(author, '\s+', ' ', 'g'))), NOW(), NOW()
+FROM books
+WHERE btrim(regexp_replace(author, '\s+', ' ', 'g')) <> ''
+GROUP BY lower(btrim(regexp_replace(author, '\s+', ' ', 'g')));
+
+INSERT INTO book_authors (book_id, author_id, role, ordinal)
+SELECT books.id, authors.id, 'author', 1
+FROM books
+JOIN authors ON lower(authors.name) = lower(btrim(regexp_replace(books.author, '\s+', ' ', 'g')));
+
+-- +goose Down
+DROP TABLE IF EXISTS book_authors;
+DROP TABLE IF EXISTS authors;
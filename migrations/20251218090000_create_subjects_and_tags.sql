@@ -0,0 +1,31 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS subjects (
+    id UUID PRIMARY KEY,
+    name TEXT NOT NULL CHECK (btrim(name) <> ''),
+    parent_id UUID REFERENCES subjects (id) ON DELETE RESTRICT CHECK (parent_id <> id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_subjects_parent_name ON subjects (parent_id, lower(name)) NULLS NOT DISTINCT;
+
+CREATE TABLE IF NOT EXISTS book_subjects (
+    book_id UUID NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+    subject_id UUID NOT NULL REFERENCES subjects (id) ON DELETE RESTRICT,
+    PRIMARY KEY (book_id, subject_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_book_subjects_subject_id ON book_subjects (subject_id);
+
+CREATE TABLE IF NOT EXISTS book_tags (
+    book_id UUID NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+    tag TEXT NOT NULL CHECK (tag <> '' AND tag = lower(tag)),
+    PRIMARY KEY (book_id, tag)
+);
+
+CREATE INDEX IF NOT EXISTS idx_book_tags_tag ON book_tags (tag);
+
+-- +goose Down
+DROP TABLE IF EXISTS book_tags;
+DROP TABLE IF EXISTS book_subjects;
+DROP TABLE IF EXISTS subjects;
@@ -0,0 +1,28 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS works (
+    id UUID PRIMARY KEY,
+    title TEXT NOT NULL CHECK (btrim(title) <> ''),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE books
+    ADD COLUMN IF NOT EXISTS work_id UUID REFERENCES works (id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS publisher TEXT,
+    ADD COLUMN IF NOT EXISTS edition TEXT,
+    ADD COLUMN IF NOT EXISTS format TEXT CHECK (format IN ('hardcover', 'paperback', 'ebook', 'audiobook')),
+    ADD COLUMN IF NOT EXISTS language TEXT,
+    ADD COLUMN IF NOT EXISTS page_count INT CHECK (page_count > 0);
+
+CREATE INDEX IF NOT EXISTS idx_books_work_id ON books (work_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_books_work_id;
+ALTER TABLE books
+    DROP COLUMN IF EXISTS page_count,
+    DROP COLUMN IF EXISTS language,
+    DROP COLUMN IF EXISTS format,
+    DROP COLUMN IF EXISTS edition,
+    DROP COLUMN IF EXISTS publisher,
+    DROP COLUMN IF EXISTS work_id;
+DROP TABLE IF EXISTS works;
@@ -0,0 +1,42 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS branches (
+    id UUID PRIMARY KEY,
+    code TEXT NOT NULL UNIQUE CHECK (code ~ '^[a-z0-9][a-z0-9-]*$'),
+    name TEXT NOT NULL CHECK (btrim(name) <> ''),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS branch_holdings (
+    book_id UUID NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+    branch_id UUID NOT NULL REFERENCES branches (id) ON DELETE RESTRICT,
+    copies_total INT NOT NULL CHECK (copies_total >= 0),
+    copies_available INT NOT NULL CHECK (copies_available >= 0 AND copies_available <= copies_total),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (book_id, branch_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_branch_holdings_branch_available ON branch_holdings (branch_id) WHERE copies_available > 0;
+
+CREATE TABLE IF NOT EXISTS transfers (
+    id UUID PRIMARY KEY,
+    book_id UUID NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+    from_branch_id UUID NOT NULL REFERENCES branches (id) ON DELETE RESTRICT,
+    to_branch_id UUID NOT NULL REFERENCES branches (id) ON DELETE RESTRICT,
+    copies INT NOT NULL CHECK (copies > 0),
+    status TEXT NOT NULL CHECK (status IN ('requested', 'in_transit', 'received', 'cancelled')),
+    requested_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    shipped_at TIMESTAMPTZ,
+    received_at TIMESTAMPTZ,
+    cancelled_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (from_branch_id <> to_branch_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_transfers_book_id ON transfers (book_id);
+CREATE INDEX IF NOT EXISTS idx_transfers_open ON transfers (status, requested_at DESC) WHERE status IN ('requested', 'in_transit');
+
+-- +goose Down
+DROP TABLE IF EXISTS transfers;
+DROP TABLE IF EXISTS branch_holdings;
+DROP TABLE IF EXISTS branches;
@@ -0,0 +1,18 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS inventory_ledger (
+    id UUID PRIMARY KEY,
+    book_id UUID NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+    total_delta INT NOT NULL,
+    available_delta INT NOT NULL,
+    reason TEXT NOT NULL CHECK (reason IN ('acquired', 'found', 'lost', 'damaged', 'withdrawn', 'checked_out', 'returned', 'correction')),
+    note TEXT,
+    copies_total INT NOT NULL,
+    copies_available INT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (total_delta <> 0 OR available_delta <> 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_inventory_ledger_book_id ON inventory_ledger (book_id, created_at DESC);
+
+-- +goose Down
+DROP TABLE IF EXISTS inventory_ledger;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS locked_until TIMESTAMPTZ;
+
+-- +goose Down
+ALTER TABLE idempotency_keys DROP COLUMN IF EXISTS locked_until;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS claim_token TEXT;
+
+-- +goose Down
+ALTER TABLE idempotency_keys DROP COLUMN IF EXISTS claim_token;
@@ -0,0 +1,10 @@
+// Package migrations embeds the goose SQL migrations so binaries do not depend on the
+// working directory.
+package migrations
+
+import "embed"
+
+// FS holds every *.sql migration at its root.
+//
+//go:embed *.sql
+var FS embed.FS